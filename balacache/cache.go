@@ -0,0 +1,286 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package balacache inspects and cleans the bala cache written by the Central client, which holds the extracted
+// balas of packages as <org>/<name>/<version>/<platform> directories, marked with nightly.build and deprecated.txt
+// files.
+package balacache
+
+import (
+	"cmp"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"ballerina-lang-go/centralclient"
+	"ballerina-lang-go/common/bfs"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// tempSuffix is the suffix of the <version>_temp directories a pull extracts a bala into.
+const tempSuffix = "_temp"
+
+// OrphanedTempAge is the age from which a <version>_temp directory is assumed to be left by a failed pull, rather
+// than to belong to a pull in progress.
+const OrphanedTempAge = time.Hour
+
+// Entry is a package installed in the bala cache for one platform.
+type Entry struct {
+	Org      string
+	Name     string
+	Version  string
+	Platform string
+	// Path is the directory of the entry in the filesystem of the cache.
+	Path string
+	// Size is the total size of the files of the entry.
+	Size               int64
+	Nightly            bool
+	Deprecated         bool
+	DeprecationMessage string
+}
+
+func (e Entry) String() string {
+	return fmt.Sprintf("%s/%s:%s (%s)", e.Org, e.Name, e.Version, e.Platform)
+}
+
+// Problem is a difference between an entry and the bala it was extracted from, found by Manager.Verify.
+type Problem struct {
+	Entry   Entry
+	Message string
+}
+
+// PrunePolicy selects the entries removed by Manager.Prune.
+type PrunePolicy struct {
+	// Nightly removes the entries pulled by nightly builds.
+	Nightly bool
+	// KeepLatest, if positive, removes all but the KeepLatest latest versions of each package.
+	KeepLatest int
+	// DryRun reports what would be removed without removing it.
+	DryRun bool
+}
+
+// PruneResult is what Manager.Prune removed, or would remove on a dry run.
+type PruneResult struct {
+	Removed  []Entry
+	TempDirs []string
+}
+
+// Manager manages the bala cache in a directory of a filesystem.
+type Manager interface {
+	// List returns the entries of the cache, sorted by package and version.
+	List() ([]Entry, error)
+	// Verify checks that the files of each entry match the bala they were extracted from.
+	Verify() ([]Problem, error)
+	// Prune removes the entries selected by policy and the orphaned <version>_temp directories.
+	Prune(policy PrunePolicy) (PruneResult, error)
+	// Remove removes the versions of a package, all of them when version is empty, or all the packages of org when
+	// name is empty too. It returns the removed entries, and an error if org is empty, if version is given without a
+	// name, or if nothing matches.
+	Remove(org, name, version string) ([]Entry, error)
+	// CleanTemp removes the <version>_temp directories older than OrphanedTempAge and returns their paths.
+	CleanTemp() ([]string, error)
+}
+
+type manager struct {
+	fsys fs.FS
+	dir  string
+	now  func() time.Time
+}
+
+// NewManager returns a manager of the bala cache at dir in fsys.
+func NewManager(fsys fs.FS, dir string) Manager {
+	return &manager{fsys: fsys, dir: dir, now: time.Now}
+}
+
+func (m *manager) List() ([]Entry, error) {
+	var entries []Entry
+	err := m.walkVersions(func(org, name, version, path string) error {
+		platforms, err := fs.ReadDir(m.fsys, path)
+		if err != nil {
+			return err
+		}
+		for _, platform := range platforms {
+			if !platform.IsDir() {
+				continue
+			}
+			entry, err := m.entry(org, name, version, platform.Name(), filepath.Join(path, platform.Name()))
+			if err != nil {
+				return err
+			}
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(entries, func(a, b Entry) int {
+		return cmp.Or(
+			cmp.Compare(a.Org, b.Org),
+			cmp.Compare(a.Name, b.Name),
+			compareVersions(a.Version, b.Version),
+			cmp.Compare(a.Platform, b.Platform),
+		)
+	})
+	return entries, nil
+}
+
+func (m *manager) entry(org, name, version, platform, path string) (Entry, error) {
+	entry := Entry{Org: org, Name: name, Version: version, Platform: platform, Path: path}
+	err := fs.WalkDir(m.fsys, path, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		entry.Size += info.Size()
+		return nil
+	})
+	if err != nil {
+		return Entry{}, err
+	}
+
+	if _, err := fs.Stat(m.fsys, filepath.Join(path, centralclient.NightlyBuildMetaFileName)); err == nil {
+		entry.Nightly = true
+	}
+	if message, err := fs.ReadFile(m.fsys, filepath.Join(path, centralclient.DeprecatedMetaFileName)); err == nil {
+		entry.Deprecated = true
+		entry.DeprecationMessage = strings.TrimSpace(string(message))
+	}
+	return entry, nil
+}
+
+// walkVersions calls fn with the directory of each version of each package in the cache, skipping the
+// <version>_temp directories.
+func (m *manager) walkVersions(fn func(org, name, version, path string) error) error {
+	return m.walkPackages(func(org, name, path string) error {
+		versions, err := fs.ReadDir(m.fsys, path)
+		if err != nil {
+			return err
+		}
+		for _, version := range versions {
+			if version.IsDir() && !strings.HasSuffix(version.Name(), tempSuffix) {
+				if err := fn(org, name, version.Name(), filepath.Join(path, version.Name())); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// walkPackages calls fn with the directory of each package in the cache. A cache that does not exist is empty.
+func (m *manager) walkPackages(fn func(org, name, path string) error) error {
+	orgs, err := fs.ReadDir(m.fsys, m.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, org := range orgs {
+		if !org.IsDir() {
+			continue
+		}
+		orgPath := filepath.Join(m.dir, org.Name())
+		names, err := fs.ReadDir(m.fsys, orgPath)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			if name.IsDir() {
+				if err := fn(org.Name(), name.Name(), filepath.Join(orgPath, name.Name())); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (m *manager) Remove(org, name, version string) ([]Entry, error) {
+	if org == "" || (name == "" && version != "") {
+		return nil, fmt.Errorf("invalid package '%s': expected <org>[/<name>[:<version>]]", packagePattern(org, name, version))
+	}
+	entries, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []Entry
+	for _, entry := range entries {
+		if entry.Org == org && (name == "" || entry.Name == name) && (version == "" || entry.Version == version) {
+			removed = append(removed, entry)
+		}
+	}
+	if len(removed) == 0 {
+		return nil, fmt.Errorf("no package matching '%s' in the bala cache", packagePattern(org, name, version))
+	}
+
+	target := filepath.Join(m.dir, org, name, version)
+	if err := bfs.Remove(m.fsys, target); err != nil {
+		return nil, err
+	}
+	m.removeEmptyParents(target)
+	return removed, nil
+}
+
+// removeEmptyParents removes the directories of the cache above path that are left empty.
+func (m *manager) removeEmptyParents(path string) {
+	for dir := filepath.Dir(path); dir != m.dir && dir != "."; dir = filepath.Dir(dir) {
+		if entries, err := fs.ReadDir(m.fsys, dir); err != nil || len(entries) > 0 {
+			return
+		}
+		if err := bfs.Remove(m.fsys, dir); err != nil {
+			return
+		}
+	}
+}
+
+func packagePattern(org, name, version string) string {
+	pattern := org
+	if name != "" {
+		pattern += "/" + name
+	}
+	if version != "" {
+		pattern += ":" + version
+	}
+	return pattern
+}
+
+// compareVersions compares two versions by precedence, placing the names that are not versions after them.
+func compareVersions(a, b string) int {
+	va, errA := semver.StrictNewVersion(a)
+	vb, errB := semver.StrictNewVersion(b)
+	switch {
+	case errA == nil && errB == nil:
+		return va.Compare(vb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	default:
+		return cmp.Compare(a, b)
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package balacache
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"ballerina-lang-go/centralclient"
+	"ballerina-lang-go/common/bfs"
+)
+
+// testBalaFiles are the files of the balas of the test cache.
+var testBalaFiles = map[string]string{
+	"bala.json":            `{"bala_version": "2.0.0"}`,
+	"package.json":         `{"name": "winery"}`,
+	"modules/winery/a.bal": "public function main() {}",
+}
+
+// newTestCache returns a bala cache in the "bala" directory of a memFS with:
+//
+//	ballerina/io 1.6.0 (any, nightly)
+//	wso2/winery 1.0.0 (any), 1.1.0 (any, deprecated), 2.0.0 (any, java21)
+//	wso2/winery 2.1.0_temp
+func newTestCache(t *testing.T) fs.FS {
+	t.Helper()
+	memFS := bfs.NewMemFS()
+	writeTestEntry(t, memFS, "bala/ballerina/io/1.6.0/any", "io-any-1.6.0.bala")
+	writeTestEntry(t, memFS, "bala/wso2/winery/1.0.0/any", "winery-any-1.0.0.bala")
+	writeTestEntry(t, memFS, "bala/wso2/winery/1.1.0/any", "winery-any-1.1.0.bala")
+	writeTestEntry(t, memFS, "bala/wso2/winery/2.0.0/any", "winery-any-2.0.0.bala")
+	writeTestEntry(t, memFS, "bala/wso2/winery/2.0.0/java21", "")
+	writeTestEntry(t, memFS, "bala/wso2/winery/2.1.0_temp/any", "winery-any-2.1.0.bala")
+	writeFile(t, memFS, "bala/ballerina/io/1.6.0/any/"+centralclient.NightlyBuildMetaFileName, "")
+	writeFile(t, memFS, "bala/wso2/winery/1.1.0/any/"+centralclient.DeprecatedMetaFileName, "use 2.0.0\n")
+	return memFS
+}
+
+// writeTestEntry writes the files of an extracted bala into dir, along with the bala itself when balaName is set.
+func writeTestEntry(t *testing.T, fsys fs.FS, dir, balaName string) {
+	t.Helper()
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for name, content := range testBalaFiles {
+		writeFile(t, fsys, filepath.Join(dir, name), content)
+		w, err := writer.Create(name)
+		if err != nil {
+			t.Fatalf("failed to create entry %s: %v", name, err)
+		}
+		_, _ = w.Write([]byte(content))
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to write bala: %v", err)
+	}
+	if balaName != "" {
+		writeFile(t, fsys, filepath.Join(dir, balaName), buffer.String())
+	}
+}
+
+func writeFile(t *testing.T, fsys fs.FS, name, content string) {
+	t.Helper()
+	if err := bfs.WriteFile(fsys, name, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+}
+
+func entryNames(entries []Entry) []string {
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.String()
+	}
+	return names
+}
+
+func TestList(t *testing.T) {
+	fsys := newTestCache(t)
+	entries, err := NewManager(fsys, "bala").List()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"ballerina/io:1.6.0 (any)",
+		"wso2/winery:1.0.0 (any)",
+		"wso2/winery:1.1.0 (any)",
+		"wso2/winery:2.0.0 (any)",
+		"wso2/winery:2.0.0 (java21)",
+	}
+	if got := entryNames(entries); !slices.Equal(got, expected) {
+		t.Fatalf("expected entries %v, got %v", expected, got)
+	}
+
+	io, deprecated, java21 := entries[0], entries[2], entries[4]
+	if !io.Nightly || io.Deprecated || io.Path != "bala/ballerina/io/1.6.0/any" {
+		t.Errorf("unexpected nightly entry: %+v", io)
+	}
+	if !deprecated.Deprecated || deprecated.DeprecationMessage != "use 2.0.0" || deprecated.Nightly {
+		t.Errorf("unexpected deprecated entry: %+v", deprecated)
+	}
+	filesSize := int64(0)
+	for _, content := range testBalaFiles {
+		filesSize += int64(len(content))
+	}
+	if java21.Size != filesSize || entries[3].Size <= filesSize {
+		t.Errorf("expected sizes to count the files of the entries, got %d and %d", java21.Size, entries[3].Size)
+	}
+
+	if entries, err := NewManager(fsys, "missing").List(); err != nil || len(entries) != 0 {
+		t.Errorf("expected a missing cache to be empty, got %v, %v", entries, err)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	fsys := newTestCache(t)
+	manager := NewManager(fsys, "bala")
+
+	removed, err := manager.Remove("wso2", "winery", "2.0.0")
+	if err != nil || !slices.Equal(entryNames(removed), []string{"wso2/winery:2.0.0 (any)", "wso2/winery:2.0.0 (java21)"}) {
+		t.Fatalf("unexpected removed entries: %v, %v", removed, err)
+	}
+	if _, err := fs.Stat(fsys, "bala/wso2/winery/2.0.0"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected the version to be removed, got %v", err)
+	}
+
+	if _, err := manager.Remove("wso2", "winery", "3.0.0"); err == nil {
+		t.Error("expected an error for a package that is not in the cache")
+	}
+	if _, err := manager.Remove("wso2", "", "1.0.0"); err == nil {
+		t.Error("expected an error for a version without a package name")
+	}
+	if _, err := manager.Remove("", "", ""); err == nil {
+		t.Error("expected an error for an empty organization")
+	}
+
+	removed, err = manager.Remove("ballerina", "", "")
+	if err != nil || !slices.Equal(entryNames(removed), []string{"ballerina/io:1.6.0 (any)"}) {
+		t.Fatalf("unexpected removed entries: %v, %v", removed, err)
+	}
+	if _, err := fs.Stat(fsys, "bala/ballerina"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected the organization to be removed, got %v", err)
+	}
+
+	if _, err := manager.Remove("wso2", "winery", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entries, _ := fs.ReadDir(fsys, "bala"); len(entries) != 0 {
+		t.Errorf("expected empty directories to be removed, found %v", entries)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package balacache
+
+import (
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"ballerina-lang-go/common/bfs"
+)
+
+func (m *manager) Prune(policy PrunePolicy) (PruneResult, error) {
+	entries, err := m.List()
+	if err != nil {
+		return PruneResult{}, err
+	}
+
+	var result PruneResult
+	// Entries are sorted by package and version, so the versions of a package to keep are at the end of its run.
+	for start := 0; start < len(entries); {
+		end := start
+		for end < len(entries) && entries[end].Org == entries[start].Org && entries[end].Name == entries[start].Name {
+			end++
+		}
+		result.Removed = append(result.Removed, prunedEntries(entries[start:end], policy)...)
+		start = end
+	}
+
+	temps, err := m.orphanedTempDirs()
+	if err != nil {
+		return PruneResult{}, err
+	}
+	result.TempDirs = temps
+	if policy.DryRun {
+		return result, nil
+	}
+
+	for _, entry := range result.Removed {
+		if err := bfs.Remove(m.fsys, entry.Path); err != nil {
+			return PruneResult{}, err
+		}
+		m.removeEmptyParents(entry.Path)
+	}
+	if err := m.removeTempDirs(temps); err != nil {
+		return PruneResult{}, err
+	}
+	return result, nil
+}
+
+// prunedEntries returns the entries of a package, sorted by version, that policy removes.
+func prunedEntries(entries []Entry, policy PrunePolicy) []Entry {
+	var versions []string
+	for _, entry := range entries {
+		if !slices.Contains(versions, entry.Version) {
+			versions = append(versions, entry.Version)
+		}
+	}
+	oldVersions := 0
+	if policy.KeepLatest > 0 {
+		oldVersions = max(len(versions)-policy.KeepLatest, 0)
+	}
+
+	var pruned []Entry
+	for _, entry := range entries {
+		old := slices.Index(versions, entry.Version) < oldVersions
+		if old || policy.Nightly && entry.Nightly {
+			pruned = append(pruned, entry)
+		}
+	}
+	return pruned
+}
+
+func (m *manager) CleanTemp() ([]string, error) {
+	temps, err := m.orphanedTempDirs()
+	if err != nil {
+		return nil, err
+	}
+	if err := m.removeTempDirs(temps); err != nil {
+		return nil, err
+	}
+	return temps, nil
+}
+
+// orphanedTempDirs returns the <version>_temp directories that were not modified for OrphanedTempAge.
+func (m *manager) orphanedTempDirs() ([]string, error) {
+	var temps []string
+	err := m.walkPackages(func(org, name, path string) error {
+		versions, err := fs.ReadDir(m.fsys, path)
+		if err != nil {
+			return err
+		}
+		for _, version := range versions {
+			if !version.IsDir() || !strings.HasSuffix(version.Name(), tempSuffix) {
+				continue
+			}
+			info, err := version.Info()
+			if err != nil {
+				return err
+			}
+			if m.now().Sub(info.ModTime()) >= OrphanedTempAge {
+				temps = append(temps, filepath.Join(path, version.Name()))
+			}
+		}
+		return nil
+	})
+	return temps, err
+}
+
+func (m *manager) removeTempDirs(temps []string) error {
+	for _, temp := range temps {
+		if err := bfs.Remove(m.fsys, temp); err != nil {
+			return err
+		}
+		m.removeEmptyParents(temp)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package balacache
+
+import (
+	"errors"
+	"io/fs"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPrune(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       PrunePolicy
+		now          time.Time
+		wantRemoved  []string
+		wantTempDirs []string
+	}{
+		{
+			name:        "nightly",
+			policy:      PrunePolicy{Nightly: true},
+			now:         time.Now(),
+			wantRemoved: []string{"ballerina/io:1.6.0 (any)"},
+		},
+		{
+			name:   "keep latest",
+			policy: PrunePolicy{KeepLatest: 1},
+			now:    time.Now(),
+			wantRemoved: []string{
+				"wso2/winery:1.0.0 (any)",
+				"wso2/winery:1.1.0 (any)",
+			},
+		},
+		{
+			name:   "nightly and keep latest",
+			policy: PrunePolicy{Nightly: true, KeepLatest: 2},
+			now:    time.Now(),
+			wantRemoved: []string{
+				"ballerina/io:1.6.0 (any)",
+				"wso2/winery:1.0.0 (any)",
+			},
+		},
+		{
+			name:         "orphaned temp directories",
+			now:          time.Now().Add(2 * OrphanedTempAge),
+			wantTempDirs: []string{"bala/wso2/winery/2.1.0_temp"},
+		},
+	}
+
+	for _, test := range tests {
+		for _, dryRun := range []bool{false, true} {
+			name := test.name
+			if dryRun {
+				name += " dry run"
+			}
+			t.Run(name, func(t *testing.T) {
+				fsys := newTestCache(t)
+				cache := NewManager(fsys, "bala")
+				cache.(*manager).now = func() time.Time { return test.now }
+				policy := test.policy
+				policy.DryRun = dryRun
+
+				result, err := cache.Prune(policy)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if got := entryNames(result.Removed); !slices.Equal(got, test.wantRemoved) {
+					t.Errorf("expected removed entries %v, got %v", test.wantRemoved, got)
+				}
+				if !slices.Equal(result.TempDirs, test.wantTempDirs) {
+					t.Errorf("expected temp directories %v, got %v", test.wantTempDirs, result.TempDirs)
+				}
+
+				for _, entry := range result.Removed {
+					if _, err := fs.Stat(fsys, entry.Path); errors.Is(err, fs.ErrNotExist) == dryRun {
+						t.Errorf("unexpected state of %s after the prune: %v", entry, err)
+					}
+				}
+				for _, temp := range result.TempDirs {
+					if _, err := fs.Stat(fsys, temp); errors.Is(err, fs.ErrNotExist) == dryRun {
+						t.Errorf("unexpected state of %s after the prune: %v", temp, err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestCleanTemp(t *testing.T) {
+	fsys := newTestCache(t)
+	cache := NewManager(fsys, "bala")
+	if temps, err := cache.CleanTemp(); err != nil || len(temps) != 0 {
+		t.Errorf("expected a recent temp directory to be kept, got %v, %v", temps, err)
+	}
+
+	cache.(*manager).now = func() time.Time { return time.Now().Add(OrphanedTempAge) }
+	temps, err := cache.CleanTemp()
+	if err != nil || !slices.Equal(temps, []string{"bala/wso2/winery/2.1.0_temp"}) {
+		t.Fatalf("unexpected temp directories: %v, %v", temps, err)
+	}
+	if _, err := fs.Stat(fsys, temps[0]); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected the temp directory to be removed, got %v", err)
+	}
+	if entries, err := cache.List(); err != nil || len(entries) != 5 {
+		t.Errorf("expected the entries to be kept, got %d, %v", len(entries), err)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package balacache
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// balaMetadataFileName is the file every bala has at its root.
+const balaMetadataFileName = "bala.json"
+
+func (m *manager) Verify() ([]Problem, error) {
+	entries, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []Problem
+	for _, entry := range entries {
+		for _, message := range m.verifyEntry(entry) {
+			problems = append(problems, Problem{Entry: entry, Message: message})
+		}
+	}
+	return problems, nil
+}
+
+// verifyEntry compares the files of entry with the entries of its bala. An entry without a bala, such as one copied
+// from a local repository, is only checked for its bala.json.
+func (m *manager) verifyEntry(entry Entry) []string {
+	files, err := fs.ReadDir(m.fsys, entry.Path)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	balaName := ""
+	for _, file := range files {
+		if !file.IsDir() && strings.HasSuffix(file.Name(), ".bala") {
+			balaName = file.Name()
+			break
+		}
+	}
+	if balaName == "" {
+		if _, err := fs.Stat(m.fsys, filepath.Join(entry.Path, balaMetadataFileName)); err != nil {
+			return []string{fmt.Sprintf("missing %s", balaMetadataFileName)}
+		}
+		return nil
+	}
+
+	reader, err := m.openBala(filepath.Join(entry.Path, balaName))
+	if err != nil {
+		return []string{fmt.Sprintf("corrupt bala %s: %v", balaName, err)}
+	}
+	var problems []string
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		name := path.Clean(strings.ReplaceAll(file.Name, "\\", "/"))
+		data, err := fs.ReadFile(m.fsys, filepath.Join(entry.Path, filepath.FromSlash(name)))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("missing file %s", name))
+			continue
+		}
+		if crc32.ChecksumIEEE(data) != file.CRC32 || uint64(len(data)) != file.UncompressedSize64 {
+			problems = append(problems, fmt.Sprintf("modified file %s", name))
+		}
+	}
+	return problems
+}
+
+func (m *manager) openBala(balaPath string) (*zip.Reader, error) {
+	file, err := m.fsys.Open(balaPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if readerAt, ok := file.(io.ReaderAt); ok {
+		return zip.NewReader(readerAt, info.Size())
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	return zip.NewReader(bytes.NewReader(data), int64(len(data)))
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package balacache
+
+import (
+	"slices"
+	"testing"
+
+	"ballerina-lang-go/common/bfs"
+)
+
+func TestVerify(t *testing.T) {
+	fsys := newTestCache(t)
+	manager := NewManager(fsys, "bala")
+	if problems, err := manager.Verify(); err != nil || len(problems) != 0 {
+		t.Fatalf("expected an intact cache, got %v, %v", problems, err)
+	}
+
+	writeFile(t, fsys, "bala/wso2/winery/1.0.0/any/modules/winery/a.bal", "public function main() { panic error(\"x\"); }")
+	if err := bfs.Remove(fsys, "bala/wso2/winery/1.1.0/any/package.json"); err != nil {
+		t.Fatalf("failed to remove: %v", err)
+	}
+	writeFile(t, fsys, "bala/ballerina/io/1.6.0/any/io-any-1.6.0.bala", "not a zip")
+	if err := bfs.Remove(fsys, "bala/wso2/winery/2.0.0/java21/bala.json"); err != nil {
+		t.Fatalf("failed to remove: %v", err)
+	}
+
+	problems, err := manager.Verify()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, problem := range problems {
+		got = append(got, problem.Entry.String()+": "+problem.Message)
+	}
+	expected := []string{
+		"ballerina/io:1.6.0 (any): corrupt bala io-any-1.6.0.bala: zip: not a valid zip file",
+		"wso2/winery:1.0.0 (any): modified file modules/winery/a.bal",
+		"wso2/winery:1.1.0 (any): missing file package.json",
+		"wso2/winery:2.0.0 (java21): missing bala.json",
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("expected problems:\n%q\ngot:\n%q", expected, got)
+	}
+}
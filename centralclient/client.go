@@ -18,11 +18,15 @@ package centralclient
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/fs"
+	"iter"
+	"maps"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -34,19 +38,42 @@ import (
 	"ballerina-lang-go/centralclient/models"
 )
 
+// CentralAPIClient is a client of the Central API. Each method has a Ctx variant that takes a context which cancels
+// the requests to Central; a canceled call returns the error of the context. The other methods use
+// context.Background(). PullPackages, which pulls several packages concurrently, always takes a context.
 type CentralAPIClient interface {
 	GetPackage(orgNamePath, packageNamePath, version, supportedPlatform, ballerinaVersion string) (*models.Package, error)
+	GetPackageCtx(ctx context.Context, orgNamePath, packageNamePath, version, supportedPlatform, ballerinaVersion string) (*models.Package, error)
 	GetPackageVersions(orgNamePath, packageNamePath, supportedPlatform, ballerinaVersion string) ([]string, error)
+	GetPackageVersionsCtx(ctx context.Context, orgNamePath, packageNamePath, supportedPlatform, ballerinaVersion string) ([]string, error)
 	PullPackage(org, name, version string, fsys fs.FS, packagePathInBalaCache, supportedPlatform, ballerinaVersion string, clientContext ClientContext) error
+	PullPackageCtx(ctx context.Context, org, name, version string, fsys fs.FS, packagePathInBalaCache, supportedPlatform, ballerinaVersion string, clientContext ClientContext) error
+	PullPackages(ctx context.Context, refs []PackageRef, opts PullOptions) map[PackageRef]error
+	PushPackage(balaPath, org, name, version string, fsys fs.FS, supportedPlatform, ballerinaVersion string, clientContext ClientContext) error
+	PushPackageCtx(ctx context.Context, balaPath, org, name, version string, fsys fs.FS, supportedPlatform, ballerinaVersion string, clientContext ClientContext) error
+	SearchPackages(query PackageSearchQuery, limit, offset int, sort, supportedPlatform, ballerinaVersion string) (*models.PackageSearchResult, error)
+	SearchPackagesCtx(ctx context.Context, query PackageSearchQuery, limit, offset int, sort, supportedPlatform, ballerinaVersion string) (*models.PackageSearchResult, error)
+	SearchAllPackages(query PackageSearchQuery, pageSize int, sort, supportedPlatform, ballerinaVersion string) iter.Seq2[models.Package, error]
+	SearchAllPackagesCtx(ctx context.Context, query PackageSearchQuery, pageSize int, sort, supportedPlatform, ballerinaVersion string) iter.Seq2[models.Package, error]
 	ResolvePackageNames(request models.PackageNameResolutionRequest, supportedPlatform, ballerinaVersion string) (*models.PackageNameResolutionResponse, error)
+	ResolvePackageNamesCtx(ctx context.Context, request models.PackageNameResolutionRequest, supportedPlatform, ballerinaVersion string) (*models.PackageNameResolutionResponse, error)
 	ResolveDependencies(request models.PackageResolutionRequest, supportedPlatform, ballerinaVersion string) (*models.PackageResolutionResponse, error)
+	ResolveDependenciesCtx(ctx context.Context, request models.PackageResolutionRequest, supportedPlatform, ballerinaVersion string) (*models.PackageResolutionResponse, error)
 	GetConnectors(params map[string]string, supportedPlatform, ballerinaVersion string) (any, error)
+	GetConnectorsCtx(ctx context.Context, params map[string]string, supportedPlatform, ballerinaVersion string) (any, error)
 	GetConnector(id, supportedPlatform, ballerinaVersion string) (map[string]any, error)
+	GetConnectorCtx(ctx context.Context, id, supportedPlatform, ballerinaVersion string) (map[string]any, error)
 	GetConnectorByInfo(connector models.ConnectorInfo, supportedPlatform, ballerinaVersion string) (map[string]any, error)
+	GetConnectorByInfoCtx(ctx context.Context, connector models.ConnectorInfo, supportedPlatform, ballerinaVersion string) (map[string]any, error)
 	GetTriggers(params map[string]string, supportedPlatform, ballerinaVersion string) (any, error)
+	GetTriggersCtx(ctx context.Context, params map[string]string, supportedPlatform, ballerinaVersion string) (any, error)
 	GetTrigger(id, supportedPlatform, ballerinaVersion string) (map[string]any, error)
+	GetTriggerCtx(ctx context.Context, id, supportedPlatform, ballerinaVersion string) (map[string]any, error)
 	AccessToken() string
 	SetAccessToken(token string)
+	SetDigestPolicy(policy DigestPolicy)
+	SetPinnedDigests(digests map[string]string)
+	SetResponseCache(options *ResponseCacheOptions)
 }
 
 type centralAPIClientImpl struct {
@@ -61,6 +88,10 @@ type centralAPIClientImpl struct {
 	callTimeout    time.Duration
 	maxRetries     int
 	httpClient     http.Client
+	digestPolicy   DigestPolicy
+	pinnedDigests  map[string]string
+	responseCache  *responseCache
+	pulls          pullGroup
 }
 
 type ClientContext struct {
@@ -78,7 +109,8 @@ func (c *ClientContext) formatLog(message string) string {
 }
 
 func NewCentralAPIClient(baseURL string, proxyURL string, accessToken string) CentralAPIClient {
-	httpClient := buildHTTPClient(baseURL, proxyURL, "", "", DefaultCallTimeout*time.Second, MaxRetry)
+	httpClient := buildHTTPClient(baseURL, proxyURL, "", "", DefaultConnectTimeout*time.Second, DefaultReadTimeout*time.Second,
+		DefaultWriteTimeout*time.Second, DefaultCallTimeout*time.Second, MaxRetry)
 	return &centralAPIClientImpl{
 		baseURL:        baseURL,
 		proxyURL:       proxyURL,
@@ -93,7 +125,8 @@ func NewCentralAPIClient(baseURL string, proxyURL string, accessToken string) Ce
 }
 
 func NewCentralAPIClientFull(baseURL string, proxyURL string, proxyUsername, proxyPassword, accessToken string, connectTimeout, readTimeout, writeTimeout, callTimeout, maxRetries int) CentralAPIClient {
-	httpClient := buildHTTPClient(baseURL, proxyURL, proxyUsername, proxyPassword, time.Duration(callTimeout)*time.Second, maxRetries)
+	httpClient := buildHTTPClient(baseURL, proxyURL, proxyUsername, proxyPassword, time.Duration(connectTimeout)*time.Second,
+		time.Duration(readTimeout)*time.Second, time.Duration(writeTimeout)*time.Second, time.Duration(callTimeout)*time.Second, maxRetries)
 	return &centralAPIClientImpl{
 		baseURL:        baseURL,
 		proxyURL:       proxyURL,
@@ -110,8 +143,15 @@ func NewCentralAPIClientFull(baseURL string, proxyURL string, proxyUsername, pro
 }
 
 func (c *centralAPIClientImpl) GetPackage(orgNamePath, packageNamePath, version, supportedPlatform, ballerinaVersion string) (*models.Package, error) {
-	pkg, err := c.getPackageInternal(orgNamePath, packageNamePath, version, supportedPlatform, ballerinaVersion)
+	return c.GetPackageCtx(context.Background(), orgNamePath, packageNamePath, version, supportedPlatform, ballerinaVersion)
+}
+
+func (c *centralAPIClientImpl) GetPackageCtx(ctx context.Context, orgNamePath, packageNamePath, version, supportedPlatform, ballerinaVersion string) (*models.Package, error) {
+	pkg, err := c.getPackageInternal(ctx, orgNamePath, packageNamePath, version, supportedPlatform, ballerinaVersion)
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
 		switch err.(type) {
 		case *NoPackageError, *CentralClientError:
 			return nil, err
@@ -124,7 +164,7 @@ func (c *centralAPIClientImpl) GetPackage(orgNamePath, packageNamePath, version,
 	return pkg, nil
 }
 
-func (c *centralAPIClientImpl) getPackageInternal(orgNamePath, packageNamePath, version, supportedPlatform, ballerinaVersion string) (*models.Package, error) {
+func (c *centralAPIClientImpl) getPackageInternal(ctx context.Context, orgNamePath, packageNamePath, version, supportedPlatform, ballerinaVersion string) (*models.Package, error) {
 	resourceURL := fmt.Sprintf("%s%s%s%s", PackagePathPrefix, orgNamePath, Separator, packageNamePath)
 
 	urlStr := fmt.Sprintf("%s%s", c.baseURL, resourceURL)
@@ -132,14 +172,14 @@ func (c *centralAPIClientImpl) getPackageInternal(orgNamePath, packageNamePath,
 		urlStr = fmt.Sprintf("%s/%s", urlStr, version)
 	}
 
-	req, err := c.newRequest(http.MethodGet, urlStr, supportedPlatform, ballerinaVersion, nil)
+	req, err := c.newRequest(ctx, http.MethodGet, urlStr, supportedPlatform, ballerinaVersion, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	c.logRequestInitVerbose(req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doCached(req)
 	if err != nil {
 		return nil, err
 	}
@@ -194,27 +234,34 @@ func (c *centralAPIClientImpl) getPackageInternal(orgNamePath, packageNamePath,
 }
 
 func (c *centralAPIClientImpl) GetPackageVersions(orgNamePath, packageNamePath, supportedPlatform, ballerinaVersion string) ([]string, error) {
-	versions, err := c.getPackageVersionsInternal(orgNamePath, packageNamePath, supportedPlatform, ballerinaVersion)
+	return c.GetPackageVersionsCtx(context.Background(), orgNamePath, packageNamePath, supportedPlatform, ballerinaVersion)
+}
+
+func (c *centralAPIClientImpl) GetPackageVersionsCtx(ctx context.Context, orgNamePath, packageNamePath, supportedPlatform, ballerinaVersion string) ([]string, error) {
+	versions, err := c.getPackageVersionsInternal(ctx, orgNamePath, packageNamePath, supportedPlatform, ballerinaVersion)
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
 		return nil, wrapCentralClientError(err, fmt.Sprintf("%s%s", ErrCannotFindVersions, getPackageSignature(orgNamePath, packageNamePath, "")))
 	}
 
 	return versions, nil
 }
 
-func (c *centralAPIClientImpl) getPackageVersionsInternal(orgNamePath, packageNamePath, supportedPlatform, ballerinaVersion string) ([]string, error) {
+func (c *centralAPIClientImpl) getPackageVersionsInternal(ctx context.Context, orgNamePath, packageNamePath, supportedPlatform, ballerinaVersion string) ([]string, error) {
 	resourceURL := fmt.Sprintf("%s%s%s%s", PackagePathPrefix, orgNamePath, Separator, packageNamePath)
 
 	urlStr := fmt.Sprintf("%s%s", c.baseURL, resourceURL)
 
-	req, err := c.newRequest(http.MethodGet, urlStr, supportedPlatform, ballerinaVersion, nil)
+	req, err := c.newRequest(ctx, http.MethodGet, urlStr, supportedPlatform, ballerinaVersion, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	c.logRequestInitVerbose(req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doCached(req)
 	if err != nil {
 		return nil, err
 	}
@@ -272,14 +319,24 @@ func (c *centralAPIClientImpl) getPackageVersionsInternal(orgNamePath, packageNa
 }
 
 func (c *centralAPIClientImpl) PullPackage(org, name, version string, fsys fs.FS, packagePathInBalaCache, supportedPlatform, ballerinaVersion string, clientContext ClientContext) error {
+	return c.PullPackageCtx(context.Background(), org, name, version, fsys, packagePathInBalaCache, supportedPlatform, ballerinaVersion, clientContext)
+}
+
+// PullPackageCtx retries a pull whose download is cut off while the bala is read. Failures to send a request or to
+// receive its response are retried by the transport of the client.
+func (c *centralAPIClientImpl) PullPackageCtx(ctx context.Context, org, name, version string, fsys fs.FS, packagePathInBalaCache, supportedPlatform, ballerinaVersion string, clientContext ClientContext) error {
 	for retryCount := 0; retryCount <= c.maxRetries; retryCount++ {
-		err := c.pullPackageInternal(org, name, version, fsys, packagePathInBalaCache, supportedPlatform, ballerinaVersion, clientContext)
+		err := c.pullPackageInternal(ctx, org, name, version, fsys, packagePathInBalaCache, supportedPlatform, ballerinaVersion, clientContext)
 
 		if err == nil {
 			return nil
 		}
 
-		if !strings.Contains(err.Error(), ConnectionReset) {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+
+		if !isBodyConnectionError(err) {
 			return err
 		}
 
@@ -295,16 +352,46 @@ func (c *centralAPIClientImpl) PullPackage(org, name, version string, fsys fs.FS
 	return nil
 }
 
+// PushPackage uploads the bala at balaPath in fsys to Central as org/name:version. Upload progress is reported
+// through clientContext.OnProgress; a *PackageAlreadyExistsError is returned when the version is already published.
+func (c *centralAPIClientImpl) PushPackage(balaPath, org, name, version string, fsys fs.FS, supportedPlatform, ballerinaVersion string, clientContext ClientContext) error {
+	return c.PushPackageCtx(context.Background(), balaPath, org, name, version, fsys, supportedPlatform, ballerinaVersion, clientContext)
+}
+
+func (c *centralAPIClientImpl) PushPackageCtx(ctx context.Context, balaPath, org, name, version string, fsys fs.FS, supportedPlatform, ballerinaVersion string, clientContext ClientContext) error {
+	err := c.pushPackageInternal(ctx, balaPath, org, name, version, fsys, supportedPlatform, ballerinaVersion, clientContext)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		switch err.(type) {
+		case *CentralClientError, *PackageAlreadyExistsError:
+			return err
+		default:
+			return NewCentralClientError(clientContext.formatLog(fmt.Sprintf("%s'%s'. reason: %s", ErrCannotPush, getPackageSignature(org, name, version), err.Error())))
+		}
+	}
+
+	return nil
+}
+
 func (c *centralAPIClientImpl) ResolvePackageNames(request models.PackageNameResolutionRequest, supportedPlatform, ballerinaVersion string) (*models.PackageNameResolutionResponse, error) {
-	response, err := c.resolvePackageNamesInternal(request, supportedPlatform, ballerinaVersion)
+	return c.ResolvePackageNamesCtx(context.Background(), request, supportedPlatform, ballerinaVersion)
+}
+
+func (c *centralAPIClientImpl) ResolvePackageNamesCtx(ctx context.Context, request models.PackageNameResolutionRequest, supportedPlatform, ballerinaVersion string) (*models.PackageNameResolutionResponse, error) {
+	response, err := c.resolvePackageNamesInternal(ctx, request, supportedPlatform, ballerinaVersion)
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
 		return nil, wrapCentralOrConnectionError(err, ErrPackageResolution)
 	}
 
 	return response, nil
 }
 
-func (c *centralAPIClientImpl) resolvePackageNamesInternal(request models.PackageNameResolutionRequest, supportedPlatform, ballerinaVersion string) (*models.PackageNameResolutionResponse, error) {
+func (c *centralAPIClientImpl) resolvePackageNamesInternal(ctx context.Context, request models.PackageNameResolutionRequest, supportedPlatform, ballerinaVersion string) (*models.PackageNameResolutionResponse, error) {
 	urlStr := fmt.Sprintf("%s%s%s", c.baseURL, PackagePathPrefix, ResolveModules)
 
 	bodyBytes, err := json.Marshal(request)
@@ -312,7 +399,7 @@ func (c *centralAPIClientImpl) resolvePackageNamesInternal(request models.Packag
 		return nil, NewCentralClientError(fmt.Sprintf("%s%s", ErrPackageResolution, err.Error()))
 	}
 
-	req, err := c.newRequest(http.MethodPost, urlStr, supportedPlatform, ballerinaVersion, bytes.NewReader(bodyBytes))
+	req, err := c.newRequest(ctx, http.MethodPost, urlStr, supportedPlatform, ballerinaVersion, bytes.NewReader(bodyBytes))
 	if err != nil {
 		return nil, NewCentralClientError(fmt.Sprintf("%s%s", ErrPackageResolution, err.Error()))
 	}
@@ -377,15 +464,22 @@ func (c *centralAPIClientImpl) resolvePackageNamesInternal(request models.Packag
 }
 
 func (c *centralAPIClientImpl) ResolveDependencies(request models.PackageResolutionRequest, supportedPlatform, ballerinaVersion string) (*models.PackageResolutionResponse, error) {
-	response, err := c.resolveDependenciesInternal(request, supportedPlatform, ballerinaVersion)
+	return c.ResolveDependenciesCtx(context.Background(), request, supportedPlatform, ballerinaVersion)
+}
+
+func (c *centralAPIClientImpl) ResolveDependenciesCtx(ctx context.Context, request models.PackageResolutionRequest, supportedPlatform, ballerinaVersion string) (*models.PackageResolutionResponse, error) {
+	response, err := c.resolveDependenciesInternal(ctx, request, supportedPlatform, ballerinaVersion)
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
 		return nil, wrapCentralOrConnectionError(err, ErrPackageResolution)
 	}
 
 	return response, nil
 }
 
-func (c *centralAPIClientImpl) resolveDependenciesInternal(request models.PackageResolutionRequest, supportedPlatform, ballerinaVersion string) (*models.PackageResolutionResponse, error) {
+func (c *centralAPIClientImpl) resolveDependenciesInternal(ctx context.Context, request models.PackageResolutionRequest, supportedPlatform, ballerinaVersion string) (*models.PackageResolutionResponse, error) {
 	urlStr := fmt.Sprintf("%s%s%s", c.baseURL, PackagePathPrefix, ResolveDependencies)
 
 	bodyBytes, err := json.Marshal(request)
@@ -393,7 +487,7 @@ func (c *centralAPIClientImpl) resolveDependenciesInternal(request models.Packag
 		return nil, err
 	}
 
-	req, err := c.newRequest(http.MethodPost, urlStr, supportedPlatform, ballerinaVersion, bytes.NewReader(bodyBytes))
+	req, err := c.newRequest(ctx, http.MethodPost, urlStr, supportedPlatform, ballerinaVersion, bytes.NewReader(bodyBytes))
 	if err != nil {
 		return nil, err
 	}
@@ -457,15 +551,22 @@ func (c *centralAPIClientImpl) resolveDependenciesInternal(request models.Packag
 }
 
 func (c *centralAPIClientImpl) GetConnectors(params map[string]string, supportedPlatform, ballerinaVersion string) (any, error) {
-	connectors, err := c.getConnectorsInternal(params, supportedPlatform, ballerinaVersion)
+	return c.GetConnectorsCtx(context.Background(), params, supportedPlatform, ballerinaVersion)
+}
+
+func (c *centralAPIClientImpl) GetConnectorsCtx(ctx context.Context, params map[string]string, supportedPlatform, ballerinaVersion string) (any, error) {
+	connectors, err := c.getConnectorsInternal(ctx, params, supportedPlatform, ballerinaVersion)
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
 		return nil, wrapCentralClientError(err, ErrCannotGetConnector)
 	}
 
 	return connectors, nil
 }
 
-func (c *centralAPIClientImpl) getConnectorsInternal(params map[string]string, supportedPlatform, ballerinaVersion string) (any, error) {
+func (c *centralAPIClientImpl) getConnectorsInternal(ctx context.Context, params map[string]string, supportedPlatform, ballerinaVersion string) (any, error) {
 	baseURL, err := url.Parse(c.baseURL)
 	if err != nil {
 		return nil, err
@@ -478,14 +579,14 @@ func (c *centralAPIClientImpl) getConnectorsInternal(params map[string]string, s
 	}
 	baseURL.RawQuery = query.Encode()
 
-	req, err := c.newRequest(http.MethodGet, baseURL.String(), supportedPlatform, ballerinaVersion, nil)
+	req, err := c.newRequest(ctx, http.MethodGet, baseURL.String(), supportedPlatform, ballerinaVersion, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	c.logRequestInitVerbose(req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doCached(req)
 	if err != nil {
 		return nil, err
 	}
@@ -513,18 +614,25 @@ func (c *centralAPIClientImpl) getConnectorsInternal(params map[string]string, s
 }
 
 func (c *centralAPIClientImpl) GetConnector(id, supportedPlatform, ballerinaVersion string) (map[string]any, error) {
-	connector, err := c.getConnectorInternal(id, supportedPlatform, ballerinaVersion)
+	return c.GetConnectorCtx(context.Background(), id, supportedPlatform, ballerinaVersion)
+}
+
+func (c *centralAPIClientImpl) GetConnectorCtx(ctx context.Context, id, supportedPlatform, ballerinaVersion string) (map[string]any, error) {
+	connector, err := c.getConnectorInternal(ctx, id, supportedPlatform, ballerinaVersion)
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
 		return nil, wrapCentralClientError(err, fmt.Sprintf("%sid: %s", ErrCannotGetConnector, id))
 	}
 
 	return connector, nil
 }
 
-func (c *centralAPIClientImpl) getConnectorInternal(id, supportedPlatform, ballerinaVersion string) (map[string]any, error) {
+func (c *centralAPIClientImpl) getConnectorInternal(ctx context.Context, id, supportedPlatform, ballerinaVersion string) (map[string]any, error) {
 	urlStr := fmt.Sprintf("%s%s%s", c.baseURL, ConnectorPathPrefix, id)
 
-	req, err := c.newRequest(http.MethodGet, urlStr, supportedPlatform, ballerinaVersion, nil)
+	req, err := c.newRequest(ctx, http.MethodGet, urlStr, supportedPlatform, ballerinaVersion, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -560,19 +668,26 @@ func (c *centralAPIClientImpl) getConnectorInternal(id, supportedPlatform, balle
 }
 
 func (c *centralAPIClientImpl) GetConnectorByInfo(connector models.ConnectorInfo, supportedPlatform, ballerinaVersion string) (map[string]any, error) {
-	result, err := c.getConnectorByInfoInternal(connector, supportedPlatform, ballerinaVersion)
+	return c.GetConnectorByInfoCtx(context.Background(), connector, supportedPlatform, ballerinaVersion)
+}
+
+func (c *centralAPIClientImpl) GetConnectorByInfoCtx(ctx context.Context, connector models.ConnectorInfo, supportedPlatform, ballerinaVersion string) (map[string]any, error) {
+	result, err := c.getConnectorByInfoInternal(ctx, connector, supportedPlatform, ballerinaVersion)
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
 		return nil, wrapCentralClientError(err, fmt.Sprintf("%s'%s'", ErrCannotGetConnector, connector.PackageName))
 	}
 
 	return result, nil
 }
 
-func (c *centralAPIClientImpl) getConnectorByInfoInternal(connector models.ConnectorInfo, supportedPlatform, ballerinaVersion string) (map[string]any, error) {
+func (c *centralAPIClientImpl) getConnectorByInfoInternal(ctx context.Context, connector models.ConnectorInfo, supportedPlatform, ballerinaVersion string) (map[string]any, error) {
 	resourcePath := ConnectorPathPrefix + connector.OrgName + Separator + connector.PackageName + Separator + connector.Version + Separator + connector.ModuleName + Separator + connector.Name
 	urlStr := fmt.Sprintf("%s%s", c.baseURL, resourcePath)
 
-	req, err := c.newRequest(http.MethodGet, urlStr, supportedPlatform, ballerinaVersion, nil)
+	req, err := c.newRequest(ctx, http.MethodGet, urlStr, supportedPlatform, ballerinaVersion, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -608,15 +723,22 @@ func (c *centralAPIClientImpl) getConnectorByInfoInternal(connector models.Conne
 }
 
 func (c *centralAPIClientImpl) GetTriggers(params map[string]string, supportedPlatform, ballerinaVersion string) (any, error) {
-	triggers, err := c.getTriggersInternal(params, supportedPlatform, ballerinaVersion)
+	return c.GetTriggersCtx(context.Background(), params, supportedPlatform, ballerinaVersion)
+}
+
+func (c *centralAPIClientImpl) GetTriggersCtx(ctx context.Context, params map[string]string, supportedPlatform, ballerinaVersion string) (any, error) {
+	triggers, err := c.getTriggersInternal(ctx, params, supportedPlatform, ballerinaVersion)
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
 		return nil, wrapCentralClientError(err, ErrCannotGetTriggers)
 	}
 
 	return triggers, nil
 }
 
-func (c *centralAPIClientImpl) getTriggersInternal(params map[string]string, supportedPlatform, ballerinaVersion string) (any, error) {
+func (c *centralAPIClientImpl) getTriggersInternal(ctx context.Context, params map[string]string, supportedPlatform, ballerinaVersion string) (any, error) {
 	baseURL, err := url.Parse(c.baseURL)
 	if err != nil {
 		return nil, err
@@ -629,14 +751,14 @@ func (c *centralAPIClientImpl) getTriggersInternal(params map[string]string, sup
 	}
 	baseURL.RawQuery = query.Encode()
 
-	req, err := c.newRequest(http.MethodGet, baseURL.String(), supportedPlatform, ballerinaVersion, nil)
+	req, err := c.newRequest(ctx, http.MethodGet, baseURL.String(), supportedPlatform, ballerinaVersion, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	c.logRequestInitVerbose(req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doCached(req)
 	if err != nil {
 		return nil, err
 	}
@@ -664,18 +786,25 @@ func (c *centralAPIClientImpl) getTriggersInternal(params map[string]string, sup
 }
 
 func (c *centralAPIClientImpl) GetTrigger(id, supportedPlatform, ballerinaVersion string) (map[string]any, error) {
-	trigger, err := c.getTriggerInternal(id, supportedPlatform, ballerinaVersion)
+	return c.GetTriggerCtx(context.Background(), id, supportedPlatform, ballerinaVersion)
+}
+
+func (c *centralAPIClientImpl) GetTriggerCtx(ctx context.Context, id, supportedPlatform, ballerinaVersion string) (map[string]any, error) {
+	trigger, err := c.getTriggerInternal(ctx, id, supportedPlatform, ballerinaVersion)
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
 		return nil, wrapCentralClientError(err, fmt.Sprintf("%s id: %s", ErrCannotGetTrigger, id))
 	}
 
 	return trigger, nil
 }
 
-func (c *centralAPIClientImpl) getTriggerInternal(id, supportedPlatform, ballerinaVersion string) (map[string]any, error) {
+func (c *centralAPIClientImpl) getTriggerInternal(ctx context.Context, id, supportedPlatform, ballerinaVersion string) (map[string]any, error) {
 	urlStr := fmt.Sprintf("%s%s%s", c.baseURL, TriggerPathPrefix, id)
 
-	req, err := c.newRequest(http.MethodGet, urlStr, supportedPlatform, ballerinaVersion, nil)
+	req, err := c.newRequest(ctx, http.MethodGet, urlStr, supportedPlatform, ballerinaVersion, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -717,6 +846,28 @@ func (c *centralAPIClientImpl) SetAccessToken(token string) {
 	c.accessToken = token
 }
 
+// SetDigestPolicy sets the policy applied when the digest of a pulled bala does not match the expected digest. The
+// default is DigestWarn.
+func (c *centralAPIClientImpl) SetDigestPolicy(policy DigestPolicy) {
+	c.digestPolicy = policy
+}
+
+// SetPinnedDigests sets the digests expected for pulled packages, keyed by "org/name:version" as returned by
+// manifest.Dependencies.PinnedDigests. A pinned digest is checked in addition to the digest sent by Central.
+func (c *centralAPIClientImpl) SetPinnedDigests(digests map[string]string) {
+	c.pinnedDigests = maps.Clone(digests)
+}
+
+// SetResponseCache caches the responses of GetPackage, GetPackageVersions, GetConnectors and GetTriggers as
+// configured by options. A nil options disables the cache, which is the default.
+func (c *centralAPIClientImpl) SetResponseCache(options *ResponseCacheOptions) {
+	if options == nil {
+		c.responseCache = nil
+		return
+	}
+	c.responseCache = newResponseCache(*options)
+}
+
 // wrapCentralClientError wraps an error with CentralClientError unless it's already a CentralClientError.
 func wrapCentralClientError(err error, message string) error {
 	if _, ok := err.(*CentralClientError); ok {
@@ -736,7 +887,8 @@ func wrapCentralOrConnectionError(err error, message string) error {
 	}
 }
 
-func (c *centralAPIClientImpl) pullPackageInternal(org, name, version string, fsys fs.FS, packagePathInBalaCache, supportedPlatform, ballerinaVersion string, clientContext ClientContext) error {
+func (c *centralAPIClientImpl) pullPackageInternal(ctx context.Context, org, name, version string, fsys fs.FS, packagePathInBalaCache, supportedPlatform, ballerinaVersion string, clientContext ClientContext) error {
+	ctx = withOnRetry(ctx, clientContext.OnRetry)
 	resourceURL := fmt.Sprintf("%s%s%s%s", PackagePathPrefix, org, Separator, name)
 
 	urlStr := fmt.Sprintf("%s%s", c.baseURL, resourceURL)
@@ -747,7 +899,7 @@ func (c *centralAPIClientImpl) pullPackageInternal(org, name, version string, fs
 		urlStr = fmt.Sprintf("%s/%s", urlStr, "*")
 	}
 
-	req, err := c.newRequest(http.MethodGet, urlStr, supportedPlatform, ballerinaVersion, nil)
+	req, err := c.newRequest(ctx, http.MethodGet, urlStr, supportedPlatform, ballerinaVersion, nil)
 	if err != nil {
 		return NewCentralClientError(clientContext.formatLog(fmt.Sprintf("%s'%s'", ErrCannotPullPackage, getPackageSignature(org, name, version))))
 	}
@@ -790,7 +942,7 @@ func (c *centralAPIClientImpl) pullPackageInternal(org, name, version string, fs
 		}
 
 		if balaURL != "" && balaFileName != "" {
-			downloadReq, err := c.newRequest(http.MethodGet, balaURL, supportedPlatform, ballerinaVersion, nil)
+			downloadReq, err := c.newRequest(ctx, http.MethodGet, balaURL, supportedPlatform, ballerinaVersion, nil)
 			if err != nil {
 				return NewCentralClientError(clientContext.formatLog(fmt.Sprintf("%s'%s'", ErrCannotPullPackage, getPackageSignature(org, name, version))))
 			}
@@ -817,8 +969,8 @@ func (c *centralAPIClientImpl) pullPackageInternal(org, name, version string, fs
 					deprecMsg = deprecationMessage
 				}
 
-				return createBalaInHomeRepo(downloadResp, fsys, packagePathInBalaCache, org, name, isNightlyBuild,
-					deprecMsg, balaURL, balaFileName, digest, clientContext)
+				return createBalaInHomeRepo(ctx, downloadResp, fsys, packagePathInBalaCache, org, name, isNightlyBuild,
+					deprecMsg, balaURL, balaFileName, digestCheck{policy: c.digestPolicy, central: digest, pinned: c.pinnedDigests}, clientContext)
 			}
 
 			errorMsg := clientContext.formatLog(fmt.Sprintf("%s'%s'. BALA content download from '%s' failed.", ErrCannotPullPackage, getPackageSignature(org, name, version), balaURL))
@@ -857,9 +1009,105 @@ func (c *centralAPIClientImpl) pullPackageInternal(org, name, version string, fs
 	return NewCentralClientError(errorMsg)
 }
 
-func buildHTTPClient(baseURL, proxyURL, proxyUsername, proxyPassword string, callTimeout time.Duration, maxRetries int) http.Client {
+func (c *centralAPIClientImpl) pushPackageInternal(ctx context.Context, balaPath, org, name, version string, fsys fs.FS, supportedPlatform, ballerinaVersion string, clientContext ClientContext) error {
+	ctx = withOnRetry(ctx, clientContext.OnRetry)
+	packageSignature := getPackageSignature(org, name, version)
+
+	info, err := fs.Stat(fsys, balaPath)
+	if err != nil || info.IsDir() {
+		return NewCentralClientError(clientContext.formatLog(fmt.Sprintf("%s'%s'. reason: cannot read bala file: %s", ErrCannotPush, packageSignature, balaPath)))
+	}
+
+	// The bala is streamed from fsys, and reopened for each retry, so that progress follows the upload.
+	openBala := func() (io.ReadCloser, error) {
+		balaFile, err := fsys.Open(balaPath)
+		if err != nil {
+			return nil, err
+		}
+		return struct {
+			io.Reader
+			io.Closer
+		}{newProgressReader(balaFile, info.Size(), clientContext), balaFile}, nil
+	}
+	body, err := openBala()
+	if err != nil {
+		return NewCentralClientError(clientContext.formatLog(fmt.Sprintf("%s'%s'. reason: cannot read bala file: %s", ErrCannotPush, packageSignature, balaPath)))
+	}
+
+	resourceURL := strings.TrimSuffix(PackagePathPrefix, Separator)
+	urlStr := fmt.Sprintf("%s%s", c.baseURL, resourceURL)
+
+	req, err := c.newRequest(ctx, http.MethodPost, urlStr, supportedPlatform, ballerinaVersion, body)
+	if err != nil {
+		body.Close()
+		return err
+	}
+
+	req.GetBody = openBala
+	req.ContentLength = info.Size()
+	req.Header.Set(ContentType, ApplicationOctetStream)
+	req.Header.Set(Accept, ApplicationJSON)
+
+	c.logRequestInitVerbose(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	c.logRequestConnectVerbose(req, resourceURL)
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	c.logResponseVerbose(resp, string(bodyBytes))
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		return nil
+
+	case http.StatusUnauthorized:
+		return c.handleUnauthorizedResponseWithOrg(org, bodyBytes)
+
+	case http.StatusConflict, http.StatusBadRequest:
+		var errResp models.Error
+		if isApplicationJSONContentType(resp.Header.Get(ContentType)) {
+			if err := json.Unmarshal(bodyBytes, &errResp); err != nil {
+				errResp = models.Error{}
+			}
+		}
+
+		if resp.StatusCode == http.StatusConflict || strings.Contains(errResp.Message, "already exists") {
+			reason := errResp.Message
+			if reason == "" {
+				reason = "package already exists"
+			}
+			return NewPackageAlreadyExistsError(clientContext.formatLog(fmt.Sprintf("%s'%s'. reason: %s", ErrCannotPush, packageSignature, reason)), version)
+		}
+	}
+
+	return c.handleResponseErrors(resp, clientContext.formatLog(fmt.Sprintf("%s'%s'", ErrCannotPush, packageSignature)), bodyBytes)
+}
+
+// buildHTTPClient returns the HTTP client of the Central API client. connectTimeout bounds establishing a connection,
+// including the TLS handshake, readTimeout and writeTimeout bound each read and write of a connection, and callTimeout
+// bounds a whole request. A zero timeout means no timeout.
+func buildHTTPClient(baseURL, proxyURL, proxyUsername, proxyPassword string, connectTimeout, readTimeout, writeTimeout, callTimeout time.Duration, maxRetries int) http.Client {
+	dialer := &net.Dialer{Timeout: connectTimeout}
 	transport := &http.Transport{
 		TLSClientConfig: &tls.Config{},
+		DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+			conn, err := dialer.DialContext(ctx, network, address)
+			if err != nil {
+				return nil, err
+			}
+			return &deadlineConn{Conn: conn, readTimeout: readTimeout, writeTimeout: writeTimeout}, nil
+		},
+		TLSHandshakeTimeout:   connectTimeout,
+		ResponseHeaderTimeout: readTimeout,
 	}
 
 	if proxyURL != "" {
@@ -873,12 +1121,8 @@ func buildHTTPClient(baseURL, proxyURL, proxyUsername, proxyPassword string, cal
 	}
 
 	client := http.Client{
-		Transport: &customRetryTransport{
-			transport:  transport,
-			maxRetries: maxRetries,
-			baseURL:    baseURL,
-		},
-		Timeout: callTimeout,
+		Transport: newRetryTransport(transport, maxRetries, baseURL),
+		Timeout:   callTimeout,
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
 			return http.ErrUseLastResponse
 		},
@@ -887,61 +1131,34 @@ func buildHTTPClient(baseURL, proxyURL, proxyUsername, proxyPassword string, cal
 	return client
 }
 
-type customRetryTransport struct {
-	transport  http.RoundTripper
-	maxRetries int
-	baseURL    string
+// deadlineConn is a connection whose reads and writes fail when they make no progress for readTimeout and
+// writeTimeout, so that a stalled download or upload fails however long the bala is. A zero timeout sets no deadline.
+type deadlineConn struct {
+	net.Conn
+	readTimeout  time.Duration
+	writeTimeout time.Duration
 }
 
-func (r *customRetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	var resp *http.Response
-	var err error
-	retryCount := 0
-
-	for retryCount <= r.maxRetries {
-		// Clone request body for potential retries
-		var bodyBytes []byte
-		if req.Body != nil {
-			bodyBytes, err = io.ReadAll(req.Body)
-			if err != nil {
-				return nil, err
-			}
-			req.Body.Close()
-			req.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
-		}
-
-		resp, err = r.transport.RoundTrip(req)
-		if err != nil {
-			return nil, err
-		}
-
-		if resp.StatusCode < 500 || retryCount == r.maxRetries {
-			return resp, nil
-		}
-
-		var bodyContent string
-		if resp.Body != nil {
-			bodyBytes, readErr := io.ReadAll(resp.Body)
-			resp.Body.Close()
-			if readErr == nil {
-				bodyContent = string(bodyBytes)
-			}
+func (c *deadlineConn) Read(p []byte) (int, error) {
+	if c.readTimeout > 0 {
+		if err := c.Conn.SetReadDeadline(time.Now().Add(c.readTimeout)); err != nil {
+			return 0, err
 		}
+	}
+	return c.Conn.Read(p)
+}
 
-		r.logRetryVerbose(resp, bodyContent, req, retryCount+1)
-
-		retryCount = retryCount + 1
-
-		if bodyBytes != nil {
-			req.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+func (c *deadlineConn) Write(p []byte) (int, error) {
+	if c.writeTimeout > 0 {
+		if err := c.Conn.SetWriteDeadline(time.Now().Add(c.writeTimeout)); err != nil {
+			return 0, err
 		}
 	}
-
-	return resp, err
+	return c.Conn.Write(p)
 }
 
-func (c *centralAPIClientImpl) newRequest(method, urlStr, supportedPlatform, ballerinaVersion string, body io.Reader) (*http.Request, error) {
-	req, err := http.NewRequest(method, urlStr, body)
+func (c *centralAPIClientImpl) newRequest(ctx context.Context, method, urlStr, supportedPlatform, ballerinaVersion string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, urlStr, body)
 	if err != nil {
 		return nil, err
 	}
@@ -1013,30 +1230,6 @@ func (c *centralAPIClientImpl) handleUnauthorizedResponseWithOrg(org string, bod
 	return NewCentralClientError(fmt.Sprintf("unauthorized access token for organization: '%s'. check access token set in 'Settings.toml' file.", org))
 }
 
-func (r *customRetryTransport) logRetryVerbose(resp *http.Response, bodyContent string, req *http.Request, retryCount int) {
-	if !isVerboseEnabled() {
-		return
-	}
-
-	fmt.Fprintf(os.Stderr, "< HTTP %d %s\n", resp.StatusCode, resp.Status)
-
-	for name, values := range resp.Header {
-		for _, value := range values {
-			fmt.Fprintf(os.Stderr, "> %s: %s\n", name, value)
-		}
-	}
-
-	fmt.Fprintln(os.Stderr, "< ")
-
-	if bodyContent != "" {
-		fmt.Fprintln(os.Stderr, bodyContent)
-	}
-
-	fmt.Fprintf(os.Stderr, "* Connection to host %s left intact \n\n", r.baseURL)
-	fmt.Fprintf(os.Stderr, "* Retrying request to %s due to %d response code. Retry attempt: %d\n",
-		req.URL.String(), resp.StatusCode, retryCount)
-}
-
 func (c *centralAPIClientImpl) logRequestInitVerbose(req *http.Request) {
 	if isVerboseEnabled() {
 		fmt.Fprintf(os.Stderr, "* Trying %s\n", req.URL.String())
@@ -17,16 +17,29 @@
 package centralclient
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"testing"
+	"testing/iotest"
+	"time"
 
+	"ballerina-lang-go/centralclient/models"
 	"ballerina-lang-go/common/bfs"
 
 	"golang.org/x/tools/txtar"
@@ -157,6 +170,590 @@ func TestPullPackageConnectionResetRetry(t *testing.T) {
 	}
 }
 
+func TestPullPackageConnectionResetOnConnect(t *testing.T) {
+	attempts := 0
+	reset := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.String(), "/registry/packages/") {
+			resp := newBinaryResponse(http.StatusFound, nil, req)
+			resp.Header.Set(Location, "https://fileserver.dev-central.ballerina.io/2.0/foo/sf/1.3.5/sf-any-1.3.5.bala")
+			resp.Header.Set(ContentDisposition, "attachment; filename=sf-any-1.3.5.bala")
+			return resp, nil
+		}
+		attempts++
+		return nil, errors.New("Connection reset by peer")
+	})
+	client := newTestCentralAPIClient(http.Client{Transport: &customRetryTransport{
+		transport:  reset,
+		maxRetries: 2,
+		baseURL:    "https://localhost:9090/registry",
+		baseDelay:  time.Millisecond,
+		maxDelay:   time.Second,
+		clock:      systemClock{},
+		random:     func() float64 { return 0 },
+	}, CheckRedirect: preventRedirect})
+
+	var retries []int
+	clientContext := ClientContext{OnRetry: func(retryCount int) {
+		retries = append(retries, retryCount)
+	}}
+	err := client.PullPackage("foo", "sf", "1.3.5", bfs.NewMemFS(), filepath.Join("bala", "foo", "sf"), "any", testBalVersion, clientContext)
+	if err == nil {
+		t.Fatal("expected the pull to fail")
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts to download the bala, got %d", attempts)
+	}
+	if !slices.Equal(retries, []int{1, 2}) {
+		t.Errorf("expected OnRetry to be called for each retry once, got %v", retries)
+	}
+}
+
+func TestPushPackage(t *testing.T) {
+	balaContent, err := os.ReadFile(filepath.Join(utilTestResources, testBalaName))
+	if err != nil {
+		t.Fatalf("failed to read bala file: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		status        int
+		contentType   string
+		body          string
+		expectedError string
+		alreadyExists bool
+	}{
+		{
+			name:   "success",
+			status: http.StatusNoContent,
+		},
+		{
+			name:          "already exists",
+			status:        http.StatusConflict,
+			contentType:   ApplicationJSON,
+			body:          `{"message": "package already exists: wso2/sf:1.3.5"}`,
+			expectedError: "error: failed to push the package: 'wso2/sf:1.3.5'. reason: package already exists: wso2/sf:1.3.5",
+			alreadyExists: true,
+		},
+		{
+			name:          "already exists as bad request",
+			status:        http.StatusBadRequest,
+			contentType:   ApplicationJSON,
+			body:          `{"message": "package 'wso2/sf:1.3.5' already exists"}`,
+			expectedError: "error: failed to push the package: 'wso2/sf:1.3.5'. reason: package 'wso2/sf:1.3.5' already exists",
+			alreadyExists: true,
+		},
+		{
+			name:          "bad request",
+			status:        http.StatusBadRequest,
+			contentType:   ApplicationJSON,
+			body:          `{"message": "invalid bala file"}`,
+			expectedError: "error: failed to push the package: 'wso2/sf:1.3.5'. reason: invalid bala file",
+		},
+		{
+			name:          "unauthorized",
+			status:        http.StatusUnauthorized,
+			contentType:   ApplicationJSON,
+			body:          `{"message": "user is not a member of the organization"}`,
+			expectedError: "unauthorized access token for organization: 'wso2'. check access token set in 'Settings.toml' file. reason: user is not a member of the organization",
+		},
+		{
+			name:          "server error",
+			status:        http.StatusInternalServerError,
+			contentType:   ApplicationJSON,
+			body:          `{"message": "storage unavailable"}`,
+			expectedError: "error: failed to push the package: 'wso2/sf:1.3.5'. reason: storage unavailable",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var received []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/registry/packages" {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+				if got := r.Header.Get(ContentType); got != ApplicationOctetStream {
+					t.Errorf("expected content type %s, got %s", ApplicationOctetStream, got)
+				}
+				if got := r.Header.Get(Authorization); got != getBearerToken(accessToken) {
+					t.Errorf("unexpected authorization header: %s", got)
+				}
+				received, _ = io.ReadAll(r.Body)
+				if test.contentType != "" {
+					w.Header().Set(ContentType, test.contentType)
+				}
+				w.WriteHeader(test.status)
+				_, _ = io.WriteString(w, test.body)
+			}))
+			defer server.Close()
+
+			memFS := bfs.NewMemFS()
+			balaPath := filepath.Join("target", "bala", "wso2-sf-any-1.3.5.bala")
+			if err := bfs.WriteFile(memFS, balaPath, balaContent, 0o644); err != nil {
+				t.Fatalf("failed to write bala file: %v", err)
+			}
+
+			var progress []int
+			clientContext := ClientContext{OnProgress: func(percentComplete int) {
+				progress = append(progress, percentComplete)
+			}}
+
+			client := NewCentralAPIClientFull(server.URL+"/registry", "", "", "", accessToken, 0, 0, 0, 0, 0)
+			err := client.PushPackage(balaPath, "wso2", "sf", "1.3.5", memFS, "any", testBalVersion, clientContext)
+
+			if !bytes.Equal(received, balaContent) {
+				t.Errorf("expected the bala content to be uploaded, got %d bytes", len(received))
+			}
+			if len(progress) == 0 || progress[len(progress)-1] != 100 {
+				t.Errorf("expected progress to reach 100%%, got %v", progress)
+			}
+
+			if test.expectedError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error %q, got nil", test.expectedError)
+			}
+			if err.Error() != test.expectedError {
+				t.Errorf("expected error %q, got %q", test.expectedError, err.Error())
+			}
+			alreadyExistsErr, ok := err.(*PackageAlreadyExistsError)
+			if ok != test.alreadyExists {
+				t.Errorf("expected PackageAlreadyExistsError: %t, got %T", test.alreadyExists, err)
+			}
+			if ok && alreadyExistsErr.Version() != "1.3.5" {
+				t.Errorf("expected version 1.3.5, got %s", alreadyExistsErr.Version())
+			}
+		})
+	}
+}
+
+func TestPushPackageMissingBala(t *testing.T) {
+	client := newTestCentralAPIClient(http.Client{})
+	err := client.PushPackage("missing.bala", "wso2", "sf", "1.3.5", bfs.NewMemFS(), "any", testBalVersion, ClientContext{})
+	expected := "error: failed to push the package: 'wso2/sf:1.3.5'. reason: cannot read bala file: missing.bala"
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected error %q, got %v", expected, err)
+	}
+}
+
+// progressCheckingBody fails the test unless the progress reported so far matches the bytes of the body handed to
+// the connection.
+type progressCheckingBody struct {
+	io.ReadCloser
+	t        *testing.T
+	size     int
+	sent     int
+	progress func() int
+}
+
+func (b *progressCheckingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.sent += n
+	if n > 0 {
+		if got, want := b.progress(), b.sent*100/b.size; got != want {
+			b.t.Errorf("progress %d%% reported after sending %d of %d bytes, want %d%%", got, b.sent, b.size, want)
+		}
+	}
+	return n, err
+}
+
+func TestPushPackageStreamsUpload(t *testing.T) {
+	balaContent := bytes.Repeat([]byte("bala"), 64*1024)
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		received, _ := io.ReadAll(r.Body)
+		if !bytes.Equal(received, balaContent) {
+			t.Errorf("attempt %d: expected the bala content to be uploaded, got %d bytes", attempts, len(received))
+		}
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	memFS := bfs.NewMemFS()
+	if err := bfs.WriteFile(memFS, "wso2-sf-any-1.3.5.bala", balaContent, 0o644); err != nil {
+		t.Fatalf("failed to write bala file: %v", err)
+	}
+
+	var mu sync.Mutex
+	progress := -1
+	clientContext := ClientContext{OnProgress: func(percentComplete int) {
+		mu.Lock()
+		defer mu.Unlock()
+		progress = percentComplete
+	}}
+	lastProgress := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return progress
+	}
+
+	upload := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		attempt := req.Clone(req.Context())
+		attempt.Body = &progressCheckingBody{ReadCloser: req.Body, t: t, size: len(balaContent), progress: lastProgress}
+		return http.DefaultTransport.RoundTrip(attempt)
+	})
+	client := &centralAPIClientImpl{
+		baseURL: server.URL + "/registry",
+		httpClient: http.Client{Transport: &customRetryTransport{
+			transport:  upload,
+			maxRetries: 1,
+			baseURL:    server.URL + "/registry",
+			baseDelay:  time.Millisecond,
+			maxDelay:   time.Second,
+			clock:      systemClock{},
+			random:     func() float64 { return 0 },
+		}},
+	}
+
+	if err := client.PushPackage("wso2-sf-any-1.3.5.bala", "wso2", "sf", "1.3.5", memFS, "any", testBalVersion, clientContext); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("expected the upload to be retried once, got %d attempts", attempts)
+	}
+	if got := lastProgress(); got != 100 {
+		t.Errorf("expected progress to reach 100%%, got %d%%", got)
+	}
+}
+
+func TestPackageSearchQuery(t *testing.T) {
+	tests := []struct {
+		query    PackageSearchQuery
+		expected string
+	}{
+		{PackageSearchQuery{}, ""},
+		{PackageSearchQuery{Text: " http "}, "http"},
+		{PackageSearchQuery{Text: "http", Org: "ballerina", Keyword: "network"}, "http org:ballerina keyword:network"},
+		{PackageSearchQuery{Org: "wso2", Name: "sf"}, "org:wso2 name:sf"},
+	}
+	for _, test := range tests {
+		if got := test.query.String(); got != test.expected {
+			t.Errorf("String() = %q, want %q", got, test.expected)
+		}
+	}
+}
+
+func TestSearchPackages(t *testing.T) {
+	server := newSearchServer(t, 5)
+	defer server.Close()
+	client := NewCentralAPIClientFull(server.URL+"/registry", "", "", "", accessToken, 0, 0, 0, 0, 0)
+
+	result, err := client.SearchPackages(PackageSearchQuery{Text: "winery", Org: "foo"}, 2, 1, SortByPullCount, "any", testBalVersion)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Count != 5 || len(result.Packages) != 2 {
+		t.Fatalf("expected 2 of 5 packages, got %d of %d", len(result.Packages), result.Count)
+	}
+	if result.Packages[0].Name != "winery1" || result.Packages[1].Name != "winery2" {
+		t.Errorf("unexpected packages: %s, %s", result.Packages[0].Name, result.Packages[1].Name)
+	}
+
+	var names []string
+	for pkg, err := range client.SearchAllPackages(PackageSearchQuery{Org: "foo"}, 2, "", "any", testBalVersion) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		names = append(names, pkg.Name)
+	}
+	if got := strings.Join(names, ","); got != "winery0,winery1,winery2,winery3,winery4" {
+		t.Errorf("unexpected packages from iterator: %s", got)
+	}
+
+	names = nil
+	for pkg := range client.SearchAllPackages(PackageSearchQuery{Org: "foo"}, 2, "", "any", testBalVersion) {
+		names = append(names, pkg.Name)
+		if len(names) == 3 {
+			break
+		}
+	}
+	if len(names) != 3 {
+		t.Errorf("expected iteration to stop after 3 packages, got %v", names)
+	}
+}
+
+func TestSearchPackagesErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expectedError string
+	}{
+		{
+			name:          "bad request",
+			status:        http.StatusBadRequest,
+			body:          `{"message": "invalid sort order"}`,
+			expectedError: "error: failed to search packages: 'org:foo'. reason: invalid sort order",
+		},
+		{
+			name:          "unauthorized",
+			status:        http.StatusUnauthorized,
+			body:          `{"message": "token expired"}`,
+			expectedError: "unauthorized access token. check access token set in 'Settings.toml' file. reason: token expired",
+		},
+		{
+			name:          "server error",
+			status:        http.StatusInternalServerError,
+			body:          `{"message": "search index unavailable"}`,
+			expectedError: "error: failed to search packages: 'org:foo'. reason: search index unavailable",
+		},
+		{
+			name:          "invalid json",
+			status:        http.StatusOK,
+			body:          `{"packages": [`,
+			expectedError: "error: failed to search packages: 'org:foo'. reason: unexpected error",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set(ContentType, ApplicationJSON)
+				w.WriteHeader(test.status)
+				_, _ = io.WriteString(w, test.body)
+			}))
+			defer server.Close()
+			client := NewCentralAPIClientFull(server.URL+"/registry", "", "", "", accessToken, 0, 0, 0, 0, 0)
+
+			_, err := client.SearchPackages(PackageSearchQuery{Org: "foo"}, 0, 0, "", "any", testBalVersion)
+			if err == nil || err.Error() != test.expectedError {
+				t.Fatalf("expected error %q, got %v", test.expectedError, err)
+			}
+
+			var iterErr error
+			count := 0
+			for _, err := range client.SearchAllPackages(PackageSearchQuery{Org: "foo"}, 0, "", "any", testBalVersion) {
+				iterErr = err
+				count++
+			}
+			if count != 1 || iterErr == nil || iterErr.Error() != test.expectedError {
+				t.Errorf("expected the iterator to yield the error once, got %d values and %v", count, iterErr)
+			}
+		})
+	}
+}
+
+// newSearchServer returns a stand-in for the Central search API that serves total packages named winery0,
+// winery1, ... in pages selected by the limit and offset query parameters.
+func newSearchServer(t *testing.T, total int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/registry/packages/" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if !strings.Contains(query.Get("q"), "org:foo") {
+			t.Errorf("expected an organization filter, got %q", query.Get("q"))
+		}
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		offset, _ := strconv.Atoi(query.Get("offset"))
+
+		result := models.PackageSearchResult{Count: total, Packages: []models.Package{}}
+		for i := offset; i < total && i < offset+limit; i++ {
+			result.Packages = append(result.Packages, models.Package{
+				Organization: "foo",
+				Name:         fmt.Sprintf("winery%d", i),
+				Version:      "1.0.0",
+			})
+		}
+		w.Header().Set(ContentType, ApplicationJSON)
+		_ = json.NewEncoder(w).Encode(result)
+	}))
+}
+
+func TestPullPackageStaleTempDir(t *testing.T) {
+	balaContent, err := os.ReadFile(filepath.Join(utilTestResources, testBalaName))
+	if err != nil {
+		t.Fatalf("failed to read bala file: %v", err)
+	}
+
+	for _, test := range []struct {
+		name    string
+		bala    []byte
+		wantErr bool
+	}{
+		{name: "success", bala: balaContent},
+		{name: "failure", bala: []byte("not a bala"), wantErr: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			// A directory filesystem, as moving onto an existing directory only fails on a real filesystem.
+			fsys := bfs.NewDirFS(t.TempDir())
+			pkgDir := filepath.Join("bala", "wso2", "sf")
+			for _, file := range []string{
+				filepath.Join(pkgDir, "1.3.5_temp", "2020r2-any", "stale.txt"),
+				filepath.Join(pkgDir, "1.3.5_temp", "java21", "stale.txt"),
+				filepath.Join(pkgDir, "1.3.5", "java21", "bala.json"),
+			} {
+				if err := bfs.MkdirAll(fsys, filepath.Dir(file), 0o755); err != nil {
+					t.Fatalf("failed to create directory: %v", err)
+				}
+				if err := bfs.WriteFile(fsys, file, []byte("{}"), 0o644); err != nil {
+					t.Fatalf("failed to write %s: %v", file, err)
+				}
+			}
+
+			client := newTestCentralAPIClient(newDeprecatedPackageMockClient(test.bala, "sf-2020r2-any-1.3.5.bala"))
+			err := client.PullPackage("wso2", "sf", "1.3.5", fsys, pkgDir, "2020r2-any", testBalVersion, ClientContext{})
+			if (err != nil) != test.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if _, err := fs.Stat(fsys, filepath.Join(pkgDir, "1.3.5_temp")); !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("expected the temp directory to be removed, got %v", err)
+			}
+			if _, err := fs.Stat(fsys, filepath.Join(pkgDir, "1.3.5", "java21", "bala.json")); err != nil {
+				t.Errorf("expected the other platform to be kept: %v", err)
+			}
+			entries, _ := fs.ReadDir(fsys, filepath.Join(pkgDir, "1.3.5"))
+			wantEntries := 1
+			if !test.wantErr {
+				wantEntries = 2
+				if _, err := fs.Stat(fsys, filepath.Join(pkgDir, "1.3.5", "2020r2-any", "bala.json")); err != nil {
+					t.Errorf("expected the bala to be extracted: %v", err)
+				}
+				if _, err := fs.Stat(fsys, filepath.Join(pkgDir, "1.3.5", "2020r2-any", "stale.txt")); !errors.Is(err, fs.ErrNotExist) {
+					t.Errorf("expected the stale content not to be installed, got %v", err)
+				}
+			}
+			if len(entries) != wantEntries {
+				t.Errorf("expected %d platforms in the bala cache, got %v", wantEntries, entries)
+			}
+		})
+	}
+}
+
+func TestPullPackageCtxCanceledDuringDownload(t *testing.T) {
+	balaContent, err := os.ReadFile(filepath.Join(utilTestResources, testBalaName))
+	if err != nil {
+		t.Fatalf("failed to read bala file: %v", err)
+	}
+
+	balaFileName := "sf-2020r2-any-1.3.5.bala"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/registry/packages/") {
+			w.Header().Set(Location, "http://"+r.Host+"/bala/wso2/sf/1.3.5/"+balaFileName)
+			w.Header().Set(ContentDisposition, "attachment; filename="+balaFileName)
+			w.WriteHeader(http.StatusFound)
+			return
+		}
+		// Send half of the bala and stall until the client gives up.
+		w.Header().Set("Content-Length", strconv.Itoa(len(balaContent)))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(balaContent[:len(balaContent)/2])
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	clientContext := ClientContext{OnProgress: func(percentComplete int) {
+		cancel()
+	}}
+
+	memFS := bfs.NewMemFS()
+	client := NewCentralAPIClientFull(server.URL+"/registry", "", "", "", accessToken, 0, 0, 0, 0, 0)
+	err = client.PullPackageCtx(ctx, "wso2", "sf", "1.3.5", memFS, filepath.Join("bala", "wso2", "sf"), "any", testBalVersion, clientContext)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	for _, dir := range []string{"1.3.5_temp", "1.3.5"} {
+		if _, err := fs.Stat(memFS, filepath.Join("bala", "wso2", "sf", dir)); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("expected %s to be removed after the canceled pull, got %v", dir, err)
+		}
+	}
+}
+
+func TestGetPackageCtxDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	client := NewCentralAPIClientFull(server.URL+"/registry", "", "", "", accessToken, 0, 0, 0, 0, 0)
+	if _, err := client.GetPackageCtx(ctx, "wso2", "sf", "1.3.5", "any", testBalVersion); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if _, err := client.GetPackageVersionsCtx(ctx, "wso2", "sf", "any", testBalVersion); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestHTTPClientReadTimeout(t *testing.T) {
+	stalled := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/body" {
+			w.Header().Set("Content-Length", "4")
+			_, _ = w.Write([]byte("ba"))
+			w.(http.Flusher).Flush()
+		}
+		<-stalled
+	}))
+	defer server.Close()
+	defer close(stalled)
+
+	client := buildHTTPClient(server.URL, "", "", "", time.Second, 50*time.Millisecond, time.Second, 0, 0)
+	var netErr net.Error
+	if _, err := client.Get(server.URL + "/headers"); !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Errorf("expected a timeout waiting for the response, got %v", err)
+	}
+	resp, err := client.Get(server.URL + "/body")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	if _, err := io.ReadAll(resp.Body); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("expected a timeout reading the stalled body, got %v", err)
+	}
+}
+
+func TestDeadlineConnWriteTimeout(t *testing.T) {
+	local, remote := net.Pipe()
+	defer local.Close()
+	defer remote.Close()
+
+	// Nothing reads from remote, so the write makes no progress.
+	conn := &deadlineConn{Conn: local, writeTimeout: 20 * time.Millisecond}
+	if _, err := conn.Write([]byte("bala")); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("expected a write timeout, got %v", err)
+	}
+}
+
+func TestRetryTransportStopsWhenCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		cancel()
+		w.Header().Set(ContentType, ApplicationJSON)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = io.WriteString(w, `{"message": "unavailable"}`)
+	}))
+	defer server.Close()
+
+	client := NewCentralAPIClientFull(server.URL+"/registry", "", "", "", accessToken, 0, 0, 0, 0, 5)
+	if _, err := client.GetConnectorCtx(ctx, "42", "any", testBalVersion); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected no retries after cancellation, got %d attempts", attempts)
+	}
+}
+
 func parseTestCases(dir string) ([]TestCase, error) {
 	files, err := os.ReadDir(dir)
 	if err != nil {
@@ -417,11 +1014,12 @@ func newRetryMockClient(balaContent []byte, balaFileName string, attemptCount, d
 		}
 
 		*downloadAttempts++
+		resp := newBinaryResponse(http.StatusOK, balaContent, req)
 		if *downloadAttempts <= 2 {
-			return nil, fmt.Errorf("Connection reset by peer")
+			// The connection is reset after half of the bala has been received.
+			resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(balaContent[:len(balaContent)/2]),
+				iotest.ErrReader(fmt.Errorf("read tcp: %w", syscall.ECONNRESET))))
 		}
-
-		resp := newBinaryResponse(http.StatusOK, balaContent, req)
 		resp.Header.Set("RESOLVED_REQUESTED_URI", "https://fileserver.dev-central.ballerina.io/2.0/foo/sf/1.3.5/"+balaFileName)
 		return resp, nil
 	})
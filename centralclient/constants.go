@@ -16,6 +16,8 @@
 
 package centralclient
 
+import "time"
+
 const (
 	BallerinaPlatform                 = "Ballerina-Platform"
 	Identity                          = "identity"
@@ -32,6 +34,11 @@ const (
 	ApplicationJSON                   = "application/json"
 	BallerinaCentralTelemetryDisabled = "Ballerina-Central-Telemetry-Disabled"
 	Digest                            = "digest"
+	RetryAfter                        = "Retry-After"
+	ETag                              = "ETag"
+	LastModified                      = "Last-Modified"
+	IfNoneMatch                       = "If-None-Match"
+	IfModifiedSince                   = "If-Modified-Since"
 )
 
 const (
@@ -51,12 +58,21 @@ const (
 	TriggerPathPrefix   = Separator + "triggers" + Separator
 )
 
+// Registry URLs of Central. The staging and dev registries are selected through BALLERINA_STAGE_CENTRAL and
+// BALLERINA_DEV_CENTRAL.
+const (
+	CentralRegistryURL        = "https://api.central.ballerina.io/2.0/registry"
+	StagingCentralRegistryURL = "https://api.staging-central.ballerina.io/2.0/registry"
+	DevCentralRegistryURL     = "https://api.dev-central.ballerina.io/2.0/registry"
+)
+
 const SHA256 = "sha-256="
 
 const (
 	ErrCannotFindPackage  = "error: could not connect to remote repository to find package: "
 	ErrCannotFindVersions = "error: could not connect to remote repository to find versions for: "
 	ErrCannotPush         = "error: failed to push the package: "
+	ErrCannotSearch       = "error: failed to search packages: "
 	ErrCannotPullPackage  = "error: failed to pull the package: "
 	ErrCannotGetConnector = "error: failed to find connector: "
 	ErrCannotGetTriggers  = "error: failed to find triggers: "
@@ -71,6 +87,13 @@ const (
 	DefaultCallTimeout    = 0
 	MaxRetry              = 1
 	ConnectionReset       = "Connection reset"
+	DownloadBufferSize    = 32 * 1024
+)
+
+// The backoff of retried requests starts at RetryBaseDelay and doubles with each retry up to RetryMaxDelay.
+const (
+	RetryBaseDelay = 500 * time.Millisecond
+	RetryMaxDelay  = 30 * time.Second
 )
 
 const (
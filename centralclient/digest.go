@@ -0,0 +1,83 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package centralclient
+
+import "fmt"
+
+// DigestPolicy decides what happens when the digest of a pulled bala does not match the digest sent by Central in
+// the digest header or the digest pinned for the package in Dependencies.toml.
+type DigestPolicy int
+
+const (
+	// DigestWarn reports a mismatch through ClientContext.OnWarning and installs the package anyway.
+	DigestWarn DigestPolicy = iota
+	// DigestFail rejects the package with a DigestMismatchError. Nothing is written into the bala cache.
+	DigestFail
+	// DigestSkip does not verify the digest.
+	DigestSkip
+)
+
+func (p DigestPolicy) String() string {
+	switch p {
+	case DigestWarn:
+		return "warn"
+	case DigestFail:
+		return "fail"
+	case DigestSkip:
+		return "skip"
+	default:
+		return fmt.Sprintf("DigestPolicy(%d)", int(p))
+	}
+}
+
+// ParseDigestPolicy parses the name of a policy as written by DigestPolicy.String.
+func ParseDigestPolicy(name string) (DigestPolicy, error) {
+	for _, policy := range []DigestPolicy{DigestWarn, DigestFail, DigestSkip} {
+		if policy.String() == name {
+			return policy, nil
+		}
+	}
+	return DigestWarn, fmt.Errorf("invalid digest policy '%s', expected one of warn, fail or skip", name)
+}
+
+// digestCheck is the verification applied to a downloaded bala. central is the digest sent by Central, and pinned
+// the digests pinned by the caller keyed by "org/name:version".
+type digestCheck struct {
+	policy  DigestPolicy
+	central string
+	pinned  map[string]string
+}
+
+// verify checks the actual digest of the bala of packageName against the pinned digest of the package, if any, and
+// then against the digest sent by Central.
+func (d digestCheck) verify(actualDigest, packageName string, clientContext ClientContext) error {
+	if d.policy == DigestSkip {
+		return nil
+	}
+	for _, expected := range []string{d.pinned[packageName], d.central} {
+		if expected == "" || expected == actualDigest {
+			continue
+		}
+		if d.policy == DigestFail {
+			message := fmt.Sprintf("digest mismatch for package %s: expected %s, found %s", packageName, expected, actualDigest)
+			return NewDigestMismatchError(clientContext.formatLog(message), expected, actualDigest)
+		}
+		verifyDigest(expected, actualDigest, packageName, clientContext)
+		return nil
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package centralclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"ballerina-lang-go/common/bfs"
+)
+
+func TestPullPackageDigestPolicy(t *testing.T) {
+	bala := newTestZip(t, []testZipEntry{{name: "bala.json", content: []byte("{}")}})
+	sum := sha256.Sum256(bala)
+	actual := SHA256 + hex.EncodeToString(sum[:])
+	wrong := SHA256 + hex.EncodeToString(make([]byte, sha256.Size))
+
+	tests := []struct {
+		name          string
+		policy        DigestPolicy
+		central       string
+		pinned        string
+		wantExpected  string
+		wantWarnings  int
+		wantInstalled bool
+	}{
+		{name: "warn on central mismatch", policy: DigestWarn, central: wrong, wantWarnings: 1, wantInstalled: true},
+		{name: "warn on pinned mismatch", policy: DigestWarn, central: actual, pinned: wrong, wantWarnings: 1, wantInstalled: true},
+		{name: "fail on central mismatch", policy: DigestFail, central: wrong, wantExpected: wrong},
+		{name: "fail on pinned mismatch", policy: DigestFail, central: actual, pinned: wrong, wantExpected: wrong},
+		{name: "fail with matching digests", policy: DigestFail, central: actual, pinned: actual, wantInstalled: true},
+		{name: "fail without digests", policy: DigestFail, wantInstalled: true},
+		{name: "skip", policy: DigestSkip, central: wrong, pinned: wrong, wantInstalled: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newDigestServer(t, bala, test.central)
+			defer server.Close()
+
+			client := NewCentralAPIClientFull(server.URL+"/registry", "", "", "", accessToken, 0, 0, 0, 0, 0)
+			client.SetDigestPolicy(test.policy)
+			if test.pinned != "" {
+				client.SetPinnedDigests(map[string]string{"wso2/sf:1.3.5": test.pinned})
+			}
+
+			var warnings []string
+			clientContext := ClientContext{OnWarning: func(msg string) { warnings = append(warnings, msg) }}
+			memFS := bfs.NewMemFS()
+			err := client.PullPackage("wso2", "sf", "1.3.5", memFS, filepath.Join("bala", "wso2", "sf"), "any", testBalVersion, clientContext)
+
+			if test.wantExpected != "" {
+				var mismatchErr *DigestMismatchError
+				if !errors.As(err, &mismatchErr) {
+					t.Fatalf("expected a digest mismatch error, got %v", err)
+				}
+				if mismatchErr.Expected() != test.wantExpected || mismatchErr.Actual() != actual {
+					t.Errorf("unexpected digests: expected %s, actual %s", mismatchErr.Expected(), mismatchErr.Actual())
+				}
+				if _, err := fs.Stat(memFS, "bala"); !errors.Is(err, fs.ErrNotExist) {
+					t.Errorf("expected nothing to be written into the bala cache, got %v", err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			_, err = fs.Stat(memFS, filepath.Join("bala", "wso2", "sf", "1.3.5", "any", "bala.json"))
+			if installed := err == nil; installed != test.wantInstalled {
+				t.Errorf("expected installed to be %t, got %t", test.wantInstalled, installed)
+			}
+			if len(warnings) != test.wantWarnings {
+				t.Errorf("expected %d warnings, got %v", test.wantWarnings, warnings)
+			}
+		})
+	}
+}
+
+func TestParseDigestPolicy(t *testing.T) {
+	for _, policy := range []DigestPolicy{DigestWarn, DigestFail, DigestSkip} {
+		if parsed, err := ParseDigestPolicy(policy.String()); err != nil || parsed != policy {
+			t.Errorf("expected %s to round trip, got %s, %v", policy, parsed, err)
+		}
+	}
+	if _, err := ParseDigestPolicy("strict"); err == nil {
+		t.Error("expected an error for an unknown policy")
+	}
+}
+
+// newDigestServer serves bala as wso2/sf:1.3.5, sending digest in the digest header when it is set.
+func newDigestServer(t *testing.T, bala []byte, digest string) *httptest.Server {
+	t.Helper()
+	balaFileName := "sf-any-1.3.5.bala"
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/registry/packages/wso2/sf/1.3.5" {
+			w.Header().Set(Location, "http://"+r.Host+"/bala/wso2/sf/1.3.5/"+balaFileName)
+			w.Header().Set(ContentDisposition, "attachment; filename="+balaFileName)
+			if digest != "" {
+				w.Header().Set(Digest, digest)
+			}
+			w.WriteHeader(http.StatusFound)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(bala)))
+		_, _ = w.Write(bala)
+	}))
+}
@@ -63,3 +63,69 @@ func NewPackageAlreadyExistsError(message string, version string) *PackageAlread
 		version:            version,
 	}
 }
+
+// UnsafeBalaEntryError is returned when a bala has an entry that would be extracted outside of the bala cache, such
+// as an entry with an absolute path, a path with '..' elements, or a symbolic link.
+type UnsafeBalaEntryError struct {
+	CentralClientError
+	entry string
+}
+
+func (e *UnsafeBalaEntryError) Entry() string {
+	return e.entry
+}
+
+func NewUnsafeBalaEntryError(message string, entry string) *UnsafeBalaEntryError {
+	return &UnsafeBalaEntryError{
+		CentralClientError: CentralClientError{message: message},
+		entry:              entry,
+	}
+}
+
+// Limits of ExtractionLimits reported by BalaLimitExceededError.
+const (
+	LimitUncompressedSize = "uncompressed size"
+	LimitEntries          = "entries"
+	LimitCompressionRatio = "compression ratio"
+)
+
+// BalaLimitExceededError is returned when a bala exceeds one of the ExtractionLimits.
+type BalaLimitExceededError struct {
+	CentralClientError
+	limit string
+}
+
+func (e *BalaLimitExceededError) Limit() string {
+	return e.limit
+}
+
+func NewBalaLimitExceededError(message string, limit string) *BalaLimitExceededError {
+	return &BalaLimitExceededError{
+		CentralClientError: CentralClientError{message: message},
+		limit:              limit,
+	}
+}
+
+// DigestMismatchError is returned under DigestFail when the digest of a downloaded bala does not match the expected
+// digest.
+type DigestMismatchError struct {
+	CentralClientError
+	expected string
+	actual   string
+}
+
+func (e *DigestMismatchError) Expected() string {
+	return e.expected
+}
+
+func (e *DigestMismatchError) Actual() string {
+	return e.actual
+}
+
+func NewDigestMismatchError(message string, expected, actual string) *DigestMismatchError {
+	return &DigestMismatchError{
+		CentralClientError: CentralClientError{message: message},
+		expected:           expected,
+		actual:             actual,
+	}
+}
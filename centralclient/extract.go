@@ -0,0 +1,236 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package centralclient
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"ballerina-lang-go/common/bfs"
+)
+
+// ExtractionLimits bounds what extracting a bala may write, so that a hostile bala cannot exhaust the disk. A zero
+// field takes the value of DefaultExtractionLimits, so the zero value applies the default limits.
+type ExtractionLimits struct {
+	// MaxUncompressedSize is the largest total size of the extracted entries, in bytes.
+	MaxUncompressedSize int64
+	// MaxEntries is the largest number of entries in a bala.
+	MaxEntries int
+	// MaxCompressionRatio is the largest ratio of the uncompressed to the compressed size of an entry. Entries smaller
+	// than MinRatioCheckedSize are not checked, as small files of repeated content legitimately compress well.
+	MaxCompressionRatio uint64
+}
+
+// MinRatioCheckedSize is the uncompressed size from which ExtractionLimits.MaxCompressionRatio applies to an entry.
+const MinRatioCheckedSize = 1 << 20
+
+// DefaultExtractionLimits are the limits applied to the balas pulled from Central.
+var DefaultExtractionLimits = ExtractionLimits{
+	MaxUncompressedSize: 1 << 30,
+	MaxEntries:          20000,
+	MaxCompressionRatio: 200,
+}
+
+// withDefaults returns the limits with the zero fields replaced by those of DefaultExtractionLimits.
+func (l ExtractionLimits) withDefaults() ExtractionLimits {
+	if l.MaxUncompressedSize == 0 {
+		l.MaxUncompressedSize = DefaultExtractionLimits.MaxUncompressedSize
+	}
+	if l.MaxEntries == 0 {
+		l.MaxEntries = DefaultExtractionLimits.MaxEntries
+	}
+	if l.MaxCompressionRatio == 0 {
+		l.MaxCompressionRatio = DefaultExtractionLimits.MaxCompressionRatio
+	}
+	return l
+}
+
+// ExtractBala extracts the bala at balaFilePath in fsys into balaFileDestPath with the checks applied to the balas
+// pulled from Central. It installs balas that come from other repositories, such as a mirror of Central.
+func ExtractBala(ctx context.Context, fsys fs.FS, balaFilePath, balaFileDestPath string, limits ExtractionLimits) error {
+	return extractBala(ctx, fsys, balaFilePath, balaFileDestPath, limits, ClientContext{})
+}
+
+// extractBala extracts the bala at balaFilePath into balaFileDestPath. The archive is read in place when the file
+// supports io.ReaderAt, and each entry is streamed into its destination, so that memory use does not grow with the
+// size of the bala. Every entry is validated against limits before anything is written, and an entry that would be
+// extracted outside of balaFileDestPath is rejected with an UnsafeBalaEntryError.
+func extractBala(ctx context.Context, fsys fs.FS, balaFilePath, balaFileDestPath string, limits ExtractionLimits, clientContext ClientContext) error {
+	limits = limits.withDefaults()
+	balaFile, err := fsys.Open(balaFilePath)
+	if err != nil {
+		return err
+	}
+	defer balaFile.Close()
+
+	info, err := balaFile.Stat()
+	if err != nil {
+		return err
+	}
+
+	readerAt, ok := balaFile.(io.ReaderAt)
+	if !ok {
+		// Files without random access have to be read into memory for the zip reader.
+		content, err := io.ReadAll(balaFile)
+		if err != nil {
+			return err
+		}
+		readerAt = bytes.NewReader(content)
+	}
+
+	reader, err := zip.NewReader(readerAt, info.Size())
+	if err != nil {
+		return err
+	}
+
+	entryPaths, err := validateBalaEntries(reader.File, limits)
+	if err != nil {
+		return err
+	}
+
+	if err := bfs.MkdirAll(fsys, balaFileDestPath, 0o755); err != nil {
+		return err
+	}
+
+	remaining := limits.MaxUncompressedSize
+	for i, file := range reader.File {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		path := filepath.Join(balaFileDestPath, entryPaths[i])
+
+		if file.FileInfo().IsDir() {
+			if err := bfs.MkdirAll(fsys, path, 0o755); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := bfs.MkdirAll(fsys, filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+
+		written, err := extractBalaEntry(fsys, file, path, remaining)
+		if err != nil {
+			return err
+		}
+		remaining = remaining - written
+	}
+
+	return nil
+}
+
+// validateBalaEntries checks the entries of a bala against limits and returns the cleaned, slash separated path of
+// each entry relative to the destination of the extraction.
+func validateBalaEntries(files []*zip.File, limits ExtractionLimits) ([]string, error) {
+	if len(files) > limits.MaxEntries {
+		return nil, NewBalaLimitExceededError(fmt.Sprintf("bala has %d entries, more than the limit of %d", len(files), limits.MaxEntries), LimitEntries)
+	}
+
+	paths := make([]string, len(files))
+	var totalSize uint64
+	for i, file := range files {
+		entryPath, err := balaEntryPath(file)
+		if err != nil {
+			return nil, err
+		}
+		paths[i] = entryPath
+
+		totalSize = totalSize + file.UncompressedSize64
+		if totalSize > uint64(limits.MaxUncompressedSize) {
+			return nil, NewBalaLimitExceededError(fmt.Sprintf("bala expands to more than the limit of %d bytes", limits.MaxUncompressedSize), LimitUncompressedSize)
+		}
+
+		if file.UncompressedSize64 >= MinRatioCheckedSize &&
+			(file.CompressedSize64 == 0 || file.UncompressedSize64/file.CompressedSize64 > limits.MaxCompressionRatio) {
+			return nil, NewBalaLimitExceededError(fmt.Sprintf("bala entry '%s' has a compression ratio higher than the limit of %d", file.Name, limits.MaxCompressionRatio), LimitCompressionRatio)
+		}
+	}
+
+	return paths, nil
+}
+
+// balaEntryPath returns the cleaned, slash separated path of a bala entry, rejecting absolute paths, paths that
+// escape the destination, and entries that are neither regular files nor directories, such as symbolic links.
+func balaEntryPath(file *zip.File) (string, error) {
+	name := strings.ReplaceAll(file.Name, "\\", "/")
+
+	mode := file.Mode()
+	if mode&fs.ModeSymlink != 0 {
+		return "", NewUnsafeBalaEntryError(fmt.Sprintf("bala entry '%s' is a symbolic link", file.Name), file.Name)
+	}
+	if !mode.IsRegular() && !mode.IsDir() {
+		return "", NewUnsafeBalaEntryError(fmt.Sprintf("bala entry '%s' is not a regular file", file.Name), file.Name)
+	}
+
+	if name == "" || path.IsAbs(name) || filepath.VolumeName(name) != "" || (len(name) >= 2 && name[1] == ':') {
+		return "", NewUnsafeBalaEntryError(fmt.Sprintf("bala entry '%s' has an absolute path", file.Name), file.Name)
+	}
+
+	cleaned := path.Clean(name)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", NewUnsafeBalaEntryError(fmt.Sprintf("bala entry '%s' is outside of the bala", file.Name), file.Name)
+	}
+
+	return filepath.FromSlash(cleaned), nil
+}
+
+// extractBalaEntry streams a bala entry into path and returns the number of bytes written, failing once more than
+// remaining bytes would be written.
+func extractBalaEntry(fsys fs.FS, file *zip.File, path string, remaining int64) (int64, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+
+	perm := file.Mode().Perm()
+	if perm == 0 {
+		perm = 0o644
+	}
+
+	outFile, err := bfs.OpenFile(fsys, path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return 0, err
+	}
+	defer outFile.Close()
+
+	writer, ok := outFile.(io.Writer)
+	if !ok {
+		return 0, &fs.PathError{Op: "write", Path: path, Err: fs.ErrInvalid}
+	}
+
+	// The declared sizes were checked against the limits, but the content is counted as well in case they lie.
+	written, err := io.Copy(writer, io.LimitReader(rc, remaining+1))
+	if err != nil {
+		return written, err
+	}
+	if written > remaining {
+		return written, NewBalaLimitExceededError("bala expands to more than its declared size", LimitUncompressedSize)
+	}
+
+	return written, outFile.Close()
+}
@@ -0,0 +1,261 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package centralclient
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"hash/crc32"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"ballerina-lang-go/common/bfs"
+)
+
+type testZipEntry struct {
+	name    string
+	mode    fs.FileMode
+	content []byte
+	// declaredSize, if set, replaces the uncompressed size recorded for the entry.
+	declaredSize uint64
+}
+
+func TestExtractBalaHostileArchives(t *testing.T) {
+	smallLimits := ExtractionLimits{MaxUncompressedSize: 10, MaxEntries: 2, MaxCompressionRatio: DefaultExtractionLimits.MaxCompressionRatio}
+
+	tests := []struct {
+		name          string
+		entries       []testZipEntry
+		limits        ExtractionLimits
+		unsafeEntry   string
+		exceededLimit string
+	}{
+		{
+			name:        "parent traversal",
+			entries:     []testZipEntry{{name: "bala.json"}, {name: "../../.bashrc", content: []byte("evil")}},
+			unsafeEntry: "../../.bashrc",
+		},
+		{
+			name:        "nested traversal",
+			entries:     []testZipEntry{{name: "modules/../../evil", content: []byte("evil")}},
+			unsafeEntry: "modules/../../evil",
+		},
+		{
+			name:        "backslash traversal",
+			entries:     []testZipEntry{{name: `..\..\evil`, content: []byte("evil")}},
+			unsafeEntry: `..\..\evil`,
+		},
+		{
+			name:        "absolute path",
+			entries:     []testZipEntry{{name: "/etc/passwd", content: []byte("evil")}},
+			unsafeEntry: "/etc/passwd",
+		},
+		{
+			name:        "windows absolute path",
+			entries:     []testZipEntry{{name: `C:\Windows\evil.dll`, content: []byte("evil")}},
+			unsafeEntry: `C:\Windows\evil.dll`,
+		},
+		{
+			name:        "symbolic link",
+			entries:     []testZipEntry{{name: "modules/link", mode: fs.ModeSymlink | 0o777, content: []byte("/etc/passwd")}},
+			unsafeEntry: "modules/link",
+		},
+		{
+			name:        "named pipe",
+			entries:     []testZipEntry{{name: "pipe", mode: fs.ModeNamedPipe | 0o644}},
+			unsafeEntry: "pipe",
+		},
+		{
+			name:          "too many entries",
+			entries:       []testZipEntry{{name: "a"}, {name: "b"}, {name: "c"}},
+			limits:        smallLimits,
+			exceededLimit: LimitEntries,
+		},
+		{
+			name:          "too large",
+			entries:       []testZipEntry{{name: "a", content: []byte("0123456789a")}},
+			limits:        smallLimits,
+			exceededLimit: LimitUncompressedSize,
+		},
+		{
+			name:          "compression ratio",
+			entries:       []testZipEntry{{name: "bomb", content: make([]byte, 8<<20)}},
+			exceededLimit: LimitCompressionRatio,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			limits := test.limits
+			if limits == (ExtractionLimits{}) {
+				limits = DefaultExtractionLimits
+			}
+			memFS := bfs.NewMemFS()
+			if err := bfs.WriteFile(memFS, "hostile.bala", newTestZip(t, test.entries), 0o644); err != nil {
+				t.Fatalf("failed to write bala: %v", err)
+			}
+
+			err := extractBala(context.Background(), memFS, "hostile.bala", "cache/extracted", limits, ClientContext{})
+
+			var unsafeErr *UnsafeBalaEntryError
+			var limitErr *BalaLimitExceededError
+			switch {
+			case test.unsafeEntry != "":
+				if !errors.As(err, &unsafeErr) || unsafeErr.Entry() != test.unsafeEntry {
+					t.Fatalf("expected an unsafe entry error for %q, got %v", test.unsafeEntry, err)
+				}
+			case !errors.As(err, &limitErr) || limitErr.Limit() != test.exceededLimit:
+				t.Fatalf("expected the %s limit to be exceeded, got %v", test.exceededLimit, err)
+			}
+
+			entries, _ := fs.ReadDir(memFS, ".")
+			if len(entries) != 1 || entries[0].Name() != "hostile.bala" {
+				t.Errorf("expected nothing to be extracted, found %v", entries)
+			}
+		})
+	}
+}
+
+func TestExtractBalaDeclaredSizeMismatch(t *testing.T) {
+	memFS := bfs.NewMemFS()
+	bala := newTestZip(t, []testZipEntry{{name: "a", content: []byte("more than declared"), declaredSize: 4}})
+	if err := bfs.WriteFile(memFS, "lying.bala", bala, 0o644); err != nil {
+		t.Fatalf("failed to write bala: %v", err)
+	}
+	if err := extractBala(context.Background(), memFS, "lying.bala", "extracted", DefaultExtractionLimits, ClientContext{}); err == nil {
+		t.Error("expected an error for an entry larger than its declared size")
+	}
+}
+
+func TestExtractBalaValidArchive(t *testing.T) {
+	memFS := bfs.NewMemFS()
+	bala := newTestZip(t, []testZipEntry{
+		{name: "bala.json", content: []byte(`{"bala_version": "2.0.0"}`)},
+		{name: "docs/", mode: fs.ModeDir | 0o700},
+		{name: `modules\winery\main.bal`, content: []byte("public function main() {}")},
+		{name: "./package.json", content: []byte("{}")},
+	})
+	if err := bfs.WriteFile(memFS, "valid.bala", bala, 0o644); err != nil {
+		t.Fatalf("failed to write bala: %v", err)
+	}
+
+	if err := extractBala(context.Background(), memFS, "valid.bala", "extracted", DefaultExtractionLimits, ClientContext{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, name := range []string{"bala.json", "modules/winery/main.bal", "package.json"} {
+		if _, err := fs.Stat(memFS, filepath.Join("extracted", name)); err != nil {
+			t.Errorf("expected %s to be extracted: %v", name, err)
+		}
+	}
+	if info, err := fs.Stat(memFS, "extracted/docs"); err != nil || !info.IsDir() {
+		t.Errorf("expected the docs directory to be extracted: %v", err)
+	}
+}
+
+func TestExtractBalaZeroLimits(t *testing.T) {
+	memFS := bfs.NewMemFS()
+	bala := newTestZip(t, []testZipEntry{{name: "bala.json", content: []byte(`{"bala_version": "2.0.0"}`)}})
+	if err := bfs.WriteFile(memFS, "valid.bala", bala, 0o644); err != nil {
+		t.Fatalf("failed to write bala: %v", err)
+	}
+	if err := ExtractBala(context.Background(), memFS, "valid.bala", "extracted", ExtractionLimits{}); err != nil {
+		t.Fatalf("expected the zero limits to apply the default limits, got %v", err)
+	}
+
+	limits := ExtractionLimits{MaxEntries: 1}.withDefaults()
+	expected := DefaultExtractionLimits
+	expected.MaxEntries = 1
+	if limits != expected {
+		t.Errorf("expected only the zero limits to be defaulted, got %+v", limits)
+	}
+}
+
+func TestPullPackageRejectsHostileBala(t *testing.T) {
+	bala := newTestZip(t, []testZipEntry{{name: "bala.json"}, {name: "../../../.bashrc", content: []byte("evil")}})
+	balaFileName := "sf-2020r2-any-1.3.5.bala"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/registry/packages/wso2/sf/1.3.5" {
+			w.Header().Set(Location, "http://"+r.Host+"/bala/wso2/sf/1.3.5/"+balaFileName)
+			w.Header().Set(ContentDisposition, "attachment; filename="+balaFileName)
+			w.WriteHeader(http.StatusFound)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(bala)))
+		_, _ = w.Write(bala)
+	}))
+	defer server.Close()
+
+	memFS := bfs.NewMemFS()
+	client := NewCentralAPIClientFull(server.URL+"/registry", "", "", "", accessToken, 0, 0, 0, 0, 0)
+	err := client.PullPackage("wso2", "sf", "1.3.5", memFS, filepath.Join("bala", "wso2", "sf"), "any", testBalVersion, ClientContext{})
+
+	var unsafeErr *UnsafeBalaEntryError
+	if !errors.As(err, &unsafeErr) {
+		t.Fatalf("expected an unsafe entry error, got %v", err)
+	}
+	entries, _ := fs.ReadDir(memFS, filepath.Join("bala", "wso2", "sf"))
+	if len(entries) != 0 {
+		t.Errorf("expected nothing to be left in the bala cache, found %v", entries)
+	}
+	if _, err := fs.Stat(memFS, ".bashrc"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected the hostile entry not to be written, got %v", err)
+	}
+}
+
+// newTestZip returns a zip archive of entries. Entries with content are deflated; the others are stored empty.
+func newTestZip(t *testing.T, entries []testZipEntry) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for _, entry := range entries {
+		header := &zip.FileHeader{Name: entry.name, Method: zip.Deflate}
+		if entry.mode != 0 {
+			header.SetMode(entry.mode)
+		}
+
+		if entry.declaredSize != 0 {
+			header.Method = zip.Store
+			header.CRC32 = crc32.ChecksumIEEE(entry.content)
+			header.CompressedSize64 = uint64(len(entry.content))
+			header.UncompressedSize64 = entry.declaredSize
+			w, err := writer.CreateRaw(header)
+			if err != nil {
+				t.Fatalf("failed to create entry %s: %v", entry.name, err)
+			}
+			_, _ = w.Write(entry.content)
+			continue
+		}
+
+		w, err := writer.CreateHeader(header)
+		if err != nil {
+			t.Fatalf("failed to create entry %s: %v", entry.name, err)
+		}
+		if _, err := w.Write(entry.content); err != nil {
+			t.Fatalf("failed to write entry %s: %v", entry.name, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close zip: %v", err)
+	}
+	return buffer.Bytes()
+}
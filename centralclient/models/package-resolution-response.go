@@ -17,24 +17,24 @@
 package models
 
 type PackageResolutionResponse struct {
-	Resolved   []packageResolutionResponsePackage `json:"resolved"`
-	Unresolved []packageResolutionResponsePackage `json:"unresolved"`
+	Resolved   []PackageResolutionResponsePackage `json:"resolved"`
+	Unresolved []PackageResolutionResponsePackage `json:"unresolved"`
 }
 
 // PackageResolutionResponse.java:45-109
-type packageResolutionResponsePackage struct {
+type PackageResolutionResponsePackage struct {
 	Org              string                                `json:"org"`
 	Name             string                                `json:"name"`
 	Version          string                                `json:"version"`
-	DependencyGraph  []packageResolutionResponseDependency `json:"dependencyGraph"`
+	DependencyGraph  []PackageResolutionResponseDependency `json:"dependencyGraph"`
 	IsDeprecated     bool                                  `json:"isDeprecated"`
 	DeprecateMessage string                                `json:"deprecateMessage"`
 }
 
 // PackageResolutionResponse.java:114-158
-type packageResolutionResponseDependency struct {
+type PackageResolutionResponseDependency struct {
 	Org          string                                `json:"org"`
 	Name         string                                `json:"name"`
 	Version      string                                `json:"version"`
-	Dependencies []packageResolutionResponseDependency `json:"dependencies"`
+	Dependencies []PackageResolutionResponseDependency `json:"dependencies"`
 }
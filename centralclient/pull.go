@@ -0,0 +1,311 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package centralclient
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sync"
+)
+
+// DefaultPullConcurrency is the number of packages PullPackages pulls at the same time when PullOptions.Concurrency
+// is not set.
+const DefaultPullConcurrency = 4
+
+// PackageRef identifies a package to pull. An empty Version pulls the latest version of the package.
+type PackageRef struct {
+	Org     string
+	Name    string
+	Version string
+}
+
+func (r PackageRef) String() string {
+	return getPackageSignature(r.Org, r.Name, r.Version)
+}
+
+// PullOptions configures PullPackages.
+type PullOptions struct {
+	// FS is the filesystem of the bala cache, and BalaCacheDir its path in FS. A package is pulled into
+	// <BalaCacheDir>/<org>/<name>.
+	FS                fs.FS
+	BalaCacheDir      string
+	SupportedPlatform string
+	BallerinaVersion  string
+	// Concurrency is the maximum number of packages pulled at the same time.
+	Concurrency int
+	// OnProgress receives the progress events of all the packages, one at a time.
+	OnProgress func(event PullProgressEvent)
+	// OnWarning receives the warnings of all the packages, one at a time.
+	OnWarning func(msg string)
+	IsBuild   bool
+}
+
+// PullStatus is the state of a package reported by a PullProgressEvent.
+type PullStatus int
+
+const (
+	PullStarted PullStatus = iota
+	PullDownloading
+	PullRetrying
+	PullCompleted
+	// PullCached reports a package that was already in the bala cache.
+	PullCached
+	PullFailed
+)
+
+func (s PullStatus) String() string {
+	switch s {
+	case PullStarted:
+		return "started"
+	case PullDownloading:
+		return "downloading"
+	case PullRetrying:
+		return "retrying"
+	case PullCompleted:
+		return "completed"
+	case PullCached:
+		return "cached"
+	case PullFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// PullProgressEvent reports a change of the state of one package of a PullPackages call, together with the
+// progress of the whole call.
+type PullProgressEvent struct {
+	Package PackageRef
+	Status  PullStatus
+	// Percent is the download progress of Package, and Err the error of a failed pull.
+	Percent int
+	Err     error
+	// Done is the number of packages that completed, were cached or failed out of Total, and TotalPercent the download progress
+	// of all the packages.
+	Done         int
+	Total        int
+	TotalPercent int
+}
+
+// pullProgress aggregates the progress of the packages of a PullPackages call and serializes the calls to the
+// callbacks of PullOptions.
+type pullProgress struct {
+	mu       sync.Mutex
+	opts     PullOptions
+	percents map[PackageRef]int
+	done     map[PackageRef]bool
+}
+
+func newPullProgress(refs []PackageRef, opts PullOptions) *pullProgress {
+	p := &pullProgress{opts: opts, percents: make(map[PackageRef]int), done: make(map[PackageRef]bool)}
+	for _, ref := range refs {
+		p.percents[ref] = 0
+	}
+	return p
+}
+
+func (p *pullProgress) report(ref PackageRef, status PullStatus, percent int, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch status {
+	case PullStarted:
+		percent = 0
+	case PullCompleted, PullCached:
+		percent = 100
+	case PullRetrying, PullFailed:
+		percent = p.percents[ref]
+	}
+	p.percents[ref] = percent
+	if status == PullCompleted || status == PullCached || status == PullFailed {
+		p.done[ref] = true
+	}
+	if p.opts.OnProgress == nil {
+		return
+	}
+
+	// A failed package counts as fully downloaded, so that the total reaches 100% once every package is done.
+	total := 0
+	for ref, percent := range p.percents {
+		if p.done[ref] {
+			percent = 100
+		}
+		total += percent
+	}
+	p.opts.OnProgress(PullProgressEvent{
+		Package:      ref,
+		Status:       status,
+		Percent:      percent,
+		Err:          err,
+		Done:         len(p.done),
+		Total:        len(p.percents),
+		TotalPercent: total / len(p.percents),
+	})
+}
+
+func (p *pullProgress) warn(msg string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.opts.OnWarning != nil {
+		p.opts.OnWarning(msg)
+	}
+}
+
+// pullGroup runs the pulls of a package version into the same package directory one at a time, as they share the
+// <version>_temp directory of the version. A pull that waited for a pull of the same platform takes its result
+// instead of downloading the bala again.
+type pullGroup struct {
+	mu    sync.Mutex
+	calls map[string]*pullCall
+}
+
+type pullCall struct {
+	platform string
+	done     chan struct{}
+	err      error
+}
+
+func (g *pullGroup) do(ctx context.Context, key, platform string, pull func() error) error {
+	for {
+		g.mu.Lock()
+		if g.calls == nil {
+			g.calls = make(map[string]*pullCall)
+		}
+		if call, ok := g.calls[key]; ok {
+			g.mu.Unlock()
+			select {
+			case <-call.done:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			// A pull stopped by its own context says nothing about this one, so it is tried again.
+			canceled := errors.Is(call.err, context.Canceled) || errors.Is(call.err, context.DeadlineExceeded)
+			if call.platform == platform && !canceled {
+				return call.err
+			}
+			continue
+		}
+		call := &pullCall{platform: platform, done: make(chan struct{})}
+		g.calls[key] = call
+		g.mu.Unlock()
+
+		call.err = pull()
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(call.done)
+		return call.err
+	}
+}
+
+// PullPackages pulls packages into the bala cache of opts, at most opts.Concurrency at the same time. A package
+// listed more than once is pulled once, and the latest version of a package without a version is resolved first so
+// that it is pulled once with the refs naming that version. Pulls of the same version, by this call or by another
+// one of the client, share one download. The returned map has the error of each package, nil for the packages that
+// were pulled or already cached, and the error of ctx for the packages not pulled before ctx was done.
+func (c *centralAPIClientImpl) PullPackages(ctx context.Context, refs []PackageRef, opts PullOptions) map[PackageRef]error {
+	unique := make([]PackageRef, 0, len(refs))
+	seen := make(map[PackageRef]bool)
+	for _, ref := range refs {
+		if !seen[ref] {
+			seen[ref] = true
+			unique = append(unique, ref)
+		}
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultPullConcurrency
+	}
+
+	progress := newPullProgress(unique, opts)
+	results := make(map[PackageRef]error, len(unique))
+	var resultsMu sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, concurrency)
+
+	for _, ref := range unique {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if err := ctx.Err(); err != nil {
+			resultsMu.Lock()
+			results[ref] = err
+			resultsMu.Unlock()
+			progress.report(ref, PullFailed, 0, err)
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			err := c.pullOne(ctx, ref, opts, progress)
+			resultsMu.Lock()
+			results[ref] = err
+			resultsMu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+	return results
+}
+
+func (c *centralAPIClientImpl) pullOne(ctx context.Context, ref PackageRef, opts PullOptions, progress *pullProgress) error {
+	clientContext := ClientContext{
+		OnRetry: func(retryCount int) {
+			progress.report(ref, PullRetrying, 0, nil)
+		},
+		OnProgress: func(percentComplete int) {
+			progress.report(ref, PullDownloading, percentComplete, nil)
+		},
+		OnWarning: progress.warn,
+		IsBuild:   opts.IsBuild,
+	}
+
+	progress.report(ref, PullStarted, 0, nil)
+	version := ref.Version
+	if version == "" {
+		pkg, err := c.GetPackageCtx(ctx, ref.Org, ref.Name, "", opts.SupportedPlatform, opts.BallerinaVersion)
+		if err != nil {
+			progress.report(ref, PullFailed, 0, err)
+			return err
+		}
+		version = pkg.Version
+	}
+
+	pkgPath := filepath.Join(opts.BalaCacheDir, ref.Org, ref.Name)
+	err := c.pulls.do(ctx, pkgPath+":"+version, opts.SupportedPlatform, func() error {
+		return c.PullPackageCtx(ctx, ref.Org, ref.Name, version, opts.FS, pkgPath, opts.SupportedPlatform, opts.BallerinaVersion, clientContext)
+	})
+	var existsErr *PackageAlreadyExistsError
+	switch {
+	case errors.As(err, &existsErr):
+		progress.report(ref, PullCached, 100, nil)
+		return nil
+	case err != nil:
+		progress.report(ref, PullFailed, 0, err)
+		return err
+	}
+	progress.report(ref, PullCompleted, 100, nil)
+	return nil
+}
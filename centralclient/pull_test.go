@@ -0,0 +1,263 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package centralclient
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ballerina-lang-go/common/bfs"
+)
+
+func TestPullPackages(t *testing.T) {
+	server, stats := newPullServer(t, nil)
+	defer server.Close()
+
+	refs := []PackageRef{
+		{Org: "wso2", Name: "a", Version: "1.0.0"},
+		{Org: "wso2", Name: "b", Version: "1.0.0"},
+		{Org: "wso2", Name: "c", Version: "1.0.0"},
+		{Org: "wso2", Name: "a", Version: "1.0.0"},
+		{Org: "wso2", Name: "d", Version: "1.0.0"},
+		{Org: "wso2", Name: "missing", Version: "1.0.0"},
+	}
+	memFS := bfs.NewMemFS()
+	var events []PullProgressEvent
+	client := NewCentralAPIClientFull(server.URL+"/registry", "", "", "", accessToken, 0, 0, 0, 0, 0)
+	results := client.PullPackages(context.Background(), refs, PullOptions{
+		FS:                memFS,
+		BalaCacheDir:      "bala",
+		SupportedPlatform: "any",
+		BallerinaVersion:  testBalVersion,
+		Concurrency:       2,
+		OnProgress:        func(event PullProgressEvent) { events = append(events, event) },
+	})
+
+	if len(results) != 5 {
+		t.Fatalf("expected a result for each of the 5 distinct packages, got %v", results)
+	}
+	for _, name := range []string{"a", "b", "c", "d"} {
+		ref := PackageRef{Org: "wso2", Name: name, Version: "1.0.0"}
+		if err := results[ref]; err != nil {
+			t.Errorf("unexpected error for %s: %v", ref, err)
+		}
+		if _, err := fs.Stat(memFS, filepath.Join("bala", "wso2", name, "1.0.0", "any", "bala.json")); err != nil {
+			t.Errorf("expected %s to be in the bala cache: %v", ref, err)
+		}
+	}
+	if err := results[PackageRef{Org: "wso2", Name: "missing", Version: "1.0.0"}]; err == nil || !strings.Contains(err.Error(), "package not found") {
+		t.Errorf("expected a not found error for the missing package, got %v", err)
+	}
+
+	if got := stats.requests["a"].Load(); got != 1 {
+		t.Errorf("expected the duplicate package to be pulled once, got %d requests", got)
+	}
+	if got := stats.maxInFlight(); got > 2 {
+		t.Errorf("expected at most 2 downloads at the same time, got %d", got)
+	}
+
+	started, finished := map[string]int{}, map[string]int{}
+	for _, event := range events {
+		switch event.Status {
+		case PullStarted:
+			started[event.Package.Name]++
+		case PullCompleted, PullFailed:
+			finished[event.Package.Name]++
+		}
+		if event.Total != 5 {
+			t.Errorf("expected a total of 5 packages, got %+v", event)
+		}
+	}
+	for _, name := range []string{"a", "b", "c", "d", "missing"} {
+		if started[name] != 1 || finished[name] != 1 {
+			t.Errorf("expected one start and one finish event for %s, got %d and %d", name, started[name], finished[name])
+		}
+	}
+	if last := events[len(events)-1]; last.Done != 5 || last.TotalPercent != 100 {
+		t.Errorf("expected the last event to finish the pull, got %+v", last)
+	}
+}
+
+func TestPullPackagesCanceled(t *testing.T) {
+	server, _ := newPullServer(t, nil)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	refs := []PackageRef{{Org: "wso2", Name: "a", Version: "1.0.0"}, {Org: "wso2", Name: "b", Version: "1.0.0"}}
+	client := NewCentralAPIClientFull(server.URL+"/registry", "", "", "", accessToken, 0, 0, 0, 0, 0)
+	results := client.PullPackages(ctx, refs, PullOptions{FS: bfs.NewMemFS(), BalaCacheDir: "bala", SupportedPlatform: "any"})
+
+	for _, ref := range refs {
+		if !errors.Is(results[ref], context.Canceled) {
+			t.Errorf("expected %s to be canceled, got %v", ref, results[ref])
+		}
+	}
+}
+
+func TestPullPackagesSharesDownloads(t *testing.T) {
+	gate := make(chan struct{})
+	server, stats := newPullServer(t, gate)
+	defer server.Close()
+
+	// The latest version of wso2/a is 1.0.0, so the two refs share the download. It is held back until the latest
+	// version was resolved and the pull of 1.0.0 started, and a little longer, so that a second pull of the bala
+	// would reach the server while the first one is still downloading.
+	refs := []PackageRef{{Org: "wso2", Name: "a", Version: ""}, {Org: "wso2", Name: "a", Version: "1.0.0"}}
+	memFS := bfs.NewMemFS()
+	client := NewCentralAPIClientFull(server.URL+"/registry", "", "", "", accessToken, 0, 0, 0, 0, 0)
+	go func() {
+		deadline := time.Now().Add(5 * time.Second)
+		for (stats.resolves["a"].Load() == 0 || stats.requests["a"].Load() == 0) && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		time.Sleep(100 * time.Millisecond)
+		close(gate)
+	}()
+	var events []PullProgressEvent
+	results := client.PullPackages(context.Background(), refs, PullOptions{
+		FS:                memFS,
+		BalaCacheDir:      "bala",
+		SupportedPlatform: "any",
+		BallerinaVersion:  testBalVersion,
+		OnProgress:        func(event PullProgressEvent) { events = append(events, event) },
+	})
+
+	for _, ref := range refs {
+		if err, ok := results[ref]; !ok || err != nil {
+			t.Errorf("expected %s to be pulled, got %v", ref, err)
+		}
+	}
+	if got := stats.requests["a"].Load(); got != 1 {
+		t.Errorf("expected the bala to be downloaded once, got %d requests", got)
+	}
+	if _, err := fs.Stat(memFS, filepath.Join("bala", "wso2", "a", "1.0.0", "any", "bala.json")); err != nil {
+		t.Errorf("expected wso2/a:1.0.0 to be in the bala cache: %v", err)
+	}
+	if last := events[len(events)-1]; last.Done != 2 || last.TotalPercent != 100 {
+		t.Errorf("expected the last event to finish the pull, got %+v", last)
+	}
+}
+
+func TestPullPackagesCached(t *testing.T) {
+	server, _ := newPullServer(t, nil)
+	defer server.Close()
+
+	memFS := bfs.NewMemFS()
+	if err := bfs.MkdirAll(memFS, filepath.Join("bala", "wso2", "a", "1.0.0", "any"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := bfs.WriteFile(memFS, filepath.Join("bala", "wso2", "a", "1.0.0", "any", "bala.json"), []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ref := PackageRef{Org: "wso2", Name: "a", Version: "1.0.0"}
+	var statuses []PullStatus
+	client := NewCentralAPIClientFull(server.URL+"/registry", "", "", "", accessToken, 0, 0, 0, 0, 0)
+	results := client.PullPackages(context.Background(), []PackageRef{ref}, PullOptions{
+		FS:                memFS,
+		BalaCacheDir:      "bala",
+		SupportedPlatform: "any",
+		BallerinaVersion:  testBalVersion,
+		OnProgress:        func(event PullProgressEvent) { statuses = append(statuses, event.Status) },
+	})
+
+	if err, ok := results[ref]; !ok || err != nil {
+		t.Errorf("expected the cached package to succeed, got %v", err)
+	}
+	if !slices.Equal(statuses, []PullStatus{PullStarted, PullCached}) {
+		t.Errorf("expected the package to be reported as cached, got %v", statuses)
+	}
+}
+
+type pullServerStats struct {
+	resolves map[string]*atomic.Int32
+	requests map[string]*atomic.Int32
+	mu       sync.Mutex
+	inFlight int
+	max      int
+}
+
+func (s *pullServerStats) maxInFlight() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.max
+}
+
+// newPullServer serves version 1.0.0 of any wso2 package except wso2/missing, as the latest version too, and records
+// the resolve and pull requests per package and the number of downloads served at the same time. A download waits for gate to
+// be closed when gate is not nil.
+func newPullServer(t *testing.T, gate <-chan struct{}) (*httptest.Server, *pullServerStats) {
+	t.Helper()
+	bala := newTestZip(t, []testZipEntry{{name: "bala.json", content: []byte("{}")}})
+	stats := &pullServerStats{resolves: make(map[string]*atomic.Int32), requests: make(map[string]*atomic.Int32)}
+	for _, name := range []string{"a", "b", "c", "d", "missing"} {
+		stats.resolves[name] = &atomic.Int32{}
+		stats.requests[name] = &atomic.Int32{}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if name, ok := strings.CutPrefix(r.URL.Path, "/registry/packages/wso2/"); ok {
+			if !strings.Contains(name, "/") {
+				w.Header().Set(ContentType, ApplicationJSON)
+				_, _ = w.Write([]byte(`{"organization": "wso2", "name": "` + name + `", "version": "1.0.0"}`))
+				stats.resolves[name].Add(1)
+				return
+			}
+			name = strings.TrimSuffix(name, "/1.0.0")
+			stats.requests[name].Add(1)
+			if name == "missing" {
+				w.Header().Set(ContentType, ApplicationJSON)
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "package not found"}`))
+				return
+			}
+			balaFileName := name + "-any-1.0.0.bala"
+			w.Header().Set(Location, "http://"+r.Host+"/bala/wso2/"+name+"/1.0.0/"+balaFileName)
+			w.Header().Set(ContentDisposition, "attachment; filename="+balaFileName)
+			w.WriteHeader(http.StatusFound)
+			return
+		}
+
+		stats.mu.Lock()
+		stats.inFlight++
+		stats.max = max(stats.max, stats.inFlight)
+		stats.mu.Unlock()
+		defer func() {
+			stats.mu.Lock()
+			stats.inFlight--
+			stats.mu.Unlock()
+		}()
+		time.Sleep(20 * time.Millisecond)
+		if gate != nil {
+			<-gate
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(bala)))
+		_, _ = w.Write(bala)
+	}))
+	return server, stats
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package centralclient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"time"
+
+	"ballerina-lang-go/common/bfs"
+)
+
+// ResponseCacheOptions configures the on-disk cache of the responses of GetPackage, GetPackageVersions,
+// GetConnectors and GetTriggers.
+type ResponseCacheOptions struct {
+	// FS is the filesystem of the cache, and Dir its directory in FS.
+	FS  fs.FS
+	Dir string
+	// TTL is how long a cached response is used without asking Central. An older response is revalidated with a
+	// conditional request using its ETag or Last-Modified header.
+	TTL time.Duration
+	// Offline serves a cached response, however old, when Central cannot be reached, reporting it through
+	// OnWarning.
+	Offline   bool
+	OnWarning func(msg string)
+}
+
+// cachedResponse is a successful response of Central as stored in the response cache.
+type cachedResponse struct {
+	URL         string      `json:"url"`
+	StatusCode  int         `json:"statusCode"`
+	Header      http.Header `json:"header"`
+	Body        []byte      `json:"body"`
+	ValidatedAt time.Time   `json:"validatedAt"`
+}
+
+// cachedHeaders are the headers of a response kept in the response cache.
+var cachedHeaders = []string{ContentType, ETag, LastModified}
+
+type responseCache struct {
+	options ResponseCacheOptions
+	clock   clock
+}
+
+func newResponseCache(options ResponseCacheOptions) *responseCache {
+	return &responseCache{options: options, clock: systemClock{}}
+}
+
+// path returns the path of the cached response of req. Requests differ by the platform, the Ballerina version and
+// the access token they are sent with, so these are part of the key, which is hashed to keep the token out of the
+// cache.
+func (rc *responseCache) path(req *http.Request) string {
+	hash := sha256.New()
+	for _, part := range []string{req.Method, req.URL.String(), req.Header.Get(BallerinaPlatform), req.Header.Get(UserAgent), req.Header.Get(Authorization)} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return filepath.Join(rc.options.Dir, hex.EncodeToString(hash.Sum(nil))+".json")
+}
+
+func (rc *responseCache) load(req *http.Request) *cachedResponse {
+	data, err := fs.ReadFile(rc.options.FS, rc.path(req))
+	if err != nil {
+		return nil
+	}
+	var cached cachedResponse
+	if err := json.Unmarshal(data, &cached); err != nil || cached.URL != req.URL.String() {
+		return nil
+	}
+	return &cached
+}
+
+// store writes cached into the cache. The cache is best effort, so a response that cannot be stored is only
+// fetched again next time.
+func (rc *responseCache) store(req *http.Request, cached *cachedResponse) {
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return
+	}
+	_ = bfs.WriteFile(rc.options.FS, rc.path(req), data, 0o644)
+}
+
+func (rc *responseCache) warn(msg string) {
+	if rc.options.OnWarning != nil {
+		rc.options.OnWarning(msg)
+	}
+}
+
+// response returns a response for req with the status, headers and body of cached.
+func (cached *cachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", cached.StatusCode, http.StatusText(cached.StatusCode)),
+		StatusCode:    cached.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        cached.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(cached.Body)),
+		ContentLength: int64(len(cached.Body)),
+		Request:       req,
+	}
+}
+
+// doCached sends req through the response cache, if there is one. A fresh cached response is returned without
+// asking Central; a stale one is revalidated, and returned when Central answers 304 Not Modified.
+func (c *centralAPIClientImpl) doCached(req *http.Request) (*http.Response, error) {
+	rc := c.responseCache
+	if rc == nil || req.Method != http.MethodGet {
+		return c.httpClient.Do(req)
+	}
+
+	now := rc.clock.Now()
+	cached := rc.load(req)
+	if cached != nil && now.Sub(cached.ValidatedAt) < rc.options.TTL {
+		return cached.response(req), nil
+	}
+	if cached != nil {
+		if etag := cached.Header.Get(ETag); etag != "" {
+			req.Header.Set(IfNoneMatch, etag)
+		}
+		if lastModified := cached.Header.Get(LastModified); lastModified != "" {
+			req.Header.Set(IfModifiedSince, lastModified)
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		cause := err
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			cause = urlErr.Err
+		}
+		if cached != nil && rc.options.Offline && isConnectionError(cause) {
+			rc.warn(fmt.Sprintf("WARNING: could not connect to central, using the response cached at %s for %s",
+				cached.ValidatedAt.Format(time.RFC3339), req.URL.String()))
+			return cached.response(req), nil
+		}
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		resp.Body.Close()
+		cached.ValidatedAt = now
+		rc.store(req, cached)
+		return cached.response(req), nil
+
+	case resp.StatusCode == http.StatusOK:
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		header := make(http.Header)
+		for _, name := range cachedHeaders {
+			if value := resp.Header.Get(name); value != "" {
+				header.Set(name, value)
+			}
+		}
+		rc.store(req, &cachedResponse{
+			URL:         req.URL.String(),
+			StatusCode:  resp.StatusCode,
+			Header:      header,
+			Body:        body,
+			ValidatedAt: now,
+		})
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return resp, nil
+	}
+
+	return resp, nil
+}
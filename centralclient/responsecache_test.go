@@ -0,0 +1,204 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package centralclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"ballerina-lang-go/common/bfs"
+)
+
+// versionsServer serves the versions of wso2/winery, validating conditional requests with either an ETag or a
+// Last-Modified header.
+type versionsServer struct {
+	mu           sync.Mutex
+	versions     string
+	revision     int
+	lastModified bool
+	requests     []string
+}
+
+func (s *versionsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	validator := fmt.Sprintf(`"r%d"`, s.revision)
+	received := r.Header.Get(IfNoneMatch)
+	if s.lastModified {
+		validator = testNow.Add(time.Duration(s.revision) * time.Hour).Format(http.TimeFormat)
+		received = r.Header.Get(IfModifiedSince)
+	}
+	s.requests = append(s.requests, received)
+
+	if !strings.HasPrefix(r.URL.Path, "/registry/packages/wso2/winery") {
+		w.Header().Set(ContentType, ApplicationJSON)
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "organization not found"}`))
+		return
+	}
+	if received == validator {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	if s.lastModified {
+		w.Header().Set(LastModified, validator)
+	} else {
+		w.Header().Set(ETag, validator)
+	}
+	w.Header().Set(ContentType, ApplicationJSON)
+	_, _ = w.Write([]byte(s.versions))
+}
+
+func (s *versionsServer) update(versions string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.versions = versions
+	s.revision++
+}
+
+func (s *versionsServer) conditionalHeaders() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+func newCachedTestClient(t *testing.T, baseURL string, options ResponseCacheOptions) (CentralAPIClient, *fakeClock) {
+	t.Helper()
+	client := NewCentralAPIClientFull(baseURL+"/registry", "", "", "", accessToken, 0, 0, 0, 0, 0)
+	client.SetResponseCache(&options)
+	clock := &fakeClock{now: testNow}
+	client.(*centralAPIClientImpl).responseCache.clock = clock
+	return client, clock
+}
+
+func TestResponseCacheRevalidation(t *testing.T) {
+	for _, lastModified := range []bool{false, true} {
+		name := "etag"
+		validator := `"r0"`
+		if lastModified {
+			name = "last modified"
+			validator = testNow.Format(http.TimeFormat)
+		}
+		t.Run(name, func(t *testing.T) {
+			handler := &versionsServer{versions: `["1.0.0"]`, lastModified: lastModified}
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			client, clock := newCachedTestClient(t, server.URL, ResponseCacheOptions{FS: bfs.NewMemFS(), Dir: "cache", TTL: time.Minute})
+			getVersions := func() []string {
+				t.Helper()
+				versions, err := client.GetPackageVersions("wso2", "winery", "any", testBalVersion)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return versions
+			}
+
+			// The first call fills the cache, which answers the second call within the TTL.
+			getVersions()
+			clock.now = clock.now.Add(30 * time.Second)
+			if versions := getVersions(); !slices.Equal(versions, []string{"1.0.0"}) {
+				t.Errorf("unexpected cached versions: %v", versions)
+			}
+			// After the TTL, the cached response is revalidated and kept on a 304.
+			clock.now = clock.now.Add(time.Minute)
+			if versions := getVersions(); !slices.Equal(versions, []string{"1.0.0"}) {
+				t.Errorf("unexpected revalidated versions: %v", versions)
+			}
+			// A revalidation resets the TTL.
+			clock.now = clock.now.Add(30 * time.Second)
+			getVersions()
+			// A changed response replaces the cached one.
+			handler.update(`["1.0.0", "1.1.0"]`)
+			clock.now = clock.now.Add(time.Minute)
+			if versions := getVersions(); !slices.Equal(versions, []string{"1.0.0", "1.1.0"}) {
+				t.Errorf("expected the updated versions, got %v", versions)
+			}
+
+			expected := []string{"", validator, validator}
+			if got := handler.conditionalHeaders(); !slices.Equal(got, expected) {
+				t.Errorf("expected requests with validators %q, got %q", expected, got)
+			}
+		})
+	}
+}
+
+func TestResponseCacheOffline(t *testing.T) {
+	for _, offline := range []bool{false, true} {
+		t.Run(fmt.Sprintf("offline %t", offline), func(t *testing.T) {
+			server := httptest.NewServer(&versionsServer{versions: `["1.0.0"]`})
+			var warnings []string
+			client, clock := newCachedTestClient(t, server.URL, ResponseCacheOptions{
+				FS:        bfs.NewMemFS(),
+				Dir:       "cache",
+				TTL:       time.Minute,
+				Offline:   offline,
+				OnWarning: func(msg string) { warnings = append(warnings, msg) },
+			})
+			if _, err := client.GetPackageVersions("wso2", "winery", "any", testBalVersion); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			server.Close()
+			clock.now = clock.now.Add(time.Hour)
+			versions, err := client.GetPackageVersions("wso2", "winery", "any", testBalVersion)
+			if !offline {
+				if err == nil {
+					t.Errorf("expected an error when central is unreachable, got %v", versions)
+				}
+				return
+			}
+			if err != nil || !slices.Equal(versions, []string{"1.0.0"}) {
+				t.Fatalf("expected the stale versions, got %v, %v", versions, err)
+			}
+			if len(warnings) != 1 || !strings.Contains(warnings[0], "could not connect to central") {
+				t.Errorf("expected a warning about the stale response, got %v", warnings)
+			}
+		})
+	}
+}
+
+func TestResponseCacheKeys(t *testing.T) {
+	handler := &versionsServer{versions: `["1.0.0"]`}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	memFS := bfs.NewMemFS()
+	client, _ := newCachedTestClient(t, server.URL, ResponseCacheOptions{FS: memFS, Dir: "cache", TTL: time.Hour})
+	for range 2 {
+		_, _ = client.GetPackageVersions("wso2", "winery", "any", testBalVersion)
+		_, _ = client.GetPackageVersions("wso2", "winery", "java21", testBalVersion)
+		// Errors are not cached.
+		_, _ = client.GetPackageVersions("foo", "winery", "any", testBalVersion)
+	}
+	if got := len(handler.conditionalHeaders()); got != 4 {
+		t.Errorf("expected a request for each platform and each error, got %d requests", got)
+	}
+
+	client.SetResponseCache(nil)
+	_, _ = client.GetPackageVersions("wso2", "winery", "any", testBalVersion)
+	if got := len(handler.conditionalHeaders()); got != 5 {
+		t.Errorf("expected a request without the cache, got %d requests", got)
+	}
+}
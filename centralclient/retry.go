@@ -0,0 +1,286 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package centralclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// clock is the source of time of customRetryTransport, replaced in tests so that backoff delays can be checked
+// without waiting for them.
+type clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+type onRetryKey struct{}
+
+// withOnRetry returns a context that makes customRetryTransport report each retry of a request to onRetry.
+func withOnRetry(ctx context.Context, onRetry func(retryCount int)) context.Context {
+	if onRetry == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, onRetryKey{}, onRetry)
+}
+
+// customRetryTransport retries requests that fail with a 429 or 5xx response, and idempotent requests that fail
+// with a connection error. Retries wait for an exponential backoff with jitter, or for the delay of the Retry-After
+// header of a 429 or 503 response.
+type customRetryTransport struct {
+	transport  http.RoundTripper
+	maxRetries int
+	baseURL    string
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+	clock      clock
+	// random returns a number in [0, 1) used for the jitter of the backoff.
+	random func() float64
+}
+
+func newRetryTransport(transport http.RoundTripper, maxRetries int, baseURL string) *customRetryTransport {
+	return &customRetryTransport{
+		transport:  transport,
+		maxRetries: maxRetries,
+		baseURL:    baseURL,
+		baseDelay:  RetryBaseDelay,
+		maxDelay:   RetryMaxDelay,
+		clock:      systemClock{},
+		random:     rand.Float64,
+	}
+}
+
+func (r *customRetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Keep the body of the request so that it can be sent again on a retry. Bodies that can already be reopened
+	// through GetBody are streamed as they are.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		bodyBytes, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(bodyBytes)), nil
+		}
+	}
+
+	ctx := req.Context()
+	for retryCount := 0; ; retryCount++ {
+		attempt := req
+		if retryCount > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attempt = req.Clone(ctx)
+			attempt.Body = body
+		}
+
+		resp, err := r.transport.RoundTrip(attempt)
+		if retryCount == r.maxRetries {
+			return resp, err
+		}
+
+		var delay time.Duration
+		if err != nil {
+			if !isIdempotent(req.Method) || !isConnectionError(err) || ctx.Err() != nil {
+				return nil, err
+			}
+			r.logConnectionRetryVerbose(err, req, retryCount+1)
+			delay = r.backoff(retryCount)
+		} else {
+			if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+				return resp, nil
+			}
+			var ok bool
+			if delay, ok = r.retryDelay(resp, retryCount); !ok {
+				return resp, nil
+			}
+
+			var bodyContent string
+			if resp.Body != nil {
+				bodyBytes, readErr := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				if readErr == nil {
+					bodyContent = string(bodyBytes)
+				}
+			}
+			r.logRetryVerbose(resp, bodyContent, req, retryCount+1)
+		}
+
+		if err := r.wait(ctx, delay); err != nil {
+			return nil, err
+		}
+		if onRetry, ok := ctx.Value(onRetryKey{}).(func(int)); ok {
+			onRetry(retryCount + 1)
+		}
+	}
+}
+
+// retryDelay returns the delay before retrying a request that got resp. The Retry-After header of a 429 or 503
+// response is honored; the request is not retried when it asks for a longer delay than maxDelay.
+func (r *customRetryTransport) retryDelay(resp *http.Response, retryCount int) (time.Duration, bool) {
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		if delay, ok := parseRetryAfter(resp.Header.Get(RetryAfter), r.clock.Now()); ok {
+			return delay, delay <= r.maxDelay
+		}
+	}
+	return r.backoff(retryCount), true
+}
+
+// backoff returns the delay before the retry that follows retryCount retries: baseDelay doubled for each retry, at
+// most maxDelay, of which the upper half is random.
+func (r *customRetryTransport) backoff(retryCount int) time.Duration {
+	delay := r.maxDelay
+	if retryCount < 32 && r.baseDelay<<retryCount < r.maxDelay {
+		delay = r.baseDelay << retryCount
+	}
+	return delay/2 + time.Duration(r.random()*float64(delay/2))
+}
+
+func (r *customRetryTransport) wait(ctx context.Context, delay time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if delay <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-r.clock.After(delay):
+		return nil
+	}
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a number of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	return max(date.Sub(now), 0), true
+}
+
+// isIdempotent reports whether a request with method can be sent again after a connection error without changing
+// its effect.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// bodyReadError is a failure to read the body of a response that the transport has already returned, which the
+// transport cannot retry.
+type bodyReadError struct {
+	err error
+}
+
+func (e *bodyReadError) Error() string {
+	return "error reading stream: " + e.err.Error()
+}
+
+func (e *bodyReadError) Unwrap() error {
+	return e.err
+}
+
+// isBodyConnectionError reports whether err is a connection failure while reading the body of a response.
+func isBodyConnectionError(err error) bool {
+	var readErr *bodyReadError
+	return errors.As(err, &readErr) && isConnectionError(readErr.err)
+}
+
+// isConnectionError reports whether err is a failure of the connection to Central, such as a reset or a timeout,
+// rather than an error of the request itself.
+func isConnectionError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		strings.Contains(err.Error(), ConnectionReset)
+}
+
+func (r *customRetryTransport) logRetryVerbose(resp *http.Response, bodyContent string, req *http.Request, retryCount int) {
+	if !isVerboseEnabled() {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "< HTTP %d %s\n", resp.StatusCode, resp.Status)
+
+	for name, values := range resp.Header {
+		for _, value := range values {
+			fmt.Fprintf(os.Stderr, "> %s: %s\n", name, value)
+		}
+	}
+
+	fmt.Fprintln(os.Stderr, "< ")
+
+	if bodyContent != "" {
+		fmt.Fprintln(os.Stderr, bodyContent)
+	}
+
+	fmt.Fprintf(os.Stderr, "* Connection to host %s left intact \n\n", r.baseURL)
+	fmt.Fprintf(os.Stderr, "* Retrying request to %s due to %d response code. Retry attempt: %d\n",
+		req.URL.String(), resp.StatusCode, retryCount)
+}
+
+func (r *customRetryTransport) logConnectionRetryVerbose(err error, req *http.Request, retryCount int) {
+	if isVerboseEnabled() {
+		fmt.Fprintf(os.Stderr, "* Retrying request to %s due to %s. Retry attempt: %d\n", req.URL.String(), err.Error(), retryCount)
+	}
+}
@@ -0,0 +1,321 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package centralclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"ballerina-lang-go/common/bfs"
+)
+
+var testNow = time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeClock is a clock set by the test. It records the delays waited for and returns at once, unless blocked is set.
+type fakeClock struct {
+	now     time.Time
+	delays  []time.Duration
+	blocked bool
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.delays = append(c.delays, d)
+	ch := make(chan time.Time, 1)
+	if !c.blocked {
+		ch <- c.now.Add(d)
+	}
+	return ch
+}
+
+// testResponse is a response of a stand-in transport; a non-nil err is returned instead of a response.
+type testResponse struct {
+	status     int
+	retryAfter string
+	err        error
+}
+
+// newTestRetryTransport returns a transport with a base delay of 100ms, a maximum delay of 1s and a jitter of half
+// the random range, which answers the requests with responses in order and records the bodies it received.
+func newTestRetryTransport(maxRetries int, responses []testResponse, bodies *[]string) (*customRetryTransport, *fakeClock) {
+	clock := &fakeClock{now: testNow}
+	attempt := 0
+	transport := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		response := responses[min(attempt, len(responses)-1)]
+		attempt++
+		if req.Body != nil && bodies != nil {
+			body, _ := io.ReadAll(req.Body)
+			*bodies = append(*bodies, string(body))
+		}
+		if response.err != nil {
+			return nil, response.err
+		}
+		resp := newJSONResponse(response.status, `{"message": "status"}`, req)
+		if response.retryAfter != "" {
+			resp.Header.Set(RetryAfter, response.retryAfter)
+		}
+		return resp, nil
+	})
+	return &customRetryTransport{
+		transport:  transport,
+		maxRetries: maxRetries,
+		baseURL:    "https://localhost:9090/registry",
+		baseDelay:  100 * time.Millisecond,
+		maxDelay:   time.Second,
+		clock:      clock,
+		random:     func() float64 { return 0.5 },
+	}, clock
+}
+
+func TestRetryTransport(t *testing.T) {
+	reset := fmt.Errorf("read tcp: %w", syscall.ECONNRESET)
+	tests := []struct {
+		name       string
+		method     string
+		maxRetries int
+		responses  []testResponse
+		wantStatus int
+		wantErr    error
+		wantDelays []time.Duration
+	}{
+		{
+			name:       "exponential backoff",
+			method:     http.MethodGet,
+			maxRetries: 5,
+			responses:  []testResponse{{status: 500}, {status: 502}, {status: 500}, {status: 200}},
+			wantStatus: 200,
+			wantDelays: []time.Duration{75 * time.Millisecond, 150 * time.Millisecond, 300 * time.Millisecond},
+		},
+		{
+			name:       "backoff capped at the maximum delay",
+			method:     http.MethodGet,
+			maxRetries: 6,
+			responses:  []testResponse{{status: 500}, {status: 500}, {status: 500}, {status: 500}, {status: 500}, {status: 200}},
+			wantStatus: 200,
+			wantDelays: []time.Duration{75 * time.Millisecond, 150 * time.Millisecond, 300 * time.Millisecond, 600 * time.Millisecond, 750 * time.Millisecond},
+		},
+		{
+			name:       "retries exhausted",
+			method:     http.MethodGet,
+			maxRetries: 2,
+			responses:  []testResponse{{status: 503}},
+			wantStatus: 503,
+			wantDelays: []time.Duration{75 * time.Millisecond, 150 * time.Millisecond},
+		},
+		{
+			name:       "client errors are not retried",
+			method:     http.MethodGet,
+			maxRetries: 2,
+			responses:  []testResponse{{status: 404}},
+			wantStatus: 404,
+		},
+		{
+			name:       "retry after seconds on 429",
+			method:     http.MethodPost,
+			maxRetries: 2,
+			responses:  []testResponse{{status: 429, retryAfter: "1"}, {status: 200}},
+			wantStatus: 200,
+			wantDelays: []time.Duration{time.Second},
+		},
+		{
+			name:       "retry after date on 503",
+			method:     http.MethodGet,
+			maxRetries: 2,
+			responses:  []testResponse{{status: 503, retryAfter: testNow.Add(time.Second).Format(http.TimeFormat)}, {status: 200}},
+			wantStatus: 200,
+			wantDelays: []time.Duration{time.Second},
+		},
+		{
+			name:       "retry after longer than the maximum delay",
+			method:     http.MethodGet,
+			maxRetries: 2,
+			responses:  []testResponse{{status: 429, retryAfter: "120"}, {status: 200}},
+			wantStatus: 429,
+		},
+		{
+			name:       "invalid retry after falls back to backoff",
+			method:     http.MethodGet,
+			maxRetries: 2,
+			responses:  []testResponse{{status: 503, retryAfter: "soon"}, {status: 200}},
+			wantStatus: 200,
+			wantDelays: []time.Duration{75 * time.Millisecond},
+		},
+		{
+			name:       "connection error of an idempotent request",
+			method:     http.MethodGet,
+			maxRetries: 2,
+			responses:  []testResponse{{err: reset}, {err: io.ErrUnexpectedEOF}, {status: 200}},
+			wantStatus: 200,
+			wantDelays: []time.Duration{75 * time.Millisecond, 150 * time.Millisecond},
+		},
+		{
+			name:       "connection error of a non-idempotent request",
+			method:     http.MethodPost,
+			maxRetries: 2,
+			responses:  []testResponse{{err: reset}, {status: 200}},
+			wantErr:    syscall.ECONNRESET,
+		},
+		{
+			name:       "connection errors exhausted",
+			method:     http.MethodGet,
+			maxRetries: 1,
+			responses:  []testResponse{{err: reset}},
+			wantErr:    syscall.ECONNRESET,
+			wantDelays: []time.Duration{75 * time.Millisecond},
+		},
+		{
+			name:       "other errors are not retried",
+			method:     http.MethodGet,
+			maxRetries: 2,
+			responses:  []testResponse{{err: errors.New("x509: certificate signed by unknown authority")}, {status: 200}},
+			wantErr:    errors.New("x509: certificate signed by unknown authority"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var bodies []string
+			transport, clock := newTestRetryTransport(test.maxRetries, test.responses, &bodies)
+			var retries []int
+			ctx := withOnRetry(context.Background(), func(retryCount int) { retries = append(retries, retryCount) })
+			req, err := http.NewRequestWithContext(ctx, test.method, "https://localhost:9090/registry/packages", strings.NewReader("payload"))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			req.GetBody = nil
+
+			resp, err := transport.RoundTrip(req)
+			switch {
+			case test.wantErr != nil:
+				if err == nil || !errors.Is(err, test.wantErr) && err.Error() != test.wantErr.Error() {
+					t.Fatalf("expected error %v, got %v", test.wantErr, err)
+				}
+			case err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case resp.StatusCode != test.wantStatus:
+				t.Errorf("expected status %d, got %d", test.wantStatus, resp.StatusCode)
+			}
+
+			if !slices.Equal(clock.delays, test.wantDelays) {
+				t.Errorf("expected delays %v, got %v", test.wantDelays, clock.delays)
+			}
+			wantRetries := make([]int, len(test.wantDelays))
+			for i := range wantRetries {
+				wantRetries[i] = i + 1
+			}
+			if !slices.Equal(retries, wantRetries) {
+				t.Errorf("expected retries %v, got %v", wantRetries, retries)
+			}
+			for i, body := range bodies {
+				if body != "payload" {
+					t.Errorf("expected attempt %d to send the request body, got %q", i+1, body)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryTransportCanceledDuringBackoff(t *testing.T) {
+	transport, clock := newTestRetryTransport(3, []testResponse{{status: 503}}, nil)
+	clock.blocked = true
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://localhost:9090/registry/packages", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := transport.RoundTrip(req)
+		done <- err
+	}()
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value     string
+		wantDelay time.Duration
+		wantOK    bool
+	}{
+		{value: "", wantOK: false},
+		{value: "0", wantDelay: 0, wantOK: true},
+		{value: " 30 ", wantDelay: 30 * time.Second, wantOK: true},
+		{value: "-1", wantOK: false},
+		{value: testNow.Add(time.Minute).Format(http.TimeFormat), wantDelay: time.Minute, wantOK: true},
+		{value: testNow.Add(-time.Minute).Format(http.TimeFormat), wantDelay: 0, wantOK: true},
+		{value: "tomorrow", wantOK: false},
+	}
+
+	for _, test := range tests {
+		delay, ok := parseRetryAfter(test.value, testNow)
+		if delay != test.wantDelay || ok != test.wantOK {
+			t.Errorf("parseRetryAfter(%q) = %v, %t, expected %v, %t", test.value, delay, ok, test.wantDelay, test.wantOK)
+		}
+	}
+}
+
+func TestPullPackageReportsRetries(t *testing.T) {
+	bala := newTestZip(t, []testZipEntry{{name: "bala.json", content: []byte("{}")}})
+	balaFileName := "sf-any-1.3.5.bala"
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/registry/packages/wso2/sf/1.3.5" {
+			attempts++
+			if attempts == 1 {
+				w.Header().Set(RetryAfter, "0")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Header().Set(Location, "http://"+r.Host+"/bala/wso2/sf/1.3.5/"+balaFileName)
+			w.Header().Set(ContentDisposition, "attachment; filename="+balaFileName)
+			w.WriteHeader(http.StatusFound)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(bala)))
+		_, _ = w.Write(bala)
+	}))
+	defer server.Close()
+
+	var retries []int
+	clientContext := ClientContext{OnRetry: func(retryCount int) { retries = append(retries, retryCount) }}
+	client := NewCentralAPIClientFull(server.URL+"/registry", "", "", "", accessToken, 0, 0, 0, 0, 2)
+	err := client.PullPackage("wso2", "sf", "1.3.5", bfs.NewMemFS(), filepath.Join("bala", "wso2", "sf"), "any", testBalVersion, clientContext)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempts != 2 || !slices.Equal(retries, []int{1}) {
+		t.Errorf("expected one reported retry, got %d attempts and retries %v", attempts, retries)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package centralclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"ballerina-lang-go/centralclient/models"
+)
+
+// Sort orders accepted by SearchPackages.
+const (
+	SortByRelevance   = "relevance"
+	SortByPullCount   = "pullCount,DESC"
+	SortByCreatedDate = "createdDate,DESC"
+)
+
+// DefaultSearchLimit is the page size used when SearchPackages is called with a non-positive limit.
+const DefaultSearchLimit = 10
+
+// PackageSearchQuery is a Central package search. Text is matched against the names, summaries and keywords of
+// packages, while Org, Name and Keyword restrict the results to packages with exactly that organization, name or
+// keyword.
+type PackageSearchQuery struct {
+	Text    string
+	Org     string
+	Name    string
+	Keyword string
+}
+
+// String returns the query in the syntax of the Central search API, e.g. "http org:ballerina keyword:network".
+func (q PackageSearchQuery) String() string {
+	var terms []string
+	if text := strings.TrimSpace(q.Text); text != "" {
+		terms = append(terms, text)
+	}
+	for _, filter := range []struct{ name, value string }{{"org", q.Org}, {"name", q.Name}, {"keyword", q.Keyword}} {
+		if value := strings.TrimSpace(filter.value); value != "" {
+			terms = append(terms, filter.name+":"+value)
+		}
+	}
+	return strings.Join(terms, " ")
+}
+
+func (c *centralAPIClientImpl) SearchPackages(query PackageSearchQuery, limit, offset int, sort, supportedPlatform, ballerinaVersion string) (*models.PackageSearchResult, error) {
+	return c.SearchPackagesCtx(context.Background(), query, limit, offset, sort, supportedPlatform, ballerinaVersion)
+}
+
+func (c *centralAPIClientImpl) SearchPackagesCtx(ctx context.Context, query PackageSearchQuery, limit, offset int, sort, supportedPlatform, ballerinaVersion string) (*models.PackageSearchResult, error) {
+	result, err := c.searchPackagesInternal(ctx, query, limit, offset, sort, supportedPlatform, ballerinaVersion)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, wrapCentralClientError(err, fmt.Sprintf("%s'%s'", ErrCannotSearch, query))
+	}
+
+	return result, nil
+}
+
+// SearchAllPackages returns an iterator over every package matching query, fetching pages of pageSize packages
+// from Central as the iteration proceeds. An error ends the iteration after being yielded with a zero Package.
+func (c *centralAPIClientImpl) SearchAllPackages(query PackageSearchQuery, pageSize int, sort, supportedPlatform, ballerinaVersion string) iter.Seq2[models.Package, error] {
+	return c.SearchAllPackagesCtx(context.Background(), query, pageSize, sort, supportedPlatform, ballerinaVersion)
+}
+
+func (c *centralAPIClientImpl) SearchAllPackagesCtx(ctx context.Context, query PackageSearchQuery, pageSize int, sort, supportedPlatform, ballerinaVersion string) iter.Seq2[models.Package, error] {
+	return func(yield func(models.Package, error) bool) {
+		offset := 0
+		for {
+			result, err := c.SearchPackagesCtx(ctx, query, pageSize, offset, sort, supportedPlatform, ballerinaVersion)
+			if err != nil {
+				yield(models.Package{}, err)
+				return
+			}
+
+			for _, pkg := range result.Packages {
+				if !yield(pkg, nil) {
+					return
+				}
+			}
+
+			offset = offset + len(result.Packages)
+			if len(result.Packages) == 0 || offset >= result.Count {
+				return
+			}
+		}
+	}
+}
+
+func (c *centralAPIClientImpl) searchPackagesInternal(ctx context.Context, query PackageSearchQuery, limit, offset int, sort, supportedPlatform, ballerinaVersion string) (*models.PackageSearchResult, error) {
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	params := url.Values{}
+	params.Set("q", query.String())
+	params.Set("limit", strconv.Itoa(limit))
+	params.Set("offset", strconv.Itoa(offset))
+	if sort != "" {
+		params.Set("sort", sort)
+	}
+
+	resourceURL := fmt.Sprintf("%s?%s", PackagePathPrefix, params.Encode())
+	urlStr := fmt.Sprintf("%s%s", c.baseURL, resourceURL)
+
+	req, err := c.newRequest(ctx, http.MethodGet, urlStr, supportedPlatform, ballerinaVersion, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.logRequestInitVerbose(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	c.logRequestConnectVerbose(req, resourceURL)
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	c.logResponseVerbose(resp, string(bodyBytes))
+
+	contentType := resp.Header.Get(ContentType)
+	if isApplicationJSONContentType(contentType) && resp.StatusCode == http.StatusOK {
+		var result models.PackageSearchResult
+		if err := json.Unmarshal(bodyBytes, &result); err != nil {
+			return nil, NewCentralClientError(fmt.Sprintf("%s'%s'. reason: unexpected error", ErrCannotSearch, query))
+		}
+		return &result, nil
+	}
+
+	return nil, c.handleResponseErrors(resp, fmt.Sprintf("%s'%s'", ErrCannotSearch, query), bodyBytes)
+}
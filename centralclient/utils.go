@@ -17,8 +17,7 @@
 package centralclient
 
 import (
-	"archive/zip"
-	"bytes"
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
@@ -36,7 +35,8 @@ import (
 )
 
 const (
-	DeprecatedMetaFileName = "deprecated.txt"
+	DeprecatedMetaFileName   = "deprecated.txt"
+	NightlyBuildMetaFileName = "nightly.build"
 )
 
 var (
@@ -45,7 +45,19 @@ var (
 	SetTestModeActive        = os.Getenv(TestModeActive) == "true"
 )
 
-func createBalaInHomeRepo(balaDownloadResponse *http.Response, fsys fs.FS, pkgPathInBalaCache, pkgOrg, pkgName string, isNightlyBuild bool, deprecationMsg, newUrl, contentDisposition string, trueDigest string, clientContext ClientContext) error {
+// RegistryURL returns the URL of the Central registry selected by the environment.
+func RegistryURL() string {
+	switch {
+	case SetBallerinaStageCentral:
+		return StagingCentralRegistryURL
+	case SetBallerinaDevCentral:
+		return DevCentralRegistryURL
+	default:
+		return CentralRegistryURL
+	}
+}
+
+func createBalaInHomeRepo(ctx context.Context, balaDownloadResponse *http.Response, fsys fs.FS, pkgPathInBalaCache, pkgOrg, pkgName string, isNightlyBuild bool, deprecationMsg, newUrl, contentDisposition string, digests digestCheck, clientContext ClientContext) error {
 	responseContentLength := balaDownloadResponse.ContentLength
 	if responseContentLength <= 0 {
 		return NewCentralClientError(clientContext.formatLog("invalid response from the server, please try again!"))
@@ -95,21 +107,35 @@ func createBalaInHomeRepo(balaDownloadResponse *http.Response, fsys fs.FS, pkgPa
 
 	// Create the following temp path
 	// bala/<org-name>/<pkg-name>/<pkg-version_temp/<platform>
-	tempPath := filepath.Join(pkgPathInBalaCache, fmt.Sprintf("%s_temp", validPkgVersion), platform)
+	// A <version>_temp directory left behind by an earlier pull that crashed is cleared first, so that none of its
+	// content is moved into the bala cache.
+	tempDir := filepath.Join(pkgPathInBalaCache, fmt.Sprintf("%s_temp", validPkgVersion))
+	tempPath := filepath.Join(tempDir, platform)
+	if err := bfs.RemoveIfExists(fsys, tempDir); err != nil {
+		return NewCentralClientError(clientContext.formatLog("error creating directory for bala file"))
+	}
+	createdDir := bfs.FirstMissingDir(fsys, tempPath)
 	if err := createBalaFileDirectory(fsys, tempPath, clientContext); err != nil {
 		return err
 	}
 
-	if err := writeBalaFile(balaDownloadResponse, fsys, filepath.Join(tempPath, balaFile), fmt.Sprintf("%s/%s:%s", pkgOrg, pkgName, validPkgVersion), trueDigest, clientContext); err != nil {
+	// A failed or canceled pull must not leave anything behind in the bala cache, not even the directories of the
+	// package created for the <version>_temp directory.
+	cleanUp := func() {
+		_ = bfs.RemoveIfExists(fsys, tempDir)
+		_ = bfs.RemoveIfExists(fsys, createdDir)
+	}
+	if err := writeBalaFile(ctx, balaDownloadResponse, fsys, filepath.Join(tempPath, balaFile), fmt.Sprintf("%s/%s:%s", pkgOrg, pkgName, validPkgVersion), digests, clientContext); err != nil {
+		cleanUp()
 		return err
 	}
 
-	tempDir := filepath.Dir(tempPath)
-	platformDir := filepath.Dir(balaCacheWithPkgPath)
-
-	if err := bfs.Move(fsys, tempDir, platformDir); err != nil {
+	// Only the platform directory is moved into place, as the <version> directory may already hold other platforms.
+	if err := bfs.MoveDir(fsys, tempPath, balaCacheWithPkgPath); err != nil {
+		cleanUp()
 		return NewCentralClientError(clientContext.formatLog("error creating directory for bala file"))
 	}
+	_ = bfs.RemoveIfExists(fsys, tempDir)
 
 	if err := handleNightlyBuild(isNightlyBuild, fsys, balaCacheWithPkgPath, clientContext); err != nil {
 		return err
@@ -162,19 +188,32 @@ func createBalaFileDirectory(fsys fs.FS, fullPathToStoreBala string, clientConte
 	return nil
 }
 
-func writeBalaFile(balaDownloadResponse *http.Response, fsys fs.FS, balaPath, fullPkgName string, trueDigest string, clientContext ClientContext) error {
+func writeBalaFile(ctx context.Context, balaDownloadResponse *http.Response, fsys fs.FS, balaPath, fullPkgName string, digests digestCheck, clientContext ClientContext) error {
 	balaDownloadResponseBody := balaDownloadResponse.Body
 
 	if balaDownloadResponseBody == nil {
 		return NewCentralClientError(clientContext.formatLog(fmt.Sprintf("error occurred extracting bytes of bala file: %s", fullPkgName)))
 	}
 
-	if err := writeAndHandleProgress(balaDownloadResponseBody, balaDownloadResponse.ContentLength, fsys, balaPath, clientContext); err != nil {
+	hash, err := writeAndHandleProgress(ctx, balaDownloadResponseBody, balaDownloadResponse.ContentLength, fsys, balaPath, clientContext)
+	if err != nil {
 		return err
 	}
 
-	if err := extractBala(fsys, balaPath, filepath.Dir(balaPath), trueDigest, fullPkgName, clientContext); err != nil {
-		return NewCentralClientError(clientContext.formatLog(fmt.Sprintf("error occurred extracting bala file: %s", err.Error())))
+	if err := digests.verify(fmt.Sprintf("%s%s", SHA256, hash), fullPkgName, clientContext); err != nil {
+		return err
+	}
+
+	if err := extractBala(ctx, fsys, balaPath, filepath.Dir(balaPath), DefaultExtractionLimits, clientContext); err != nil {
+		message := clientContext.formatLog(fmt.Sprintf("error occurred extracting bala file: %s", err.Error()))
+		switch e := err.(type) {
+		case *UnsafeBalaEntryError:
+			return NewUnsafeBalaEntryError(message, e.Entry())
+		case *BalaLimitExceededError:
+			return NewBalaLimitExceededError(message, e.Limit())
+		default:
+			return NewCentralClientError(message)
+		}
 	}
 
 	if err := bfs.Remove(fsys, balaPath); err != nil {
@@ -184,22 +223,42 @@ func writeBalaFile(balaDownloadResponse *http.Response, fsys fs.FS, balaPath, fu
 	return nil
 }
 
-func writeAndHandleProgress(inputStream io.Reader, totalSizeInBytes int64, fsys fs.FS, balaPath string, clientContext ClientContext,
-) error {
+// writeAndHandleProgress streams the bala from inputStream into balaPath, reporting the progress through
+// ClientContext.OnProgress, and returns the hex encoded SHA-256 of the bala, which is computed as it is written.
+func writeAndHandleProgress(ctx context.Context, inputStream io.Reader, totalSizeInBytes int64, fsys fs.FS, balaPath string, clientContext ClientContext,
+) (string, error) {
 	if totalSizeInBytes <= 0 {
-		return fmt.Errorf("invalid content length received from the server")
+		return "", fmt.Errorf("invalid content length received from the server")
+	}
+
+	file, err := bfs.Create(fsys, balaPath)
+	if err != nil {
+		return "", fmt.Errorf("error occurred copying bala file: %w", err)
 	}
+	defer file.Close()
 
-	file := make([]byte, 0, totalSizeInBytes)
-	buffer := make([]byte, 1024)
+	writer, ok := file.(io.Writer)
+	if !ok {
+		return "", fmt.Errorf("error occurred copying bala file: %s is not writable", balaPath)
+	}
+
+	hasher := sha256.New()
+	output := io.MultiWriter(writer, hasher)
+	buffer := make([]byte, DownloadBufferSize)
 
 	var totalRead int64
 
 	for {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
 		n, err := inputStream.Read(buffer)
 
 		if n > 0 {
-			file = append(file, buffer[:n]...)
+			if _, err := output.Write(buffer[:n]); err != nil {
+				return "", fmt.Errorf("error occurred copying bala file: %w", err)
+			}
 			totalRead = totalRead + int64(n)
 
 			progress := int((totalRead * 100) / totalSizeInBytes)
@@ -212,92 +271,63 @@ func writeAndHandleProgress(inputStream io.Reader, totalSizeInBytes int64, fsys
 			break
 		}
 		if err != nil {
-			return fmt.Errorf("error reading stream: %w", err)
+			return "", &bodyReadError{err: err}
 		}
 	}
 
-	if err := bfs.WriteFile(fsys, balaPath, file, 0o644); err != nil {
-		return fmt.Errorf("error occurred copying bala file: %w", err)
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("error occurred copying bala file: %w", err)
 	}
 
-	return nil
+	return hex.EncodeToString(hasher.Sum(nil)), nil
 }
 
-func extractBala(fsys fs.FS, balaFilePath, balaFileDestPath, trueDigest, packageName string, clientContext ClientContext) error {
-	if err := bfs.MkdirAll(fsys, balaFileDestPath, 0o755); err != nil {
-		return err
-	}
-
-	hash, err := checkHashInternal(fsys, balaFilePath)
-	if err != nil {
-		return err
+// verifyDigest warns through ClientContext.OnWarning when the digest sent by Central does not match the digest of
+// the downloaded bala.
+func verifyDigest(trueDigest, actualDigest, packageName string, clientContext ClientContext) {
+	if trueDigest == "" || trueDigest == actualDigest || clientContext.OnWarning == nil {
+		return
 	}
 
-	actualDigest := fmt.Sprintf("%s%s", SHA256, hash)
-	if trueDigest != "" && trueDigest != actualDigest {
-		if clientContext.OnWarning != nil {
-			warning := fmt.Sprintf(`*************************************************************
+	warning := fmt.Sprintf(`*************************************************************
 * WARNING: Certain packages may have originated from sources other than the official distributors. *
 *************************************************************
 
 * Verification failed: The hash value of the following package could not be confirmed. 
 %s
 `, packageName)
-			clientContext.OnWarning(warning)
-		}
-	}
-
-	file, err := fs.ReadFile(fsys, balaFilePath)
-	if err != nil {
-		return err
-	}
-
-	reader, err := zip.NewReader(bytes.NewReader(file), int64(len(file)))
-	if err != nil {
-		return err
-	}
-
-	for _, file := range reader.File {
-		path := filepath.Join(balaFileDestPath, file.Name)
-
-		if file.FileInfo().IsDir() {
-			bfs.MkdirAll(fsys, path, file.Mode())
-			continue
-		}
-
-		if err := bfs.MkdirAll(fsys, filepath.Dir(path), 0o755); err != nil {
-			return err
-		}
-
-		outFile, err := bfs.OpenFile(fsys, path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, file.Mode())
-		if err != nil {
-			return err
-		}
+	clientContext.OnWarning(warning)
+}
 
-		rc, err := file.Open()
-		if err != nil {
-			outFile.Close()
-			return err
-		}
+// progressReader reports the percentage of an upload read so far through ClientContext.OnProgress.
+type progressReader struct {
+	reader        io.Reader
+	totalSize     int64
+	totalRead     int64
+	lastProgress  int
+	clientContext ClientContext
+}
 
-		err = bfs.WriteFile(fsys, path, func() []byte {
-			data, _ := io.ReadAll(rc)
-			return data
-		}(), file.Mode())
-		outFile.Close()
-		rc.Close()
+func newProgressReader(reader io.Reader, totalSize int64, clientContext ClientContext) *progressReader {
+	return &progressReader{reader: reader, totalSize: totalSize, lastProgress: -1, clientContext: clientContext}
+}
 
-		if err != nil {
-			return err
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 && r.totalSize > 0 {
+		r.totalRead = r.totalRead + int64(n)
+		progress := int((r.totalRead * 100) / r.totalSize)
+		if progress != r.lastProgress && r.clientContext.OnProgress != nil {
+			r.clientContext.OnProgress(progress)
 		}
+		r.lastProgress = progress
 	}
-
-	return nil
+	return n, err
 }
 
 func handleNightlyBuild(isNightlyBuild bool, fsys fs.FS, balaCacheWithPkgPath string, clientContext ClientContext) error {
 	if isNightlyBuild {
-		nightlyBuildMetaFile := filepath.Join(balaCacheWithPkgPath, "nightly.build")
+		nightlyBuildMetaFile := filepath.Join(balaCacheWithPkgPath, NightlyBuildMetaFileName)
 		if _, err := fs.Stat(fsys, nightlyBuildMetaFile); os.IsNotExist(err) {
 			errMsg := "error occurred while creating nightly.build file."
 			return createMetaFile(fsys, nightlyBuildMetaFile, errMsg, clientContext)
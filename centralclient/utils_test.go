@@ -17,9 +17,16 @@
 package centralclient
 
 import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"io/fs"
+	"math/rand/v2"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -470,3 +477,137 @@ func TestCreateMetaFile(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractBalaCanceled(t *testing.T) {
+	balaContent, err := os.ReadFile(filepath.Join(utilTestResources, testBalaName))
+	if err != nil {
+		t.Fatalf("failed to read bala file: %v", err)
+	}
+	memFS := bfs.NewMemFS()
+	if err := bfs.WriteFile(memFS, testBalaName, balaContent, 0o644); err != nil {
+		t.Fatalf("failed to write bala file: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := extractBala(ctx, memFS, testBalaName, "extracted", DefaultExtractionLimits, ClientContext{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if _, err := fs.Stat(memFS, filepath.Join("extracted", "bala.json")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected nothing to be extracted, got %v", err)
+	}
+}
+
+func TestWriteAndHandleProgress(t *testing.T) {
+	content := []byte("hello world")
+	memFS := bfs.NewMemFS()
+
+	var progress []int
+	clientContext := ClientContext{OnProgress: func(percentComplete int) {
+		progress = append(progress, percentComplete)
+	}}
+	hash, err := writeAndHandleProgress(context.Background(), bytes.NewReader(content), int64(len(content)), memFS, "bala/test.bala", clientContext)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hash != "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9" {
+		t.Errorf("unexpected hash: %s", hash)
+	}
+	if written, _ := fs.ReadFile(memFS, "bala/test.bala"); !bytes.Equal(written, content) {
+		t.Errorf("unexpected content: %q", written)
+	}
+	if len(progress) == 0 || progress[len(progress)-1] != 100 {
+		t.Errorf("expected progress to reach 100%%, got %v", progress)
+	}
+
+	if _, err := writeAndHandleProgress(context.Background(), bytes.NewReader(content), 0, memFS, "bala/test.bala", clientContext); err == nil {
+		t.Error("expected an error for a missing content length")
+	}
+}
+
+func TestVerifyDigest(t *testing.T) {
+	var warnings []string
+	clientContext := ClientContext{OnWarning: func(msg string) {
+		warnings = append(warnings, msg)
+	}}
+
+	verifyDigest("", SHA256+"abc", "wso2/sf:1.3.5", clientContext)
+	verifyDigest(SHA256+"abc", SHA256+"abc", "wso2/sf:1.3.5", clientContext)
+	if len(warnings) != 0 {
+		t.Fatalf("expected no warnings, got %v", warnings)
+	}
+
+	verifyDigest(SHA256+"abc", SHA256+"def", "wso2/sf:1.3.5", clientContext)
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "wso2/sf:1.3.5") {
+		t.Errorf("expected a verification warning, got %v", warnings)
+	}
+}
+
+func TestDownloadAndExtractBalaMemory(t *testing.T) {
+	const balaSize = 8 << 20
+	bala := newSyntheticBala(t, balaSize)
+	dirFS := bfs.NewDirFS(t.TempDir())
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	downloadAndExtractBala(t, bala, dirFS)
+	runtime.ReadMemStats(&after)
+
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > balaSize/8 {
+		t.Errorf("expected memory use to stay bounded, allocated %d bytes for a %d byte bala", allocated, len(bala))
+	}
+	info, err := fs.Stat(dirFS, filepath.Join("extracted", "modules", "entry-7.bin"))
+	if err != nil || info.Size() != balaSize/8 {
+		t.Errorf("expected the entries to be extracted, got %v, %v", info, err)
+	}
+}
+
+func BenchmarkDownloadAndExtractBala(b *testing.B) {
+	const balaSize = 64 << 20
+	bala := newSyntheticBala(b, balaSize)
+	dirFS := bfs.NewDirFS(b.TempDir())
+
+	b.SetBytes(int64(len(bala)))
+	b.ReportAllocs()
+	for b.Loop() {
+		downloadAndExtractBala(b, bala, dirFS)
+	}
+}
+
+// newSyntheticBala returns a bala of eight stored entries of random content that together are size bytes long.
+func newSyntheticBala(tb testing.TB, size int) []byte {
+	tb.Helper()
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	rng := rand.New(rand.NewPCG(1, 2))
+	chunk := make([]byte, size/8)
+	for i := range 8 {
+		for j := range chunk {
+			chunk[j] = byte(rng.Uint32())
+		}
+		entry, err := writer.CreateHeader(&zip.FileHeader{Name: fmt.Sprintf("modules/entry-%d.bin", i), Method: zip.Store})
+		if err != nil {
+			tb.Fatalf("failed to create entry: %v", err)
+		}
+		if _, err := entry.Write(chunk); err != nil {
+			tb.Fatalf("failed to write entry: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		tb.Fatalf("failed to close bala: %v", err)
+	}
+	return buffer.Bytes()
+}
+
+func downloadAndExtractBala(tb testing.TB, bala []byte, fsys fs.FS) {
+	tb.Helper()
+	ctx := context.Background()
+	balaPath := filepath.Join("download", "synthetic.bala")
+	if _, err := writeAndHandleProgress(ctx, bytes.NewReader(bala), int64(len(bala)), fsys, balaPath, ClientContext{}); err != nil {
+		tb.Fatalf("failed to download bala: %v", err)
+	}
+	if err := extractBala(ctx, fsys, balaPath, "extracted", DefaultExtractionLimits, ClientContext{}); err != nil {
+		tb.Fatalf("failed to extract bala: %v", err)
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"ballerina-lang-go/balacache"
+	"ballerina-lang-go/common/bfs"
+	"ballerina-lang-go/settings"
+)
+
+// balaCacheDirName is the directory under the Ballerina home that holds the bala cache.
+const balaCacheDirName = "bala_cache"
+
+const cacheUsage = `Usage: bal cache <command> [--dir <dir>] [<args>]
+
+Commands:
+  list                                     List the packages in the bala cache
+  verify                                   Check the packages against the balas they were extracted from
+  prune [--nightly] [--keep <n>] [--dry-run]
+                                           Remove nightly builds, old versions and orphaned temp directories
+  rm <org>[/<name>[:<version>]]            Remove packages from the bala cache`
+
+func runCache(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, "error: no cache command given")
+		fmt.Fprintln(stderr, cacheUsage)
+		return ExitUsage
+	}
+	switch args[0] {
+	case "list":
+		return runCacheList(args[1:], stdout, stderr)
+	case "verify":
+		return runCacheVerify(args[1:], stdout, stderr)
+	case "prune":
+		return runCachePrune(args[1:], stdout, stderr)
+	case "rm":
+		return runCacheRemove(args[1:], stdout, stderr)
+	case "help", "-h", "--help":
+		fmt.Fprintln(stdout, cacheUsage)
+		return ExitSuccess
+	}
+	fmt.Fprintf(stderr, "error: unknown cache command '%s'\n", args[0])
+	fmt.Fprintln(stderr, cacheUsage)
+	return ExitUsage
+}
+
+// cacheFlags returns the flags of a cache command, with the --dir flag that selects the bala cache.
+func cacheFlags(name string, stderr io.Writer) (*flag.FlagSet, *string) {
+	flags := flag.NewFlagSet("cache "+name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	dir := flags.String("dir", "", "bala cache directory (default ~/.ballerina/bala_cache)")
+	return flags, dir
+}
+
+// newCacheManager returns the manager of the bala cache at dir, or at the default location when dir is empty.
+func newCacheManager(dir string) (balacache.Manager, error) {
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to locate the bala cache: %w", err)
+		}
+		dir = filepath.Join(home, settings.HomeDirName, balaCacheDirName)
+	}
+	return balacache.NewManager(bfs.NewDirFS(dir), "."), nil
+}
+
+func runCacheList(args []string, stdout, stderr io.Writer) int {
+	flags, dir := cacheFlags("list", stderr)
+	if err := flags.Parse(args); err != nil {
+		return ExitUsage
+	}
+	manager, err := newCacheManager(*dir)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return ExitFailure
+	}
+	entries, err := manager.List()
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return ExitFailure
+	}
+	if len(entries) == 0 {
+		fmt.Fprintln(stdout, "no packages in the bala cache")
+		return ExitSuccess
+	}
+
+	table := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "ORG\tNAME\tVERSION\tPLATFORM\tSIZE\tSTATUS")
+	var total int64
+	for _, entry := range entries {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\n", entry.Org, entry.Name, entry.Version, entry.Platform, formatSize(entry.Size), entryStatus(entry))
+		total += entry.Size
+	}
+	if err := table.Flush(); err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return ExitFailure
+	}
+	fmt.Fprintf(stdout, "%d packages, %s\n", len(entries), formatSize(total))
+	return ExitSuccess
+}
+
+// entryStatus describes the markers of a cache entry, with the deprecation message if there is one.
+func entryStatus(entry balacache.Entry) string {
+	var status []string
+	if entry.Deprecated {
+		if message := summaryLine(entry.DeprecationMessage); message != "" {
+			status = append(status, "deprecated: "+message)
+		} else {
+			status = append(status, "deprecated")
+		}
+	}
+	if entry.Nightly {
+		status = append(status, "nightly")
+	}
+	if len(status) == 0 {
+		return "-"
+	}
+	return strings.Join(status, ", ")
+}
+
+// formatSize formats a size in bytes with a binary unit.
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+func runCacheVerify(args []string, stdout, stderr io.Writer) int {
+	flags, dir := cacheFlags("verify", stderr)
+	if err := flags.Parse(args); err != nil {
+		return ExitUsage
+	}
+	manager, err := newCacheManager(*dir)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return ExitFailure
+	}
+	problems, err := manager.Verify()
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return ExitFailure
+	}
+	if len(problems) == 0 {
+		fmt.Fprintln(stdout, "bala cache verified")
+		return ExitSuccess
+	}
+	for _, problem := range problems {
+		fmt.Fprintf(stdout, "%s: %s\n", problem.Entry, problem.Message)
+	}
+	fmt.Fprintf(stderr, "error: found %d problems in the bala cache\n", len(problems))
+	return ExitFailure
+}
+
+func runCachePrune(args []string, stdout, stderr io.Writer) int {
+	flags, dir := cacheFlags("prune", stderr)
+	var policy balacache.PrunePolicy
+	flags.BoolVar(&policy.Nightly, "nightly", false, "remove the packages pulled by nightly builds")
+	flags.IntVar(&policy.KeepLatest, "keep", 0, "keep only the latest versions of each package")
+	flags.BoolVar(&policy.DryRun, "dry-run", false, "show what would be removed without removing it")
+	if err := flags.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if policy.KeepLatest < 0 {
+		fmt.Fprintf(stderr, "error: invalid number of versions to keep '%d'\n", policy.KeepLatest)
+		return ExitUsage
+	}
+	manager, err := newCacheManager(*dir)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return ExitFailure
+	}
+	result, err := manager.Prune(policy)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return ExitFailure
+	}
+
+	action := "removed"
+	if policy.DryRun {
+		action = "would remove"
+	}
+	var freed int64
+	for _, entry := range result.Removed {
+		fmt.Fprintf(stdout, "%s %s\n", action, entry)
+		freed += entry.Size
+	}
+	for _, temp := range result.TempDirs {
+		fmt.Fprintf(stdout, "%s temp directory %s\n", action, temp)
+	}
+	if len(result.Removed) == 0 && len(result.TempDirs) == 0 {
+		fmt.Fprintln(stdout, "nothing to prune")
+		return ExitSuccess
+	}
+	fmt.Fprintf(stdout, "%s %d packages, %s\n", action, len(result.Removed), formatSize(freed))
+	return ExitSuccess
+}
+
+func runCacheRemove(args []string, stdout, stderr io.Writer) int {
+	flags, dir := cacheFlags("rm", stderr)
+	if err := flags.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprintln(stderr, "error: expected a single package to remove")
+		fmt.Fprintln(stderr, "Usage: bal cache rm [--dir <dir>] <org>[/<name>[:<version>]]")
+		return ExitUsage
+	}
+	org, name, version, ok := parsePackageSpec(flags.Arg(0))
+	if !ok {
+		fmt.Fprintf(stderr, "error: invalid package '%s'\n", flags.Arg(0))
+		return ExitUsage
+	}
+	manager, err := newCacheManager(*dir)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return ExitFailure
+	}
+	removed, err := manager.Remove(org, name, version)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return ExitFailure
+	}
+	for _, entry := range removed {
+		fmt.Fprintf(stdout, "removed %s\n", entry)
+	}
+	return ExitSuccess
+}
+
+// parsePackageSpec splits <org>[/<name>[:<version>]] into its parts.
+func parsePackageSpec(spec string) (org, name, version string, ok bool) {
+	org, rest, hasName := strings.Cut(spec, "/")
+	if hasName {
+		var hasVersion bool
+		name, version, hasVersion = strings.Cut(rest, ":")
+		if name == "" || (hasVersion && version == "") {
+			return "", "", "", false
+		}
+	} else if strings.Contains(org, ":") {
+		return "", "", "", false
+	}
+	return org, name, version, org != ""
+}
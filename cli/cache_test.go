@@ -0,0 +1,177 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTestBalaCache writes a bala cache with the extracted balas of ballerina/io 1.6.0 (nightly) and wso2/winery
+// 1.0.0 and 2.0.0 (deprecated) into dir.
+func writeTestBalaCache(t *testing.T, dir string) {
+	t.Helper()
+	files := map[string]string{
+		"ballerina/io/1.6.0/any/bala.json":      `{"bala_version": "2.0.0"}`,
+		"ballerina/io/1.6.0/any/nightly.build":  "",
+		"wso2/winery/1.0.0/any/bala.json":       `{"bala_version": "2.0.0"}`,
+		"wso2/winery/1.0.0/any/package.json":    strings.Repeat("x", 2048),
+		"wso2/winery/2.0.0/any/bala.json":       `{"bala_version": "2.0.0"}`,
+		"wso2/winery/2.0.0/any/deprecated.txt":  "use wso2/vineyard\n",
+		"wso2/winery/2.0.0/java21/package.json": `{"name": "winery"}`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestRunCache(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+		wantGone   []string
+		wantKept   []string
+	}{
+		{
+			name:     "list",
+			args:     []string{"cache", "list"},
+			wantCode: ExitSuccess,
+			wantStdout: `ORG        NAME    VERSION  PLATFORM  SIZE     STATUS
+ballerina  io      1.6.0    any       25 B     nightly
+wso2       winery  1.0.0    any       2.0 KiB  -
+wso2       winery  2.0.0    any       43 B     deprecated: use wso2/vineyard
+wso2       winery  2.0.0    java21    18 B     -
+4 packages, 2.1 KiB
+`,
+		},
+		{
+			name:       "verify",
+			args:       []string{"cache", "verify"},
+			wantCode:   ExitFailure,
+			wantStdout: "wso2/winery:2.0.0 (java21): missing bala.json\n",
+			wantStderr: "error: found 1 problems in the bala cache\n",
+		},
+		{
+			name:       "prune dry run",
+			args:       []string{"cache", "prune", "--nightly", "--keep", "1", "--dry-run"},
+			wantCode:   ExitSuccess,
+			wantStdout: "would remove ballerina/io:1.6.0 (any)\nwould remove wso2/winery:1.0.0 (any)\nwould remove 2 packages, 2.0 KiB\n",
+			wantKept:   []string{"ballerina/io/1.6.0", "wso2/winery/1.0.0"},
+		},
+		{
+			name:       "prune",
+			args:       []string{"cache", "prune", "--nightly"},
+			wantCode:   ExitSuccess,
+			wantStdout: "removed ballerina/io:1.6.0 (any)\nremoved 1 packages, 25 B\n",
+			wantGone:   []string{"ballerina"},
+			wantKept:   []string{"wso2/winery/1.0.0"},
+		},
+		{
+			name:       "nothing to prune",
+			args:       []string{"cache", "prune", "--keep", "2"},
+			wantCode:   ExitSuccess,
+			wantStdout: "nothing to prune\n",
+		},
+		{
+			name:       "remove version",
+			args:       []string{"cache", "rm", "wso2/winery:2.0.0"},
+			wantCode:   ExitSuccess,
+			wantStdout: "removed wso2/winery:2.0.0 (any)\nremoved wso2/winery:2.0.0 (java21)\n",
+			wantGone:   []string{"wso2/winery/2.0.0"},
+			wantKept:   []string{"wso2/winery/1.0.0"},
+		},
+		{
+			name:       "remove organization",
+			args:       []string{"cache", "rm", "ballerina"},
+			wantCode:   ExitSuccess,
+			wantStdout: "removed ballerina/io:1.6.0 (any)\n",
+			wantGone:   []string{"ballerina"},
+		},
+		{
+			name:       "remove missing package",
+			args:       []string{"cache", "rm", "wso2/vineyard"},
+			wantCode:   ExitFailure,
+			wantStderr: "error: no package matching 'wso2/vineyard' in the bala cache\n",
+		},
+		{
+			name:       "invalid package",
+			args:       []string{"cache", "rm", "wso2/winery:"},
+			wantCode:   ExitUsage,
+			wantStderr: "error: invalid package 'wso2/winery:'\n",
+		},
+		{
+			name:       "invalid keep",
+			args:       []string{"cache", "prune", "--keep", "-1"},
+			wantCode:   ExitUsage,
+			wantStderr: "error: invalid number of versions to keep '-1'\n",
+		},
+		{
+			name:       "unknown command",
+			args:       []string{"cache", "clear"},
+			wantCode:   ExitUsage,
+			wantStderr: "error: unknown cache command 'clear'\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeTestBalaCache(t, dir)
+			args := append([]string{tt.args[0], tt.args[1], "--dir", dir}, tt.args[2:]...)
+			var stdout, stderr bytes.Buffer
+			if code := Run(args, &stdout, &stderr); code != tt.wantCode {
+				t.Errorf("Run() = %d, want %d", code, tt.wantCode)
+			}
+			if stdout.String() != tt.wantStdout {
+				t.Errorf("stdout = %q, want %q", stdout.String(), tt.wantStdout)
+			}
+			if !strings.HasPrefix(stderr.String(), tt.wantStderr) {
+				t.Errorf("stderr = %q, want it to start with %q", stderr.String(), tt.wantStderr)
+			}
+			for _, path := range tt.wantGone {
+				if _, err := os.Stat(filepath.Join(dir, path)); !os.IsNotExist(err) {
+					t.Errorf("expected %s to be removed, got %v", path, err)
+				}
+			}
+			for _, path := range tt.wantKept {
+				if _, err := os.Stat(filepath.Join(dir, path)); err != nil {
+					t.Errorf("expected %s to be kept, got %v", path, err)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	for size, want := range map[int64]string{0: "0 B", 1023: "1023 B", 1024: "1.0 KiB", 1536: "1.5 KiB", 5 << 20: "5.0 MiB"} {
+		if got := formatSize(size); got != want {
+			t.Errorf("formatSize(%d) = %q, want %q", size, got, want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package cli implements the commands of the bal command line tool.
+package cli
+
+import (
+	"fmt"
+	"io"
+)
+
+// Exit codes returned by Run.
+const (
+	ExitSuccess = 0
+	ExitFailure = 1
+	ExitUsage   = 2
+)
+
+type command struct {
+	name        string
+	usage       string
+	description string
+	run         func(args []string, stdout, stderr io.Writer) int
+}
+
+func commands() []command {
+	return []command{
+		{
+			name:        "cache",
+			usage:       "bal cache list|verify|prune|rm",
+			description: "Manage the packages in the bala cache",
+			run:         runCache,
+		},
+		{
+			name:        "explain",
+			usage:       "bal explain [<code>]",
+			description: "Show the explanation of a diagnostic code, or list all codes",
+			run:         runExplain,
+		},
+		{
+			name:        "search",
+			usage:       "bal search [--org <org>] [<query>]",
+			description: "Search Central for packages",
+			run:         runSearch,
+		},
+	}
+}
+
+// Run executes the command named by the first argument and returns the process exit code.
+func Run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(stdout)
+		return ExitSuccess
+	}
+	for _, cmd := range commands() {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:], stdout, stderr)
+		}
+	}
+	fmt.Fprintf(stderr, "error: unknown command '%s'\n", args[0])
+	printUsage(stderr)
+	return ExitUsage
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: bal <command> [<args>]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands() {
+		fmt.Fprintf(w, "  %-40s %s\n", cmd.usage, cmd.description)
+	}
+}
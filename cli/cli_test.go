@@ -0,0 +1,85 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRunExplain(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:       "known code",
+			args:       []string{"explain", "BCE2066"},
+			wantCode:   ExitSuccess,
+			wantStdout: "BCE2066 (error): incompatible types: expected '%s', found '%s'\n\nA value was used",
+		},
+		{
+			name:       "lower case code",
+			args:       []string{"explain", "bch2000"},
+			wantCode:   ExitSuccess,
+			wantStdout: "BCH2000 (hint): function '%s' can be marked 'isolated'",
+		},
+		{
+			name:       "list codes",
+			args:       []string{"explain"},
+			wantCode:   ExitSuccess,
+			wantStdout: "BCE2000  error    undefined module '%s'\n",
+		},
+		{
+			name:       "unknown code",
+			args:       []string{"explain", "BCE0000"},
+			wantCode:   ExitFailure,
+			wantStderr: "error: unknown diagnostic code 'BCE0000'",
+		},
+		{
+			name:       "too many arguments",
+			args:       []string{"explain", "BCE2066", "BCE2000"},
+			wantCode:   ExitUsage,
+			wantStderr: "error: too many arguments",
+		},
+		{
+			name:       "unknown command",
+			args:       []string{"frobnicate"},
+			wantCode:   ExitUsage,
+			wantStderr: "error: unknown command 'frobnicate'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := Run(tt.args, &stdout, &stderr); code != tt.wantCode {
+				t.Errorf("Run() = %d, want %d", code, tt.wantCode)
+			}
+			if !strings.Contains(stdout.String(), tt.wantStdout) {
+				t.Errorf("stdout = %q, want it to contain %q", stdout.String(), tt.wantStdout)
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("stderr = %q, want it to contain %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
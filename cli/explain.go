@@ -0,0 +1,49 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"ballerina-lang-go/diagnosticcodes"
+)
+
+func runExplain(args []string, stdout, stderr io.Writer) int {
+	switch len(args) {
+	case 0:
+		for _, definition := range diagnosticcodes.Registry().Definitions() {
+			fmt.Fprintf(stdout, "%s  %-7s  %s\n", definition.DiagnosticId(),
+				strings.ToLower(definition.Severity().String()), definition.MessageFormat())
+		}
+		return ExitSuccess
+	case 1:
+		definition, ok := diagnosticcodes.Lookup(args[0])
+		if !ok {
+			fmt.Fprintf(stderr, "error: unknown diagnostic code '%s'\n", args[0])
+			return ExitFailure
+		}
+		fmt.Fprintf(stdout, "%s (%s): %s\n\n%s\n", definition.DiagnosticId(),
+			strings.ToLower(definition.Severity().String()), definition.MessageFormat(), definition.Explanation())
+		return ExitSuccess
+	default:
+		fmt.Fprintln(stderr, "error: too many arguments")
+		fmt.Fprintln(stderr, "Usage: bal explain [<code>]")
+		return ExitUsage
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"ballerina-lang-go/centralclient"
+	"ballerina-lang-go/settings"
+	"ballerina-lang-go/tomlparser"
+)
+
+const (
+	// supportedPlatform is the platform of the packages requested from Central.
+	supportedPlatform = "any"
+	// userAgent identifies the tool in the requests to Central.
+	userAgent = "ballerina-lang-go"
+	// maxSearchPageSize is the largest number of packages requested from Central at once.
+	maxSearchPageSize = 50
+)
+
+// newCentralClient creates the client used by the commands that talk to Central, printing the diagnostics of the
+// settings to stderr. Tests replace it to use a stand-in for Central.
+var newCentralClient = func(stderr io.Writer) (centralclient.CentralAPIClient, error) {
+	s, err := settings.Load()
+	if s != nil {
+		printSettingsDiagnostics(stderr, s.Diagnostics())
+	}
+	if err != nil {
+		return nil, err
+	}
+	if s.HasErrors() {
+		return nil, fmt.Errorf("invalid settings in %s", settings.FileName)
+	}
+	return s.NewCentralAPIClient(centralclient.RegistryURL()), nil
+}
+
+// printSettingsDiagnostics prints the problems found in Settings.toml and in the environment variables that
+// override it.
+func printSettingsDiagnostics(w io.Writer, diags []tomlparser.Diagnostic) {
+	for _, d := range diags {
+		severity := strings.ToLower(d.Severity.String())
+		if d.Location == nil {
+			fmt.Fprintf(w, "%s: %s\n", severity, d.Message)
+			continue
+		}
+		fmt.Fprintf(w, "%s: %s:%d:%d: %s\n", severity, settings.FileName, d.Location.StartLine, d.Location.StartColumn, d.Message)
+	}
+}
+
+// printError prints err with the "error: " prefix of the command errors, which the errors of the Central client
+// already carry.
+func printError(w io.Writer, err error) {
+	message := err.Error()
+	if !strings.HasPrefix(strings.TrimSpace(message), "error: ") {
+		message = "error: " + message
+	}
+	fmt.Fprintln(w, message)
+}
+
+func runSearch(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("search", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var query centralclient.PackageSearchQuery
+	flags.StringVar(&query.Org, "org", "", "only show packages of the organization")
+	flags.StringVar(&query.Name, "name", "", "only show packages with the name")
+	flags.StringVar(&query.Keyword, "keyword", "", "only show packages with the keyword")
+	limit := flags.Int("limit", centralclient.DefaultSearchLimit, "maximum number of packages to show")
+	sort := flags.String("sort", centralclient.SortByRelevance, "sort order of the packages")
+	if err := flags.Parse(args); err != nil {
+		return ExitUsage
+	}
+	query.Text = strings.Join(flags.Args(), " ")
+	if query.String() == "" {
+		fmt.Fprintln(stderr, "error: no search query given")
+		fmt.Fprintln(stderr, "Usage: bal search [--org <org>] [--name <name>] [--keyword <keyword>] [<query>]")
+		return ExitUsage
+	}
+	if *limit <= 0 {
+		fmt.Fprintf(stderr, "error: invalid limit '%d'\n", *limit)
+		return ExitUsage
+	}
+
+	client, err := newCentralClient(stderr)
+	if err != nil {
+		printError(stderr, err)
+		return ExitFailure
+	}
+
+	table := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "ORG\tNAME\tVERSION\tSUMMARY")
+	found := 0
+	for pkg, err := range client.SearchAllPackages(query, min(*limit, maxSearchPageSize), *sort, supportedPlatform, userAgent) {
+		if err != nil {
+			// Show the packages of the pages read before the failure.
+			if found > 0 {
+				_ = table.Flush()
+			}
+			printError(stderr, err)
+			return ExitFailure
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", pkg.Organization, pkg.Name, pkg.Version, summaryLine(pkg.Summary))
+		found++
+		if found == *limit {
+			break
+		}
+	}
+	if found == 0 {
+		fmt.Fprintf(stdout, "no packages found for '%s'\n", query)
+		return ExitSuccess
+	}
+	if err := table.Flush(); err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return ExitFailure
+	}
+	return ExitSuccess
+}
+
+// summaryLine returns the first line of a package summary so that each package takes a single row of the table.
+func summaryLine(summary string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(summary), "\n")
+	return strings.TrimSpace(line)
+}
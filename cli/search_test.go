@@ -0,0 +1,220 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"ballerina-lang-go/centralclient"
+	"ballerina-lang-go/centralclient/models"
+	"ballerina-lang-go/settings"
+)
+
+func TestRunSearch(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		queries = append(queries, query.Get("q"))
+		if query.Get("q") == "broken" || query.Get("q") == "partial" && query.Get("offset") != "0" {
+			w.Header().Set(centralclient.ContentType, centralclient.ApplicationJSON)
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = io.WriteString(w, `{"message": "search index unavailable"}`)
+			return
+		}
+		total := 3
+		if query.Get("q") == "nothing" {
+			total = 0
+		}
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		if query.Get("q") == "partial" {
+			limit = min(limit, 2)
+		}
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		result := models.PackageSearchResult{Count: total, Packages: []models.Package{}}
+		for i := offset; i < total && i < offset+limit; i++ {
+			result.Packages = append(result.Packages, models.Package{
+				Organization: "ballerinax",
+				Name:         fmt.Sprintf("winery%d", i),
+				Version:      fmt.Sprintf("1.%d.0", i),
+				Summary:      fmt.Sprintf("Winery connector %d.\nMore details.", i),
+			})
+		}
+		w.Header().Set(centralclient.ContentType, centralclient.ApplicationJSON)
+		_ = json.NewEncoder(w).Encode(result)
+	}))
+	defer server.Close()
+
+	original := newCentralClient
+	newCentralClient = func(io.Writer) (centralclient.CentralAPIClient, error) {
+		return centralclient.NewCentralAPIClientFull(server.URL, "", "", "", "", 0, 0, 0, 0, 0), nil
+	}
+	defer func() { newCentralClient = original }()
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantQuery  string
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:      "all pages",
+			args:      []string{"search", "--org", "ballerinax", "--limit", "5", "winery"},
+			wantCode:  ExitSuccess,
+			wantQuery: "winery org:ballerinax",
+			wantStdout: `ORG         NAME     VERSION  SUMMARY
+ballerinax  winery0  1.0.0    Winery connector 0.
+ballerinax  winery1  1.1.0    Winery connector 1.
+ballerinax  winery2  1.2.0    Winery connector 2.
+`,
+		},
+		{
+			name:      "limited",
+			args:      []string{"search", "--keyword", "wine", "--limit", "1"},
+			wantCode:  ExitSuccess,
+			wantQuery: "keyword:wine",
+			wantStdout: `ORG         NAME     VERSION  SUMMARY
+ballerinax  winery0  1.0.0    Winery connector 0.
+`,
+		},
+		{
+			name:       "no results",
+			args:       []string{"search", "nothing"},
+			wantCode:   ExitSuccess,
+			wantQuery:  "nothing",
+			wantStdout: "no packages found for 'nothing'\n",
+		},
+		{
+			name:       "server error",
+			args:       []string{"search", "broken"},
+			wantCode:   ExitFailure,
+			wantQuery:  "broken",
+			wantStderr: "error: failed to search packages: 'broken'. reason: search index unavailable\n",
+		},
+		{
+			name:      "failure after the first page",
+			args:      []string{"search", "partial"},
+			wantCode:  ExitFailure,
+			wantQuery: "partial",
+			wantStdout: `ORG         NAME     VERSION  SUMMARY
+ballerinax  winery0  1.0.0    Winery connector 0.
+ballerinax  winery1  1.1.0    Winery connector 1.
+`,
+			wantStderr: "error: failed to search packages: 'partial'. reason: search index unavailable\n",
+		},
+		{
+			name:       "missing query",
+			args:       []string{"search"},
+			wantCode:   ExitUsage,
+			wantStderr: "error: no search query given\n",
+		},
+		{
+			name:       "invalid limit",
+			args:       []string{"search", "--limit", "0", "winery"},
+			wantCode:   ExitUsage,
+			wantStderr: "error: invalid limit '0'\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries = nil
+			var stdout, stderr bytes.Buffer
+			if code := Run(tt.args, &stdout, &stderr); code != tt.wantCode {
+				t.Errorf("Run() = %d, want %d", code, tt.wantCode)
+			}
+			if stdout.String() != tt.wantStdout {
+				t.Errorf("stdout = %q, want %q", stdout.String(), tt.wantStdout)
+			}
+			if !strings.HasPrefix(stderr.String(), tt.wantStderr) {
+				t.Errorf("stderr = %q, want it to start with %q", stderr.String(), tt.wantStderr)
+			}
+			if tt.wantQuery != "" && (len(queries) == 0 || queries[0] != tt.wantQuery) {
+				t.Errorf("queries = %q, want them to start with %q", queries, tt.wantQuery)
+			}
+		})
+	}
+}
+
+func TestNewCentralClientSettingsDiagnostics(t *testing.T) {
+	tests := []struct {
+		name       string
+		settings   string
+		env        map[string]string
+		wantErr    string
+		wantStderr string
+	}{
+		{
+			name:       "invalid settings file",
+			settings:   "[central]\naccesstoken = 42\n",
+			wantErr:    "invalid settings in Settings.toml",
+			wantStderr: "error: Settings.toml:2:1: invalid type for key 'central.accesstoken': expected string, found integer\n",
+		},
+		{
+			name:       "invalid environment variable",
+			env:        map[string]string{settings.MaxRetriesEnv: "many"},
+			wantErr:    "invalid settings in Settings.toml",
+			wantStderr: "error: invalid value 'many' for environment variable 'BALLERINA_CENTRAL_MAX_RETRIES': expected a non-negative integer\n",
+		},
+		{
+			name: "valid settings",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			if tt.settings != "" {
+				dir := filepath.Join(home, settings.HomeDirName)
+				if err := os.MkdirAll(dir, 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, settings.FileName), []byte(tt.settings), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var stderr bytes.Buffer
+			client, err := newCentralClient(&stderr)
+			if tt.wantErr == "" {
+				if err != nil || client == nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			} else if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+			if stderr.String() != tt.wantStderr {
+				t.Errorf("stderr = %q, want %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package bfs
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// dirFS is a filesystem backed by a directory of the operating system, such as the bala cache under the user home.
+// It implements fs.FS, fs.ReadDirFS, fs.StatFS, MutableFS, and WritableFS interfaces. Like memFS, it creates missing
+// parent directories when writing and removes directories with all their contents.
+type dirFS struct {
+	root string
+}
+
+// NewDirFS returns a filesystem for the directory tree rooted at root.
+func NewDirFS(root string) *dirFS {
+	return &dirFS{root: root}
+}
+
+// path returns the operating system path of name, which must be a valid fs.FS path.
+func (d *dirFS) path(op, name string) (string, error) {
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	return filepath.Join(d.root, filepath.FromSlash(name)), nil
+}
+
+func (d *dirFS) Open(name string) (fs.File, error) {
+	return os.DirFS(d.root).Open(name)
+}
+
+func (d *dirFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return fs.ReadDir(os.DirFS(d.root), name)
+}
+
+func (d *dirFS) Stat(name string) (fs.FileInfo, error) {
+	return fs.Stat(os.DirFS(d.root), name)
+}
+
+func (d *dirFS) Create(name string) (fs.File, error) {
+	return d.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
+}
+
+func (d *dirFS) MkdirAll(dirPath string, perm fs.FileMode) error {
+	p, err := d.path("mkdir", dirPath)
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(p, perm)
+}
+
+func (d *dirFS) OpenFile(name string, flag int, perm fs.FileMode) (fs.File, error) {
+	p, err := d.path("openfile", name)
+	if err != nil {
+		return nil, err
+	}
+	if flag&os.O_CREATE != 0 {
+		if err := d.mkdirParent(name); err != nil {
+			return nil, err
+		}
+	}
+	return os.OpenFile(p, flag, perm)
+}
+
+// Remove removes a file or directory and all its contents.
+func (d *dirFS) Remove(name string) error {
+	p, err := d.path("remove", name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Lstat(p); err != nil {
+		return err
+	}
+	return os.RemoveAll(p)
+}
+
+// Move moves a file or directory from oldpath to newpath.
+func (d *dirFS) Move(oldpath, newpath string) error {
+	oldP, err := d.path("move", oldpath)
+	if err != nil {
+		return err
+	}
+	newP, err := d.path("move", newpath)
+	if err != nil {
+		return err
+	}
+	if err := d.mkdirParent(newpath); err != nil {
+		return err
+	}
+	return os.Rename(oldP, newP)
+}
+
+// WriteFile writes data to a file, creating it if necessary.
+func (d *dirFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	p, err := d.path("writefile", name)
+	if err != nil {
+		return err
+	}
+	if err := d.mkdirParent(name); err != nil {
+		return err
+	}
+	return os.WriteFile(p, data, perm)
+}
+
+func (d *dirFS) mkdirParent(name string) error {
+	if dir := path.Dir(name); dir != "." {
+		return d.MkdirAll(dir, 0o755)
+	}
+	return nil
+}
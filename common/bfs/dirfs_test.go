@@ -0,0 +1,109 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package bfs
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDirFS(t *testing.T) {
+	root := t.TempDir()
+	fsys := NewDirFS(root)
+
+	if err := WriteFile(fsys, "dir/a.txt", []byte("alpha"), 0o644); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(root, "dir", "a.txt")); err != nil || string(data) != "alpha" {
+		t.Fatalf("expected the file on disk, got %q %v", string(data), err)
+	}
+
+	f, err := Create(fsys, "dir/sub/b.txt")
+	if err != nil {
+		t.Fatalf("Create error: %v", err)
+	}
+	if _, err := f.(io.Writer).Write([]byte("beta")); err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+	_ = f.Close()
+
+	entries, err := fs.ReadDir(fsys, "dir")
+	if err != nil || len(entries) != 2 || entries[0].Name() != "a.txt" || entries[1].Name() != "sub" {
+		t.Fatalf("unexpected entries: %v %v", entries, err)
+	}
+
+	if err := Move(fsys, "dir", "moved/dir"); err != nil {
+		t.Fatalf("Move error: %v", err)
+	}
+	if data, err := fs.ReadFile(fsys, "moved/dir/sub/b.txt"); err != nil || string(data) != "beta" {
+		t.Fatalf("expected the moved file, got %q %v", string(data), err)
+	}
+
+	if err := Remove(fsys, "moved"); err != nil {
+		t.Fatalf("Remove error: %v", err)
+	}
+	if _, err := fs.Stat(fsys, "moved/dir/a.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected the directory to be removed, got %v", err)
+	}
+	if err := Remove(fsys, "moved"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected ErrNotExist when removing non-existent, got %v", err)
+	}
+
+	if _, err := Create(fsys, "../escape.txt"); !errors.Is(err, fs.ErrInvalid) {
+		t.Fatalf("expected ErrInvalid for a path outside the root, got %v", err)
+	}
+}
+
+func TestMoveDirAndRemoveIfExists(t *testing.T) {
+	fsys := NewDirFS(t.TempDir())
+	if err := WriteFile(fsys, "temp/java21/bala.json", []byte("{}"), 0o644); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+	if err := WriteFile(fsys, "cache/1.0.0/any/bala.json", []byte("{}"), 0o644); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+	if err := MkdirAll(fsys, "cache/1.0.0/java21", 0o755); err != nil {
+		t.Fatalf("MkdirAll error: %v", err)
+	}
+
+	// The empty destination is replaced, and its sibling is kept.
+	if err := MoveDir(fsys, "temp/java21", "cache/1.0.0/java21"); err != nil {
+		t.Fatalf("MoveDir error: %v", err)
+	}
+	for _, path := range []string{"cache/1.0.0/any/bala.json", "cache/1.0.0/java21/bala.json"} {
+		if _, err := fs.Stat(fsys, path); err != nil {
+			t.Errorf("expected %s after the move: %v", path, err)
+		}
+	}
+	if err := MoveDir(fsys, "cache/1.0.0/any", "other/1.0.0/any"); err != nil {
+		t.Fatalf("MoveDir to a missing parent error: %v", err)
+	}
+
+	if err := RemoveIfExists(fsys, "temp"); err != nil {
+		t.Errorf("RemoveIfExists error: %v", err)
+	}
+	if err := RemoveIfExists(fsys, "temp"); err != nil {
+		t.Errorf("expected RemoveIfExists of a missing path to succeed, got %v", err)
+	}
+	if _, err := fs.Stat(fsys, "temp"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected temp to be removed, got %v", err)
+	}
+}
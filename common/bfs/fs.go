@@ -17,7 +17,9 @@
 package bfs
 
 import (
+	"errors"
 	"io/fs"
+	"path/filepath"
 )
 
 type MutableFS interface {
@@ -83,3 +85,40 @@ func WriteFile(fsys fs.FS, name string, data []byte, perm fs.FileMode) error {
 	}
 	return wfs.WriteFile(name, data, perm)
 }
+
+// FirstMissingDir returns the outermost directory of path that does not exist in fsys, which is the directory to
+// remove to undo a MkdirAll of path. It returns path itself when path exists.
+func FirstMissingDir(fsys fs.FS, path string) string {
+	missing := path
+	for dir := path; ; {
+		if _, err := fs.Stat(fsys, dir); err == nil {
+			return missing
+		}
+		missing = dir
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return missing
+		}
+		dir = parent
+	}
+}
+
+// RemoveIfExists removes path and everything below it from fsys. Unlike Remove, it succeeds when path does not exist.
+func RemoveIfExists(fsys fs.FS, path string) error {
+	if err := Remove(fsys, path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// MoveDir moves the directory src to dst, creating the parent directories of dst. An existing dst is replaced, so
+// callers only move onto a missing or empty directory.
+func MoveDir(fsys fs.FS, src, dst string) error {
+	if err := MkdirAll(fsys, filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	if err := RemoveIfExists(fsys, dst); err != nil {
+		return err
+	}
+	return Move(fsys, src, dst)
+}
@@ -17,16 +17,20 @@
 package bfs
 
 import (
-	"bytes"
+	"io"
 	"io/fs"
+	"os"
 	"path"
 	"strings"
+	"sync"
 	"time"
 )
 
 // memFS is an in-memory filesystem that supports both files and directories.
-// It implements fs.FS, fs.ReadDirFS, MutableFS, and WritableFS interfaces.
+// It implements fs.FS, fs.ReadDirFS, MutableFS, and WritableFS interfaces. It is safe for concurrent use, as long as
+// each file is written through one handle at a time.
 type memFS struct {
+	mu      sync.RWMutex
 	entries map[string]*memEntry
 }
 
@@ -39,10 +43,12 @@ type memEntry struct {
 	isDir   bool
 }
 
-// memFileHandle is an open handle to a memEntry, implementing fs.File.
+// memFileHandle is an open handle to a memEntry, implementing fs.File, io.Writer, io.ReaderAt and io.Seeker. Reads
+// and writes share the offset of the handle, as they do for an os.File.
 type memFileHandle struct {
 	entry  *memEntry
-	reader *bytes.Reader
+	offset int64
+	append bool
 }
 
 // memDirHandle is an open handle to a directory for reading entries.
@@ -94,6 +100,9 @@ func (mfs *memFS) mkdirAllInternal(dirPath string, perm fs.FileMode) {
 }
 
 func (mfs *memFS) Create(name string) (fs.File, error) {
+	mfs.mu.Lock()
+	defer mfs.mu.Unlock()
+
 	if !fs.ValidPath(name) {
 		return nil, &fs.PathError{Op: "create", Path: name, Err: fs.ErrInvalid}
 	}
@@ -111,13 +120,13 @@ func (mfs *memFS) Create(name string) (fs.File, error) {
 	}
 	mfs.entries[name] = entry
 
-	return &memFileHandle{
-		entry:  entry,
-		reader: bytes.NewReader(entry.data),
-	}, nil
+	return &memFileHandle{entry: entry}, nil
 }
 
 func (mfs *memFS) MkdirAll(dirPath string, perm fs.FileMode) error {
+	mfs.mu.Lock()
+	defer mfs.mu.Unlock()
+
 	if !fs.ValidPath(dirPath) {
 		return &fs.PathError{Op: "mkdir", Path: dirPath, Err: fs.ErrInvalid}
 	}
@@ -127,6 +136,9 @@ func (mfs *memFS) MkdirAll(dirPath string, perm fs.FileMode) error {
 }
 
 func (mfs *memFS) Open(name string) (fs.File, error) {
+	mfs.mu.RLock()
+	defer mfs.mu.RUnlock()
+
 	if !fs.ValidPath(name) {
 		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
 	}
@@ -155,18 +167,29 @@ func (mfs *memFS) Open(name string) (fs.File, error) {
 		}, nil
 	}
 
-	return &memFileHandle{
-		entry:  entry,
-		reader: bytes.NewReader(entry.data),
-	}, nil
+	return &memFileHandle{entry: entry}, nil
 }
 
+// OpenFile opens a file with the os.O_* flags: the file is created if os.O_CREATE is set, truncated if os.O_TRUNC is
+// set, and written at its end if os.O_APPEND is set.
 func (mfs *memFS) OpenFile(name string, flag int, perm fs.FileMode) (fs.File, error) {
+	mfs.mu.Lock()
+	defer mfs.mu.Unlock()
+
 	if !fs.ValidPath(name) {
 		return nil, &fs.PathError{Op: "openfile", Path: name, Err: fs.ErrInvalid}
 	}
 
 	entry, ok := mfs.entries[name]
+	if ok && entry.isDir {
+		return nil, &fs.PathError{Op: "openfile", Path: name, Err: fs.ErrInvalid}
+	}
+	if ok && flag&os.O_CREATE != 0 && flag&os.O_EXCL != 0 {
+		return nil, &fs.PathError{Op: "openfile", Path: name, Err: fs.ErrExist}
+	}
+	if !ok && flag&os.O_CREATE == 0 {
+		return nil, &fs.PathError{Op: "openfile", Path: name, Err: fs.ErrNotExist}
+	}
 	if !ok {
 		// Create parent directories
 		if dir := path.Dir(name); dir != "." {
@@ -182,13 +205,18 @@ func (mfs *memFS) OpenFile(name string, flag int, perm fs.FileMode) (fs.File, er
 		mfs.entries[name] = entry
 	}
 
-	return &memFileHandle{
-		entry:  entry,
-		reader: bytes.NewReader(entry.data),
-	}, nil
+	if flag&os.O_TRUNC != 0 {
+		entry.data = nil
+		entry.modTime = time.Now()
+	}
+
+	return &memFileHandle{entry: entry, append: flag&os.O_APPEND != 0}, nil
 }
 
 func (mfs *memFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	mfs.mu.RLock()
+	defer mfs.mu.RUnlock()
+
 	if !fs.ValidPath(name) {
 		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
 	}
@@ -267,6 +295,9 @@ func (mfs *memFS) readDirEntries(dirPath string) []fs.DirEntry {
 
 // Remove removes a file or directory and all its contents.
 func (mfs *memFS) Remove(name string) error {
+	mfs.mu.Lock()
+	defer mfs.mu.Unlock()
+
 	removed := false
 
 	// Remove the entry itself if it exists
@@ -293,6 +324,9 @@ func (mfs *memFS) Remove(name string) error {
 
 // Move moves a file or directory from oldpath to newpath.
 func (mfs *memFS) Move(oldpath, newpath string) error {
+	mfs.mu.Lock()
+	defer mfs.mu.Unlock()
+
 	type moveItem struct {
 		oldName string
 		newName string
@@ -339,6 +373,9 @@ func (mfs *memFS) Move(oldpath, newpath string) error {
 
 // WriteFile writes data to a file, creating it if necessary.
 func (mfs *memFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	mfs.mu.Lock()
+	defer mfs.mu.Unlock()
+
 	if !fs.ValidPath(name) {
 		return &fs.PathError{Op: "writefile", Path: name, Err: fs.ErrInvalid}
 	}
@@ -366,7 +403,63 @@ func (h *memFileHandle) Close() error {
 }
 
 func (h *memFileHandle) Read(p []byte) (int, error) {
-	return h.reader.Read(p)
+	n, err := h.ReadAt(p, h.offset)
+	h.offset += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (h *memFileHandle) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, &fs.PathError{Op: "read", Path: h.entry.name, Err: fs.ErrInvalid}
+	}
+	if off >= int64(len(h.entry.data)) {
+		return 0, io.EOF
+	}
+	n := copy(p, h.entry.data[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// Write writes p at the offset of the handle, growing the file as needed.
+func (h *memFileHandle) Write(p []byte) (int, error) {
+	if h.append {
+		h.offset = int64(len(h.entry.data))
+	}
+	end := h.offset + int64(len(p))
+	if end > int64(cap(h.entry.data)) {
+		data := make([]byte, len(h.entry.data), max(end, 2*int64(cap(h.entry.data))))
+		copy(data, h.entry.data)
+		h.entry.data = data
+	}
+	if end > int64(len(h.entry.data)) {
+		h.entry.data = h.entry.data[:end]
+	}
+	copy(h.entry.data[h.offset:], p)
+	h.offset = end
+	h.entry.modTime = time.Now()
+	return len(p), nil
+}
+
+func (h *memFileHandle) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += h.offset
+	case io.SeekEnd:
+		offset += int64(len(h.entry.data))
+	default:
+		return 0, &fs.PathError{Op: "seek", Path: h.entry.name, Err: fs.ErrInvalid}
+	}
+	if offset < 0 {
+		return 0, &fs.PathError{Op: "seek", Path: h.entry.name, Err: fs.ErrInvalid}
+	}
+	h.offset = offset
+	return offset, nil
 }
 
 func (h *memFileHandle) Stat() (fs.FileInfo, error) {
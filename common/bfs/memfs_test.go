@@ -19,9 +19,11 @@ package bfs
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
 	"io/fs"
 	"os"
+	"sync"
 	"testing"
 )
 
@@ -257,3 +259,138 @@ func TestMkdirAll_ReadDir(t *testing.T) {
 		t.Errorf("sub2 should be a directory")
 	}
 }
+
+func TestFileHandle_WriteReadAtSeek(t *testing.T) {
+	fsys := NewMemFS()
+
+	f, err := Create(fsys, "dir/data.bin")
+	if err != nil {
+		t.Fatalf("Create error: %v", err)
+	}
+	w, ok := f.(io.Writer)
+	if !ok {
+		t.Fatalf("created file is not writable")
+	}
+	for _, chunk := range []string{"hello", " ", "world"} {
+		if _, err := w.Write([]byte(chunk)); err != nil {
+			t.Fatalf("Write error: %v", err)
+		}
+	}
+	_ = f.Close()
+
+	data, _ := fs.ReadFile(fsys, "dir/data.bin")
+	if string(data) != "hello world" {
+		t.Fatalf("content mismatch: got %q", string(data))
+	}
+
+	f, _ = fsys.Open("dir/data.bin")
+	r, ok := f.(io.ReaderAt)
+	if !ok {
+		t.Fatalf("opened file does not support ReadAt")
+	}
+	buf := make([]byte, 5)
+	if n, err := r.ReadAt(buf, 6); n != 5 || err != nil || string(buf) != "world" {
+		t.Fatalf("ReadAt mismatch: got %d %v %q", n, err, string(buf))
+	}
+	if n, err := r.ReadAt(buf, 8); n != 3 || err != io.EOF {
+		t.Fatalf("expected a short read with EOF, got %d %v", n, err)
+	}
+
+	s := f.(io.Seeker)
+	if _, err := s.Seek(-5, io.SeekEnd); err != nil {
+		t.Fatalf("Seek error: %v", err)
+	}
+	rest, _ := io.ReadAll(f)
+	if string(rest) != "world" {
+		t.Fatalf("read after seek mismatch: got %q", string(rest))
+	}
+}
+
+func TestOpenFile_Flags(t *testing.T) {
+	fsys := NewMemFS()
+
+	if _, err := OpenFile(fsys, "missing.txt", os.O_RDONLY, 0o644); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected ErrNotExist without O_CREATE, got %v", err)
+	}
+
+	_ = WriteFile(fsys, "log.txt", []byte("one"), 0o644)
+	if _, err := OpenFile(fsys, "log.txt", os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644); !errors.Is(err, fs.ErrExist) {
+		t.Fatalf("expected ErrExist with O_EXCL, got %v", err)
+	}
+
+	f, err := OpenFile(fsys, "log.txt", os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatalf("OpenFile append error: %v", err)
+	}
+	_, _ = f.(io.Writer).Write([]byte(" two"))
+	if data, _ := fs.ReadFile(fsys, "log.txt"); string(data) != "one two" {
+		t.Fatalf("append mismatch: got %q", string(data))
+	}
+
+	f, err = OpenFile(fsys, "log.txt", os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		t.Fatalf("OpenFile truncate error: %v", err)
+	}
+	_, _ = f.(io.Writer).Write([]byte("three"))
+	if data, _ := fs.ReadFile(fsys, "log.txt"); string(data) != "three" {
+		t.Fatalf("truncate mismatch: got %q", string(data))
+	}
+
+	f, err = OpenFile(fsys, "log.txt", os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("OpenFile error: %v", err)
+	}
+	_, _ = f.(io.Writer).Write([]byte("T"))
+	if data, _ := fs.ReadFile(fsys, "log.txt"); string(data) != "Three" {
+		t.Fatalf("overwrite mismatch: got %q", string(data))
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	memFS := NewMemFS()
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			dir := fmt.Sprintf("bala/pkg%d", i)
+			if err := memFS.MkdirAll(dir, 0o755); err != nil {
+				t.Errorf("MkdirAll failed: %v", err)
+				return
+			}
+			f, err := memFS.Create(dir + "/bala.json")
+			if err != nil {
+				t.Errorf("Create failed: %v", err)
+				return
+			}
+			_, _ = f.(io.Writer).Write([]byte("{}"))
+			_, _ = fs.ReadDir(memFS, "bala")
+			if err := memFS.Move(dir, dir+"-moved"); err != nil {
+				t.Errorf("Move failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	entries, err := fs.ReadDir(memFS, "bala")
+	if err != nil || len(entries) != 8 {
+		t.Errorf("expected 8 packages, got %d: %v", len(entries), err)
+	}
+}
+
+func TestFirstMissingDir(t *testing.T) {
+	fsys := NewMemFS()
+	if err := fsys.MkdirAll("bala/wso2", 0o755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	tests := map[string]string{
+		"bala/wso2/winery/1.0.0": "bala/wso2/winery",
+		"bala/wso2":              "bala/wso2",
+		"cache/central":          "cache",
+	}
+	for path, expected := range tests {
+		if got := FirstMissingDir(fsys, path); got != expected {
+			t.Errorf("FirstMissingDir(%q) = %q, expected %q", path, got, expected)
+		}
+	}
+}
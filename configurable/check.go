@@ -0,0 +1,215 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package configurable
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"ballerina-lang-go/diagnosticcodes"
+)
+
+// problem is a mismatch between a value and a type, reported at the path of the offending value.
+type problem struct {
+	path []string
+	code diagnosticcodes.Definition
+	args []any
+}
+
+// check checks a TOML value against a type and converts it to the Go representation documented on Values.
+func check(t *Type, value any, path []string) (any, []problem) {
+	incompatible := func(found string) (any, []problem) {
+		return nil, []problem{{path, diagnosticcodes.ConfigIncompatibleType, []any{describePath(path), t.String(), found}}}
+	}
+
+	switch t.Kind {
+	case AnydataKind:
+		return normalize(value), nil
+	case IntKind:
+		if v, ok := value.(int64); ok {
+			return v, nil
+		}
+	case ByteKind:
+		if v, ok := value.(int64); ok {
+			if v < 0 || v > 255 {
+				return incompatible(strconv.FormatInt(v, 10))
+			}
+			return uint8(v), nil
+		}
+	case FloatKind:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case int64:
+			return float64(v), nil
+		}
+	case DecimalKind:
+		switch value.(type) {
+		case float64, int64:
+			return value, nil
+		}
+	case StringKind:
+		if v, ok := value.(string); ok {
+			return v, nil
+		}
+	case BooleanKind:
+		if v, ok := value.(bool); ok {
+			return v, nil
+		}
+	case ArrayKind, TableKind:
+		elements, ok := elementsOf(value)
+		if !ok {
+			break
+		}
+		result := make([]any, len(elements))
+		var problems []problem
+		for i, element := range elements {
+			converted, elementProblems := check(t.Element, element, childPath(path, strconv.Itoa(i)))
+			result[i] = converted
+			problems = append(problems, elementProblems...)
+		}
+		return result, problems
+	case MapKind:
+		table, ok := value.(map[string]any)
+		if !ok {
+			break
+		}
+		result := make(map[string]any, len(table))
+		var problems []problem
+		for _, key := range sortedKeys(table) {
+			converted, entryProblems := check(t.Element, table[key], childPath(path, key))
+			result[key] = converted
+			problems = append(problems, entryProblems...)
+		}
+		return result, problems
+	case RecordKind:
+		table, ok := value.(map[string]any)
+		if !ok {
+			break
+		}
+		return checkRecord(t, table, path)
+	case UnionKind:
+		for _, member := range t.Members {
+			if converted, problems := check(member, value, path); len(problems) == 0 {
+				return converted, nil
+			}
+		}
+	}
+	return incompatible(valueTypeName(value))
+}
+
+func checkRecord(t *Type, table map[string]any, path []string) (any, []problem) {
+	result := make(map[string]any, len(table))
+	var problems []problem
+	for _, field := range t.Fields {
+		value, ok := table[field.Name]
+		if !ok {
+			if !field.Optional {
+				problems = append(problems, problem{path, diagnosticcodes.ConfigMissingRecordField,
+					[]any{field.Name, describePath(path)}})
+			}
+			continue
+		}
+		converted, fieldProblems := check(field.Type, value, childPath(path, field.Name))
+		result[field.Name] = converted
+		problems = append(problems, fieldProblems...)
+	}
+	for _, key := range sortedKeys(table) {
+		if _, ok := t.field(key); ok {
+			continue
+		}
+		if t.Closed {
+			problems = append(problems, problem{childPath(path, key), diagnosticcodes.ConfigUndefinedRecordField,
+				[]any{key, t.String()}})
+			continue
+		}
+		result[key] = normalize(table[key])
+	}
+	return result, problems
+}
+
+// elementsOf returns the elements of a TOML array or array of tables.
+func elementsOf(value any) ([]any, bool) {
+	switch v := value.(type) {
+	case []any:
+		return v, true
+	case []map[string]any:
+		elements := make([]any, len(v))
+		for i, table := range v {
+			elements[i] = table
+		}
+		return elements, true
+	}
+	return nil, false
+}
+
+// normalize converts arrays of tables to []any, so that anydata values have the same representation as typed ones.
+func normalize(value any) any {
+	switch v := value.(type) {
+	case []map[string]any, []any:
+		elements, _ := elementsOf(v)
+		result := make([]any, len(elements))
+		for i, element := range elements {
+			result[i] = normalize(element)
+		}
+		return result
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for key, element := range v {
+			result[key] = normalize(element)
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+// valueTypeName names the type of a TOML value in the terms used for Ballerina types.
+func valueTypeName(value any) string {
+	switch value.(type) {
+	case int64:
+		return "int"
+	case float64:
+		return "float"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case time.Time:
+		return "datetime"
+	case []any, []map[string]any:
+		return "array"
+	case map[string]any:
+		return "table"
+	default:
+		return "unknown"
+	}
+}
+
+func childPath(path []string, key string) []string {
+	return append(append([]string(nil), path...), key)
+}
+
+func sortedKeys(table map[string]any) []string {
+	keys := make([]string, 0, len(table))
+	for key := range table {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
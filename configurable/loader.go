@@ -0,0 +1,298 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package configurable loads the values of Ballerina configurable variables from Config.toml files and inline TOML,
+// and checks them against the types of the variables.
+package configurable
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ballerina-lang-go/diagnosticcodes"
+	"ballerina-lang-go/tomlparser"
+	"ballerina-lang-go/tools/diagnostics"
+	"ballerina-lang-go/tools/text"
+)
+
+const (
+	ConfigFileName = "Config.toml"
+	// ConfigFilesEnv lists the configuration files to read instead of Config.toml, separated by the OS path list
+	// separator.
+	ConfigFilesEnv = "BAL_CONFIG_FILES"
+	// ConfigDataEnv holds configuration given as inline TOML.
+	ConfigDataEnv = "BAL_CONFIG_DATA"
+)
+
+// ModuleID identifies the module that declares a configurable variable. Name is the name of the module, such as
+// "winery" for the default module of a package and "winery.api" for other modules.
+type ModuleID struct {
+	Org  string
+	Name string
+}
+
+func (m ModuleID) String() string {
+	return m.Org + "/" + m.Name
+}
+
+// Variable is a configurable variable as reported by the compiler.
+type Variable struct {
+	Module ModuleID
+	Name   string
+	Type   *Type
+	// Required is set for variables declared with "= ?", which have no default value.
+	Required bool
+}
+
+// Source is a TOML document that configures variables. Name is the path of a file, or ConfigDataEnv for inline
+// TOML, and is used as the file name of diagnostics.
+type Source struct {
+	Name    string
+	Content string
+}
+
+// Values holds the configured values of variables, converted to Go values: int64 for int, uint8 for byte, float64
+// for float, the TOML number for decimal, string, bool, []any for arrays and tables, and map[string]any for maps
+// and records.
+type Values struct {
+	values map[variableKey]any
+}
+
+type variableKey struct {
+	module ModuleID
+	name   string
+}
+
+// Get returns the configured value of a variable. Variables that are not configured are absent, and keep the
+// default value of their declaration.
+func (v *Values) Get(module ModuleID, name string) (any, bool) {
+	value, ok := v.values[variableKey{module, name}]
+	return value, ok
+}
+
+// SourcesFromEnv returns the configuration sources of a program run: the files listed in BAL_CONFIG_FILES, or
+// Config.toml in the working directory if it is not set, followed by BAL_CONFIG_DATA. lookupEnv and readFile are
+// typically os.LookupEnv and os.ReadFile. A missing Config.toml is not an error, but a missing file listed in
+// BAL_CONFIG_FILES is.
+func SourcesFromEnv(lookupEnv func(key string) (string, bool), readFile func(name string) ([]byte, error)) ([]Source, error) {
+	var sources []Source
+	if files, ok := lookupEnv(ConfigFilesEnv); ok && strings.TrimSpace(files) != "" {
+		for _, path := range filepath.SplitList(files) {
+			if path = strings.TrimSpace(path); path == "" {
+				continue
+			}
+			content, err := readFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read configuration file %s: %w", path, err)
+			}
+			sources = append(sources, Source{Name: path, Content: string(content)})
+		}
+	} else {
+		content, err := readFile(ConfigFileName)
+		switch {
+		case err == nil:
+			sources = append(sources, Source{Name: ConfigFileName, Content: string(content)})
+		case !errors.Is(err, fs.ErrNotExist):
+			return nil, fmt.Errorf("failed to read configuration file %s: %w", ConfigFileName, err)
+		}
+	}
+	if data, ok := lookupEnv(ConfigDataEnv); ok {
+		sources = append(sources, Source{Name: ConfigDataEnv, Content: data})
+	}
+	return sources, nil
+}
+
+// Load reads the values of variables from sources given in order of priority: a variable configured by more than
+// one source takes the value of the first. root is the default module of the root package, whose variables may
+// be given at the top level of a source. The variables of a module of the root package may be given under a table
+// named after the module, and the variables of any module under a table named after its org and module, such as
+//
+//	[myorg.winery.api]
+//	port = 9090
+//
+// Missing required variables, values for unknown variables and values of the wrong type are reported as
+// diagnostics, located in the source that contains them.
+func Load(root ModuleID, variables []Variable, sources ...Source) (*Values, []diagnostics.Diagnostic) {
+	l := &loader{
+		root:         root,
+		variables:    make(map[variableKey]Variable),
+		moduleTables: make(map[string]ModuleID),
+		prefixes:     make(map[string]bool),
+		provided:     make(map[variableKey]bool),
+		values:       &Values{values: make(map[variableKey]any)},
+	}
+	for _, variable := range variables {
+		l.variables[variableKey{variable.Module, variable.Name}] = variable
+		for _, path := range l.tablePaths(variable.Module) {
+			if _, ok := l.moduleTables[joinPath(path)]; !ok {
+				l.moduleTables[joinPath(path)] = variable.Module
+			}
+			for i := 1; i <= len(path); i++ {
+				l.prefixes[joinPath(path[:i])] = true
+			}
+		}
+	}
+
+	for _, source := range sources {
+		l.load(source)
+	}
+	for _, variable := range variables {
+		if !l.provided[variableKey{variable.Module, variable.Name}] && variable.Required {
+			l.diagnostics = append(l.diagnostics, diagnostics.CreateDiagnostic(
+				diagnosticcodes.ConfigValueNotProvided.DiagnosticInfo(), nil, l.qualifiedName(variable)))
+		}
+	}
+	return l.values, l.diagnostics
+}
+
+type loader struct {
+	root      ModuleID
+	variables map[variableKey]Variable
+	// moduleTables maps the joined path of every table that may hold the variables of a module to the module.
+	moduleTables map[string]ModuleID
+	// prefixes holds the joined paths of the tables that lead to module tables.
+	prefixes map[string]bool
+	// provided holds the variables given a value by any source, including values of the wrong type, so that a
+	// mistyped value is not also reported as missing.
+	provided    map[variableKey]bool
+	values      *Values
+	diagnostics []diagnostics.Diagnostic
+
+	source Source
+	toml   *tomlparser.Toml
+}
+
+// tablePaths returns the paths of the tables that may hold the variables of a module.
+func (l *loader) tablePaths(module ModuleID) [][]string {
+	var paths [][]string
+	name := strings.Split(module.Name, ".")
+	if module == l.root {
+		paths = append(paths, nil)
+	}
+	if module.Org == l.root.Org {
+		paths = append(paths, name)
+	}
+	return append(paths, append([]string{module.Org}, name...))
+}
+
+func (l *loader) load(source Source) {
+	t, err := tomlparser.ReadString(source.Content)
+	l.source, l.toml = source, t
+	if err != nil {
+		for _, d := range t.Diagnostics() {
+			l.diagnostics = append(l.diagnostics, diagnostics.CreateDiagnostic(
+				diagnosticcodes.ConfigInvalidToml.DiagnosticInfo(), l.newLocation(d.Location), source.Name, d.Message))
+		}
+		return
+	}
+	before := len(l.diagnostics)
+	l.walk(nil, t.ToMap())
+
+	// Keys are visited in sorted order; report the diagnostics of a source in the order of the document.
+	reported := l.diagnostics[before:]
+	// Diagnostics whose keys could not be located come first.
+	sort.SliceStable(reported, func(i, j int) bool {
+		la, lb := reported[i].Location(), reported[j].Location()
+		if la == nil || lb == nil {
+			return la == nil && lb != nil
+		}
+		a, b := la.LineRange().StartLine(), lb.LineRange().StartLine()
+		return a.Line() < b.Line() || a.Line() == b.Line() && a.Offset() < b.Offset()
+	})
+}
+
+// walk assigns the variables of the module table at path and descends into the tables that lead to other module
+// tables. Every other key is an unknown variable.
+func (l *loader) walk(path []string, table map[string]any) {
+	module, isModuleTable := l.moduleTables[joinPath(path)]
+	for _, key := range sortedKeys(table) {
+		child := childPath(path, key)
+		if isModuleTable {
+			if variable, ok := l.variables[variableKey{module, key}]; ok {
+				l.assign(variable, child, table[key])
+				continue
+			}
+		}
+		if nested, ok := table[key].(map[string]any); ok && l.prefixes[joinPath(child)] {
+			l.walk(child, nested)
+			continue
+		}
+		l.report(child, diagnosticcodes.ConfigUnknownVariable, describePath(child))
+	}
+}
+
+func (l *loader) assign(variable Variable, path []string, value any) {
+	converted, problems := check(variable.Type, value, path)
+	for _, p := range problems {
+		l.report(p.path, p.code, p.args...)
+	}
+	key := variableKey{variable.Module, variable.Name}
+	if !l.provided[key] && len(problems) == 0 {
+		l.values.values[key] = converted
+	}
+	l.provided[key] = true
+}
+
+func (l *loader) report(path []string, code diagnosticcodes.Definition, args ...any) {
+	location, _ := l.toml.KeyLocation(path...)
+	for i := len(path) - 1; location == nil && i > 0; i-- {
+		location, _ = l.toml.KeyLocation(path[:i]...)
+	}
+	l.diagnostics = append(l.diagnostics, diagnostics.CreateDiagnostic(code.DiagnosticInfo(), l.newLocation(location), args...))
+}
+
+// newLocation converts a one-based tomlparser location to a location in the current source.
+func (l *loader) newLocation(location *tomlparser.Location) diagnostics.Location {
+	if location == nil {
+		return nil
+	}
+	lineRange := text.LineRangeFromLinePositions(l.source.Name,
+		text.LinePositionFromLineAndOffset(location.StartLine-1, location.StartColumn-1),
+		text.LinePositionFromLineAndOffset(location.EndLine-1, location.EndColumn-1))
+	return diagnostics.NewLocation(lineRange, nil)
+}
+
+func (l *loader) qualifiedName(variable Variable) string {
+	if variable.Module == l.root {
+		return variable.Name
+	}
+	return variable.Module.String() + ":" + variable.Name
+}
+
+func joinPath(path []string) string {
+	return strings.Join(path, "\x00")
+}
+
+// describePath writes a path as it appears in TOML, with array elements as indices.
+func describePath(path []string) string {
+	var sb strings.Builder
+	for i, segment := range path {
+		if _, err := strconv.Atoi(segment); err == nil && i > 0 {
+			sb.WriteString("[" + segment + "]")
+			continue
+		}
+		if i > 0 {
+			sb.WriteString(".")
+		}
+		sb.WriteString(segment)
+	}
+	return sb.String()
+}
@@ -0,0 +1,306 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package configurable
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/txtar"
+)
+
+// TestLoad runs the golden tests in testdata. Each archive declares the root module and the configurable
+// variables, followed by the sources in order of priority (sections named *.toml or BAL_CONFIG_DATA), and the
+// expected diagnostics and values. Variables are declared one per line as
+//
+//	<org>/<module>:<name> required|optional <type>
+//
+// Set BLESS=1 to update the expected diagnostics and values.
+func TestLoad(t *testing.T) {
+	bless := os.Getenv("BLESS") == "1" || os.Getenv("BLESS") == "true"
+
+	files, err := filepath.Glob(filepath.Join("testdata", "*.txtar"))
+	if err != nil {
+		t.Fatalf("failed to list golden files: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no golden files found in testdata")
+	}
+
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".txtar")
+		t.Run(name, func(t *testing.T) {
+			archive, err := txtar.ParseFile(file)
+			if err != nil {
+				t.Fatalf("failed to parse %s: %v", file, err)
+			}
+			sections := make(map[string]string)
+			var sources []Source
+			for _, f := range archive.Files {
+				sections[f.Name] = string(f.Data)
+				if strings.HasSuffix(f.Name, ".toml") || f.Name == ConfigDataEnv {
+					sources = append(sources, Source{Name: f.Name, Content: string(f.Data)})
+				}
+			}
+
+			root, variables := parseVariables(t, sections["variables"])
+			values, diags := Load(root, variables, sources...)
+
+			var diagnosticLines strings.Builder
+			for _, d := range diags {
+				fmt.Fprintf(&diagnosticLines, "%s %s\n", d.DiagnosticInfo().Code(), d)
+			}
+			var valueLines strings.Builder
+			for _, variable := range variables {
+				if value, ok := values.Get(variable.Module, variable.Name); ok {
+					fmt.Fprintf(&valueLines, "%s:%s = %v (%T)\n", variable.Module, variable.Name, value, value)
+				}
+			}
+			actual := map[string]string{"diagnostics": diagnosticLines.String(), "values": valueLines.String()}
+
+			if bless {
+				for i := range archive.Files {
+					if content, ok := actual[archive.Files[i].Name]; ok {
+						archive.Files[i].Data = []byte(content)
+					}
+				}
+				if err := os.WriteFile(file, txtar.Format(archive), 0o644); err != nil {
+					t.Fatalf("failed to update %s: %v", file, err)
+				}
+				return
+			}
+
+			for _, section := range []string{"diagnostics", "values"} {
+				if expected := sections[section]; actual[section] != expected {
+					t.Errorf("%s mismatch:\nwant:\n%s\ngot:\n%s", section, expected, actual[section])
+				}
+			}
+		})
+	}
+}
+
+func TestSourcesFromEnv(t *testing.T) {
+	files := map[string]string{
+		ConfigFileName:    "a = 1\n",
+		"conf/first.toml": "b = 2\n",
+		"conf/other.toml": "c = 3\n",
+	}
+	readFile := func(name string) ([]byte, error) {
+		content, ok := files[name]
+		if !ok {
+			return nil, fs.ErrNotExist
+		}
+		return []byte(content), nil
+	}
+	lookup := func(env map[string]string) func(string) (string, bool) {
+		return func(key string) (string, bool) {
+			value, ok := env[key]
+			return value, ok
+		}
+	}
+	names := func(sources []Source) string {
+		var result []string
+		for _, source := range sources {
+			result = append(result, source.Name)
+		}
+		return strings.Join(result, ",")
+	}
+
+	sources, err := SourcesFromEnv(lookup(nil), readFile)
+	if err != nil || names(sources) != ConfigFileName {
+		t.Errorf("default sources = %s, %v", names(sources), err)
+	}
+
+	env := map[string]string{
+		ConfigFilesEnv: "conf/first.toml" + string(filepath.ListSeparator) + "conf/other.toml",
+		ConfigDataEnv:  "d = 4",
+	}
+	sources, err = SourcesFromEnv(lookup(env), readFile)
+	if err != nil || names(sources) != "conf/first.toml,conf/other.toml,BAL_CONFIG_DATA" {
+		t.Errorf("sources = %s, %v", names(sources), err)
+	}
+
+	delete(files, ConfigFileName)
+	sources, err = SourcesFromEnv(lookup(map[string]string{ConfigDataEnv: "d = 4"}), readFile)
+	if err != nil || names(sources) != ConfigDataEnv {
+		t.Errorf("sources without Config.toml = %s, %v", names(sources), err)
+	}
+
+	if _, err := SourcesFromEnv(lookup(map[string]string{ConfigFilesEnv: "missing.toml"}), readFile); err == nil {
+		t.Error("expected an error for a missing file in BAL_CONFIG_FILES")
+	}
+}
+
+func TestTypeString(t *testing.T) {
+	tests := []struct {
+		typ      *Type
+		expected string
+	}{
+		{ArrayOf(UnionOf(IntType, StringType)), "(int|string)[]"},
+		{MapOf(ArrayOf(ByteType)), "map<byte[]>"},
+		{TableOf(RecordOf(true, Field{"id", IntType, false}, Field{"name", StringType, true})), "table<record {| int id; string name?; |}>"},
+		{RecordOf(false).Named("Config"), "Config"},
+	}
+	for _, test := range tests {
+		if actual := test.typ.String(); actual != test.expected {
+			t.Errorf("String() = %q, want %q", actual, test.expected)
+		}
+	}
+}
+
+func parseVariables(t *testing.T, content string) (ModuleID, []Variable) {
+	var root ModuleID
+	var variables []Variable
+	for _, line := range strings.Split(strings.TrimSpace(content), "\n") {
+		if org, name, ok := strings.Cut(strings.TrimPrefix(line, "root "), "/"); ok && strings.HasPrefix(line, "root ") {
+			root = ModuleID{Org: org, Name: name}
+			continue
+		}
+		qualified, rest, _ := strings.Cut(line, " ")
+		requirement, typeDescriptor, _ := strings.Cut(rest, " ")
+		module, name, _ := strings.Cut(qualified, ":")
+		org, moduleName, _ := strings.Cut(module, "/")
+		p := &typeParser{tokens: tokenize(typeDescriptor)}
+		typ := p.parseType()
+		if p.pos != len(p.tokens) {
+			t.Fatalf("invalid type %q", typeDescriptor)
+		}
+		variables = append(variables, Variable{
+			Module:   ModuleID{Org: org, Name: moduleName},
+			Name:     name,
+			Type:     typ,
+			Required: requirement == "required",
+		})
+	}
+	return root, variables
+}
+
+// typeParser parses the subset of Ballerina type descriptors modelled by Type.
+type typeParser struct {
+	tokens []string
+	pos    int
+}
+
+func tokenize(s string) []string {
+	var tokens []string
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == ' ':
+			i++
+		case strings.HasPrefix(s[i:], "{|") || strings.HasPrefix(s[i:], "|}") || strings.HasPrefix(s[i:], "[]"):
+			tokens = append(tokens, s[i:i+2])
+			i += 2
+		case strings.ContainsRune("{}<>()|;?", rune(c)):
+			tokens = append(tokens, string(c))
+			i++
+		default:
+			start := i
+			for i < len(s) && !strings.ContainsRune(" {}<>()|;?[", rune(s[i])) {
+				i++
+			}
+			tokens = append(tokens, s[start:i])
+		}
+	}
+	return tokens
+}
+
+func (p *typeParser) next() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	p.pos++
+	return p.tokens[p.pos-1]
+}
+
+func (p *typeParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *typeParser) parseType() *Type {
+	members := []*Type{p.parsePostfix()}
+	for p.peek() == "|" {
+		p.next()
+		members = append(members, p.parsePostfix())
+	}
+	if len(members) == 1 {
+		return members[0]
+	}
+	return UnionOf(members...)
+}
+
+func (p *typeParser) parsePostfix() *Type {
+	typ := p.parsePrimary()
+	for p.peek() == "[]" {
+		p.next()
+		typ = ArrayOf(typ)
+	}
+	return typ
+}
+
+func (p *typeParser) parsePrimary() *Type {
+	switch token := p.next(); token {
+	case "int":
+		return IntType
+	case "byte":
+		return ByteType
+	case "float":
+		return FloatType
+	case "decimal":
+		return DecimalType
+	case "string":
+		return StringType
+	case "boolean":
+		return BooleanType
+	case "anydata":
+		return AnydataType
+	case "(":
+		typ := p.parseType()
+		p.next()
+		return typ
+	case "map", "table":
+		p.next()
+		element := p.parseType()
+		p.next()
+		if token == "map" {
+			return MapOf(element)
+		}
+		return TableOf(element)
+	case "record":
+		closed := p.next() == "{|"
+		var fields []Field
+		for p.peek() != "}" && p.peek() != "|}" && p.peek() != "" {
+			field := Field{Type: p.parseType(), Name: p.next()}
+			if p.peek() == "?" {
+				p.next()
+				field.Optional = true
+			}
+			p.next()
+			fields = append(fields, field)
+		}
+		p.next()
+		return RecordOf(closed, fields...)
+	default:
+		return &Type{Kind: UnionKind, Name: "<invalid " + token + ">"}
+	}
+}
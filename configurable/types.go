@@ -0,0 +1,163 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package configurable
+
+import "strings"
+
+type TypeKind uint8
+
+const (
+	IntKind TypeKind = iota
+	ByteKind
+	FloatKind
+	DecimalKind
+	StringKind
+	BooleanKind
+	AnydataKind
+	ArrayKind
+	MapKind
+	RecordKind
+	TableKind
+	UnionKind
+)
+
+// Type is the type of a configurable variable. Configurable variables are limited to subtypes of anydata, and only
+// the types that can be given in TOML are modelled.
+type Type struct {
+	Kind TypeKind
+	// Name is the name of a type definition, used in place of the structure of the type in messages.
+	Name string
+	// Element is the element type of an array, the constraint of a map or the row type of a table.
+	Element *Type
+	// Fields are the fields of a record type.
+	Fields []Field
+	// Closed is set for a record type that does not allow fields other than Fields.
+	Closed bool
+	// Members are the member types of a union type.
+	Members []*Type
+}
+
+// Field is a field of a record type.
+type Field struct {
+	Name string
+	Type *Type
+	// Optional is set for fields that are optional or have a default value.
+	Optional bool
+}
+
+var (
+	IntType     = &Type{Kind: IntKind}
+	ByteType    = &Type{Kind: ByteKind}
+	FloatType   = &Type{Kind: FloatKind}
+	DecimalType = &Type{Kind: DecimalKind}
+	StringType  = &Type{Kind: StringKind}
+	BooleanType = &Type{Kind: BooleanKind}
+	AnydataType = &Type{Kind: AnydataKind}
+)
+
+func ArrayOf(element *Type) *Type {
+	return &Type{Kind: ArrayKind, Element: element}
+}
+
+func MapOf(constraint *Type) *Type {
+	return &Type{Kind: MapKind, Element: constraint}
+}
+
+func TableOf(row *Type) *Type {
+	return &Type{Kind: TableKind, Element: row}
+}
+
+func RecordOf(closed bool, fields ...Field) *Type {
+	return &Type{Kind: RecordKind, Fields: fields, Closed: closed}
+}
+
+func UnionOf(members ...*Type) *Type {
+	return &Type{Kind: UnionKind, Members: members}
+}
+
+// Named returns a copy of the type with the name of its type definition.
+func (t *Type) Named(name string) *Type {
+	named := *t
+	named.Name = name
+	return &named
+}
+
+// String returns the type in Ballerina syntax.
+func (t *Type) String() string {
+	if t.Name != "" {
+		return t.Name
+	}
+	switch t.Kind {
+	case IntKind:
+		return "int"
+	case ByteKind:
+		return "byte"
+	case FloatKind:
+		return "float"
+	case DecimalKind:
+		return "decimal"
+	case StringKind:
+		return "string"
+	case BooleanKind:
+		return "boolean"
+	case AnydataKind:
+		return "anydata"
+	case ArrayKind:
+		if t.Element.Kind == UnionKind && t.Element.Name == "" {
+			return "(" + t.Element.String() + ")[]"
+		}
+		return t.Element.String() + "[]"
+	case MapKind:
+		return "map<" + t.Element.String() + ">"
+	case TableKind:
+		return "table<" + t.Element.String() + ">"
+	case RecordKind:
+		var sb strings.Builder
+		open, close := "record {", "}"
+		if t.Closed {
+			open, close = "record {|", "|}"
+		}
+		sb.WriteString(open)
+		for _, field := range t.Fields {
+			sb.WriteString(" " + field.Type.String() + " " + field.Name)
+			if field.Optional {
+				sb.WriteString("?")
+			}
+			sb.WriteString(";")
+		}
+		sb.WriteString(" " + close)
+		return sb.String()
+	case UnionKind:
+		members := make([]string, len(t.Members))
+		for i, member := range t.Members {
+			members[i] = member.String()
+		}
+		return strings.Join(members, "|")
+	default:
+		return "unknown"
+	}
+}
+
+// field returns the field of a record type with the given name.
+func (t *Type) field(name string) (Field, bool) {
+	for _, field := range t.Fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return Field{}, false
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diagnosticcodes
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+
+	"ballerina-lang-go/tools/diagnostics"
+)
+
+// diagnosticCodePattern matches codes such as BCE2066, where the letter after the "BC" prefix denotes the default
+// severity of the code.
+var diagnosticCodePattern = regexp.MustCompile(`^BC([EWIH])[0-9]{4}$`)
+
+var severityOfCodePrefix = map[string]diagnostics.DiagnosticSeverity{
+	"E": diagnostics.Error,
+	"W": diagnostics.Warning,
+	"I": diagnostics.Info,
+	"H": diagnostics.Hint,
+}
+
+// Definition documents a diagnostic code known to a CodeRegistry.
+type Definition interface {
+	diagnostics.DiagnosticCode
+	MessageFormat() string
+	// Explanation returns the long-form description of the diagnostic shown by the explain command.
+	Explanation() string
+	// DiagnosticInfo returns the DiagnosticInfo used to create diagnostics with this code.
+	DiagnosticInfo() diagnostics.DiagnosticInfo
+}
+
+type definitionImpl struct {
+	id            string
+	messageKey    string
+	messageFormat string
+	severity      diagnostics.DiagnosticSeverity
+	explanation   string
+}
+
+func NewDefinition(id, messageKey, messageFormat string, severity diagnostics.DiagnosticSeverity, explanation string) Definition {
+	return &definitionImpl{
+		id:            id,
+		messageKey:    messageKey,
+		messageFormat: messageFormat,
+		severity:      severity,
+		explanation:   explanation,
+	}
+}
+
+func (d *definitionImpl) DiagnosticId() string {
+	return d.id
+}
+
+func (d *definitionImpl) MessageKey() string {
+	return d.messageKey
+}
+
+func (d *definitionImpl) MessageFormat() string {
+	return d.messageFormat
+}
+
+func (d *definitionImpl) Severity() diagnostics.DiagnosticSeverity {
+	return d.severity
+}
+
+func (d *definitionImpl) Explanation() string {
+	return d.explanation
+}
+
+func (d *definitionImpl) DiagnosticInfo() diagnostics.DiagnosticInfo {
+	id := d.id
+	return diagnostics.NewDiagnosticInfo(&id, d.messageFormat, d.severity)
+}
+
+// CodeRegistry holds the diagnostic codes a tool can report. Registering a code validates its format and
+// rejects duplicates, so that codes are declared in one place rather than passed around as free-form strings.
+type CodeRegistry interface {
+	Register(definition Definition) error
+	Lookup(id string) (Definition, bool)
+	// Definitions returns the registered codes sorted by id.
+	Definitions() []Definition
+}
+
+type codeRegistryImpl struct {
+	mu          sync.RWMutex
+	definitions map[string]Definition
+	messageKeys map[string]string
+}
+
+func NewCodeRegistry() CodeRegistry {
+	return &codeRegistryImpl{
+		definitions: make(map[string]Definition),
+		messageKeys: make(map[string]string),
+	}
+}
+
+func (r *codeRegistryImpl) Register(definition Definition) error {
+	id := definition.DiagnosticId()
+	match := diagnosticCodePattern.FindStringSubmatch(id)
+	if match == nil {
+		return fmt.Errorf("invalid diagnostic code '%s': expected a code such as BCE1234", id)
+	}
+	if severity := severityOfCodePrefix[match[1]]; severity != definition.Severity() {
+		return fmt.Errorf("diagnostic code '%s' implies severity %s but is declared as %s", id, severity, definition.Severity())
+	}
+	if definition.MessageKey() == "" {
+		return fmt.Errorf("diagnostic code '%s' has no message key", id)
+	}
+	if definition.MessageFormat() == "" {
+		return fmt.Errorf("diagnostic code '%s' has no message format", id)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.definitions[id]; ok {
+		return fmt.Errorf("duplicate diagnostic code '%s'", id)
+	}
+	if other, ok := r.messageKeys[definition.MessageKey()]; ok {
+		return fmt.Errorf("message key '%s' of diagnostic code '%s' is already used by '%s'", definition.MessageKey(), id, other)
+	}
+	r.definitions[id] = definition
+	r.messageKeys[definition.MessageKey()] = id
+	return nil
+}
+
+func (r *codeRegistryImpl) Lookup(id string) (Definition, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	definition, ok := r.definitions[id]
+	return definition, ok
+}
+
+func (r *codeRegistryImpl) Definitions() []Definition {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	definitions := make([]Definition, 0, len(r.definitions))
+	for _, definition := range r.definitions {
+		definitions = append(definitions, definition)
+	}
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitions[i].DiagnosticId() < definitions[j].DiagnosticId()
+	})
+	return definitions
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diagnosticcodes
+
+import (
+	"strings"
+	"testing"
+
+	"ballerina-lang-go/tools/diagnostics"
+)
+
+func TestCodeRegistryRegister(t *testing.T) {
+	registry := NewCodeRegistry()
+	if err := registry.Register(NewDefinition("BCW1001", "unused.variable", "unused variable '%s'", diagnostics.Warning, "")); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		definition Definition
+		wantErr    string
+	}{
+		{"duplicate code", NewDefinition("BCW1001", "other.key", "other", diagnostics.Warning, ""), "duplicate diagnostic code"},
+		{"duplicate message key", NewDefinition("BCW1002", "unused.variable", "other", diagnostics.Warning, ""), "already used by 'BCW1001'"},
+		{"malformed code", NewDefinition("BCW101", "short.code", "short", diagnostics.Warning, ""), "invalid diagnostic code"},
+		{"unknown prefix", NewDefinition("XYZ1234", "bad.prefix", "bad", diagnostics.Warning, ""), "invalid diagnostic code"},
+		{"severity mismatch", NewDefinition("BCE1003", "mismatch", "mismatch", diagnostics.Warning, ""), "implies severity ERROR"},
+		{"missing message format", NewDefinition("BCW1004", "empty.format", "", diagnostics.Warning, ""), "no message format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := registry.Register(tt.definition)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Register() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if got := len(registry.Definitions()); got != 1 {
+		t.Errorf("expected only the first definition to be registered, got %d", got)
+	}
+}
+
+func TestCodeRegistryLookup(t *testing.T) {
+	registry := NewCodeRegistry()
+	for _, definition := range []Definition{
+		NewDefinition("BCH2000", "function.can.be.isolated", "function '%s' can be marked 'isolated'", diagnostics.Hint, "hint"),
+		NewDefinition("BCE2066", "incompatible.types", "incompatible types: expected '%s', found '%s'", diagnostics.Error, "error"),
+	} {
+		if err := registry.Register(definition); err != nil {
+			t.Fatalf("Register() error: %v", err)
+		}
+	}
+
+	definition, ok := registry.Lookup("BCE2066")
+	if !ok {
+		t.Fatal("expected BCE2066 to be registered")
+	}
+	info := definition.DiagnosticInfo()
+	if info.Code() != "BCE2066" || info.Severity() != diagnostics.Error || info.MessageFormat() != definition.MessageFormat() {
+		t.Errorf("unexpected diagnostic info: %s %s %q", info.Code(), info.Severity(), info.MessageFormat())
+	}
+	if _, ok := registry.Lookup("BCE9999"); ok {
+		t.Error("expected BCE9999 not to be registered")
+	}
+
+	definitions := registry.Definitions()
+	if len(definitions) != 2 || definitions[0].DiagnosticId() != "BCE2066" || definitions[1].DiagnosticId() != "BCH2000" {
+		t.Errorf("Definitions() should be sorted by id, got %v", definitions)
+	}
+}
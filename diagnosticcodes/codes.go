@@ -0,0 +1,63 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diagnosticcodes
+
+import "ballerina-lang-go/tools/diagnostics"
+
+// Error codes.
+//
+// Codes that have quick fixes document the positional properties their diagnostics carry:
+//   - UndefinedModule: the module prefix (Symbolic) and the module to import (String).
+//   - MissingReturnType: the function name (Symbolic) and the type of the returned value (Symbolic).
+//   - NonIsolatedCallInIsolated: the name of the called function (Symbolic).
+var (
+	UndefinedModule             = define("BCE2000", "undefined.module", "undefined module '%s'", diagnostics.Error)
+	CyclicModuleImportsDetected = define("BCE2001", "cyclic.module.imports.detected", "cyclic module imports detected '%s'", diagnostics.Error)
+	UnusedModulePrefix          = define("BCE2002", "unused.module.prefix", "unused module prefix '%s'", diagnostics.Error)
+	ModuleNotFound              = define("BCE2003", "module.not.found", "cannot resolve module '%s'", diagnostics.Error)
+	UndefinedSymbol             = define("BCE2010", "undefined.symbol", "undefined symbol '%s'", diagnostics.Error)
+	UndefinedFunction           = define("BCE2011", "undefined.function", "undefined function '%s'", diagnostics.Error)
+	RedeclaredSymbol            = define("BCE2012", "redeclared.symbol", "redeclared symbol '%s'", diagnostics.Error)
+	IncompatibleTypes           = define("BCE2066", "incompatible.types", "incompatible types: expected '%s', found '%s'", diagnostics.Error)
+	MissingReturnType           = define("BCE2095", "missing.return.type", "function '%s' does not declare a return type but returns a value of type '%s'", diagnostics.Error)
+	NonIsolatedCallInIsolated   = define("BCE3943", "invalid.non.isolated.call.in.isolated.function", "invalid invocation of non-isolated function '%s' in an 'isolated' function", diagnostics.Error)
+)
+
+// Configurable variable codes, reported while loading Config.toml.
+var (
+	ConfigValueNotProvided     = define("BCE4000", "config.value.not.provided", "value not provided for required configurable variable '%s'", diagnostics.Error)
+	ConfigUnknownVariable      = define("BCE4001", "config.unknown.variable", "unknown configurable variable '%s'", diagnostics.Error)
+	ConfigIncompatibleType     = define("BCE4002", "config.incompatible.type", "incompatible value for '%s': expected '%s', found '%s'", diagnostics.Error)
+	ConfigInvalidToml          = define("BCE4003", "config.invalid.toml", "invalid TOML in '%s': %s", diagnostics.Error)
+	ConfigMissingRecordField   = define("BCE4004", "config.missing.record.field", "value not provided for required field '%s' of '%s'", diagnostics.Error)
+	ConfigUndefinedRecordField = define("BCE4005", "config.undefined.record.field", "undefined field '%s' provided for closed record '%s'", diagnostics.Error)
+)
+
+// Warning codes.
+var (
+	DeprecatedConstructUsage = define("BCW1000", "deprecated.construct.usage", "usage of construct '%s' is deprecated", diagnostics.Warning)
+	UnusedVariable           = define("BCW1001", "unused.variable", "unused variable '%s'", diagnostics.Warning)
+	UnusedParameter          = define("BCW1002", "unused.parameter", "unused parameter '%s'", diagnostics.Warning)
+)
+
+// Hint codes.
+//
+// FunctionCanBeIsolated carries the function name (Symbolic) for its quick fix.
+var (
+	FunctionCanBeIsolated = define("BCH2000", "function.can.be.isolated", "function '%s' can be marked 'isolated'", diagnostics.Hint)
+	MissingDocumentation  = define("BCH2001", "missing.documentation", "public construct '%s' is not documented", diagnostics.Hint)
+)
@@ -0,0 +1,58 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package diagnosticcodes declares the diagnostic codes reported by the compiler together with their message
+// templates, default severities and long-form explanations.
+package diagnosticcodes
+
+import (
+	"embed"
+	"strings"
+
+	"ballerina-lang-go/tools/diagnostics"
+)
+
+// explanations holds one markdown file per diagnostic code, named after the code.
+//
+//go:embed explanations/*.md
+var explanations embed.FS
+
+var registry = NewCodeRegistry()
+
+// define registers a compiler diagnostic code. It panics if the code is invalid, a duplicate, or has no
+// explanation, since these are programming errors that the tests catch.
+func define(id, messageKey, messageFormat string, severity diagnostics.DiagnosticSeverity) Definition {
+	explanation, err := explanations.ReadFile("explanations/" + id + ".md")
+	if err != nil {
+		panic("no explanation for diagnostic code " + id)
+	}
+	definition := NewDefinition(id, messageKey, messageFormat, severity,
+		strings.TrimSpace(string(explanation)))
+	if err := registry.Register(definition); err != nil {
+		panic(err)
+	}
+	return definition
+}
+
+// Registry returns the registry holding every compiler diagnostic code.
+func Registry() CodeRegistry {
+	return registry
+}
+
+// Lookup returns the definition of the compiler diagnostic code with the given id.
+func Lookup(id string) (Definition, bool) {
+	return registry.Lookup(strings.ToUpper(strings.TrimSpace(id)))
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diagnosticcodes
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+func TestEveryExplanationBelongsToACode(t *testing.T) {
+	files, err := fs.Glob(explanations, "explanations/*.md")
+	if err != nil {
+		t.Fatalf("failed to list explanations: %v", err)
+	}
+	for _, file := range files {
+		id := strings.TrimSuffix(strings.TrimPrefix(file, "explanations/"), ".md")
+		if _, ok := Lookup(id); !ok {
+			t.Errorf("explanation %s does not belong to a registered diagnostic code", file)
+		}
+	}
+	if got, want := len(Registry().Definitions()), len(files); got != want {
+		t.Errorf("expected %d registered codes, got %d", want, got)
+	}
+}
+
+func TestRegisteredCodes(t *testing.T) {
+	for _, definition := range Registry().Definitions() {
+		if definition.Explanation() == "" {
+			t.Errorf("%s has an empty explanation", definition.DiagnosticId())
+		}
+	}
+
+	definition, ok := Lookup(" bce2066 ")
+	if !ok {
+		t.Fatal("Lookup() should ignore case and surrounding spaces")
+	}
+	if definition != IncompatibleTypes {
+		t.Errorf("Lookup(BCE2066) = %s, want IncompatibleTypes", definition.DiagnosticId())
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diagnosticcodes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"ballerina-lang-go/tomlparser"
+	"ballerina-lang-go/tools/diagnostics"
+)
+
+// DiagnosticsTable is the Ballerina.toml table that configures the severity of diagnostic codes, for example
+//
+//	[diagnostics]
+//	BCW1001 = "error"
+//	BCH2001 = "off"
+const DiagnosticsTable = "diagnostics"
+
+// SuppressedSeverity is the value that turns off a diagnostic code.
+const SuppressedSeverity = "off"
+
+// SeverityOverrides holds the per-project severities of diagnostic codes. Error codes cannot be overridden.
+type SeverityOverrides struct {
+	severities map[string]diagnostics.DiagnosticSeverity
+	suppressed map[string]bool
+}
+
+// ReadSeverityOverrides reads the [diagnostics] table of a Ballerina.toml manifest. Invalid entries are skipped
+// and reported as diagnostics.
+func ReadSeverityOverrides(manifest *tomlparser.Toml) (SeverityOverrides, []tomlparser.Diagnostic) {
+	overrides := SeverityOverrides{
+		severities: make(map[string]diagnostics.DiagnosticSeverity),
+		suppressed: make(map[string]bool),
+	}
+	table, ok := manifest.GetTable(DiagnosticsTable)
+	if !ok {
+		if _, exists := manifest.Get(DiagnosticsTable); exists {
+			return overrides, []tomlparser.Diagnostic{overrideDiagnostic(manifest, DiagnosticsTable, diagnostics.Error,
+				"'%s' must be a table", DiagnosticsTable)}
+		}
+		return overrides, nil
+	}
+
+	entries := table.ToMap()
+	codes := make([]string, 0, len(entries))
+	for code := range entries {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	var diags []tomlparser.Diagnostic
+	for _, code := range codes {
+		definition, ok := registry.Lookup(code)
+		if !ok {
+			diags = append(diags, overrideDiagnostic(table, code, diagnostics.Warning, "unknown diagnostic code '%s'", code))
+			continue
+		}
+		value, ok := entries[code].(string)
+		if !ok {
+			diags = append(diags, overrideDiagnostic(table, code, diagnostics.Error,
+				"severity of diagnostic code '%s' must be a string", code))
+			continue
+		}
+		if definition.Severity() == diagnostics.Error {
+			diags = append(diags, overrideDiagnostic(table, code, diagnostics.Error,
+				"severity of error code '%s' cannot be overridden", code))
+			continue
+		}
+		if strings.EqualFold(value, SuppressedSeverity) {
+			overrides.suppressed[code] = true
+			continue
+		}
+		severity, ok := diagnostics.DiagnosticSeverityFromString(value)
+		if !ok || severity == diagnostics.Internal {
+			diags = append(diags, overrideDiagnostic(table, code, diagnostics.Error,
+				"invalid severity '%s' for diagnostic code '%s': expected one of 'error', 'warning', 'info', 'hint' or 'off'",
+				value, code))
+			continue
+		}
+		overrides.severities[code] = severity
+	}
+	return overrides, diags
+}
+
+// IsSuppressed reports whether the diagnostic code is turned off.
+func (o SeverityOverrides) IsSuppressed(code string) bool {
+	return o.suppressed[code]
+}
+
+// Severity returns the configured severity of the diagnostic code, if any.
+func (o SeverityOverrides) Severity(code string) (diagnostics.DiagnosticSeverity, bool) {
+	severity, ok := o.severities[code]
+	return severity, ok
+}
+
+// Apply drops the diagnostics whose codes are suppressed and reports the rest with their configured severity.
+func (o SeverityOverrides) Apply(diags []diagnostics.Diagnostic) []diagnostics.Diagnostic {
+	result := make([]diagnostics.Diagnostic, 0, len(diags))
+	for _, diagnostic := range diags {
+		code := diagnostic.DiagnosticInfo().Code()
+		if o.IsSuppressed(code) {
+			continue
+		}
+		if severity, ok := o.Severity(code); ok {
+			diagnostic = diagnostics.WithSeverity(diagnostic, severity)
+		}
+		result = append(result, diagnostic)
+	}
+	return result
+}
+
+// overrideDiagnostic reports a problem with the key of table.
+func overrideDiagnostic(table *tomlparser.Toml, key string, severity diagnostics.DiagnosticSeverity, format string, args ...any) tomlparser.Diagnostic {
+	location, _ := table.KeyLocation(key)
+	return tomlparser.Diagnostic{
+		Message:  fmt.Sprintf(format, args...),
+		Severity: severity,
+		Location: location,
+	}
+}
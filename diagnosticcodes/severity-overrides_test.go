@@ -0,0 +1,122 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diagnosticcodes
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"ballerina-lang-go/tomlparser"
+	"ballerina-lang-go/tools/diagnostics"
+	"ballerina-lang-go/tools/text"
+)
+
+func TestReadSeverityOverrides(t *testing.T) {
+	manifest, err := tomlparser.ReadString(`
+[package]
+org = "foo"
+name = "bar"
+version = "0.1.0"
+
+[diagnostics]
+BCW1001 = "error"
+BCW1002 = "OFF"
+BCH2001 = "warning"
+BCE2066 = "warning"
+BCW9999 = "error"
+BCH2000 = "loud"
+BCW1000 = 1
+`)
+	if err != nil {
+		t.Fatalf("ReadString() error: %v", err)
+	}
+
+	overrides, diags := ReadSeverityOverrides(manifest)
+	var messages []string
+	for _, d := range diags {
+		if d.Location == nil {
+			t.Fatalf("diagnostic without a location: %s", d.Message)
+		}
+		messages = append(messages, fmt.Sprintf("%d:%d %s: %s", d.Location.StartLine, d.Location.StartColumn, d.Severity, d.Message))
+	}
+	want := []string{
+		"11:1 ERROR: severity of error code 'BCE2066' cannot be overridden",
+		"13:1 ERROR: invalid severity 'loud' for diagnostic code 'BCH2000': expected one of 'error', 'warning', 'info', 'hint' or 'off'",
+		"14:1 ERROR: severity of diagnostic code 'BCW1000' must be a string",
+		"12:1 WARNING: unknown diagnostic code 'BCW9999'",
+	}
+	if strings.Join(messages, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected diagnostics:\n%s\nwant:\n%s", strings.Join(messages, "\n"), strings.Join(want, "\n"))
+	}
+
+	if severity, ok := overrides.Severity("BCW1001"); !ok || severity != diagnostics.Error {
+		t.Errorf("Severity(BCW1001) = %s, %v, want ERROR", severity, ok)
+	}
+	if !overrides.IsSuppressed("BCW1002") {
+		t.Error("BCW1002 should be suppressed")
+	}
+	if _, ok := overrides.Severity("BCE2066"); ok {
+		t.Error("error codes must not be overridden")
+	}
+}
+
+func TestSeverityOverridesApply(t *testing.T) {
+	manifest, err := tomlparser.ReadString("[diagnostics]\nBCW1001 = \"error\"\nBCW1002 = \"off\"\n")
+	if err != nil {
+		t.Fatalf("ReadString() error: %v", err)
+	}
+	overrides, diags := ReadSeverityOverrides(manifest)
+	if len(diags) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	location := diagnostics.NewLocation(
+		text.LineRangeFromLinePositions("main.bal", text.LinePositionFromLineAndOffset(1, 4), text.LinePositionFromLineAndOffset(1, 5)),
+		text.TextRangeFromStartOffsetAndLength(10, 1))
+	input := []diagnostics.Diagnostic{
+		diagnostics.CreateDiagnostic(UnusedVariable.DiagnosticInfo(), location, "x"),
+		diagnostics.CreateDiagnostic(UnusedParameter.DiagnosticInfo(), location, "y"),
+		diagnostics.CreateDiagnostic(IncompatibleTypes.DiagnosticInfo(), location, "int", "string"),
+	}
+
+	result := overrides.Apply(input)
+	if len(result) != 2 {
+		t.Fatalf("expected the suppressed diagnostic to be dropped, got %d diagnostics", len(result))
+	}
+	if result[0].DiagnosticInfo().Severity() != diagnostics.Error || result[0].Message() != "unused variable 'x'" {
+		t.Errorf("unexpected overridden diagnostic: %s", result[0])
+	}
+	if result[1] != input[2] {
+		t.Errorf("diagnostics without overrides should be unchanged, got %s", result[1])
+	}
+}
+
+func TestReadSeverityOverridesWithoutTable(t *testing.T) {
+	manifest, err := tomlparser.ReadString("diagnostics = \"all\"\n")
+	if err != nil {
+		t.Fatalf("ReadString() error: %v", err)
+	}
+	if _, diags := ReadSeverityOverrides(manifest); len(diags) != 1 || diags[0].Location == nil || diags[0].Location.StartLine != 1 {
+		t.Errorf("expected a diagnostic at the non-table [diagnostics] entry, got %v", diags)
+	}
+
+	empty, _ := tomlparser.ReadString("")
+	if _, diags := ReadSeverityOverrides(empty); len(diags) != 0 {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+}
@@ -16,8 +16,12 @@
 
 package main
 
-import "fmt"
+import (
+	"os"
+
+	"ballerina-lang-go/cli"
+)
 
 func main() {
-	fmt.Println("hello world!")
+	os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
 }
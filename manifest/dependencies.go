@@ -0,0 +1,366 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package manifest
+
+import (
+	_ "embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ballerina-lang-go/centralclient/models"
+	"ballerina-lang-go/tomlparser"
+	"ballerina-lang-go/tools/diagnostics"
+)
+
+const DependenciesFileName = "Dependencies.toml"
+
+// DependenciesTomlVersion is the version of the Dependencies.toml format written by Dependencies.String.
+const DependenciesTomlVersion = "2"
+
+const (
+	// ScopeDefault is the scope of packages used by the package itself. It is not written to Dependencies.toml.
+	ScopeDefault = "default"
+	// ScopeTestOnly is the scope of packages used only by tests.
+	ScopeTestOnly = "testOnly"
+)
+
+const dependenciesHeader = `# AUTO-GENERATED FILE. DO NOT MODIFY.
+
+# This file is auto-generated by Ballerina for managing dependency versions.
+# It should not be modified by hand.
+`
+
+//go:embed dependencies.schema.json
+var dependenciesSchemaContent string
+
+var dependenciesSchema = func() tomlparser.Schema {
+	schema, err := tomlparser.NewSchemaFromString(dependenciesSchemaContent)
+	if err != nil {
+		panic(fmt.Sprintf("invalid dependencies schema: %v", err))
+	}
+	return schema
+}()
+
+// Dependencies is the Dependencies.toml lock file, which pins the resolved version of every package in the
+// dependency graph so that builds are reproducible.
+type Dependencies struct {
+	Ballerina BallerinaInfo   `toml:"ballerina"`
+	Packages  []LockedPackage `toml:"package"`
+
+	toml        *tomlparser.Toml
+	diagnostics []tomlparser.Diagnostic
+}
+
+type BallerinaInfo struct {
+	DependenciesTomlVersion string `toml:"dependencies-toml-version"`
+	DistributionVersion     string `toml:"distribution-version"`
+}
+
+// LockedPackage is a [[package]] entry of Dependencies.toml.
+type LockedPackage struct {
+	Org     string `toml:"org"`
+	Name    string `toml:"name"`
+	Version string `toml:"version"`
+	Scope   string `toml:"scope"`
+	// Transitive is set for packages that are not imported by the root package.
+	Transitive bool `toml:"transitive"`
+	// Digest is the SHA-256 digest of the bala of the package, written as "sha-256=<hex>". When set, a pulled bala
+	// must have this digest.
+	Digest       string             `toml:"digest"`
+	Dependencies []PackageReference `toml:"dependencies"`
+	Modules      []LockedModule     `toml:"modules"`
+}
+
+// PackageReference refers to a package in the dependencies of a LockedPackage.
+type PackageReference struct {
+	Org  string `toml:"org"`
+	Name string `toml:"name"`
+}
+
+// LockedModule is a module of a LockedPackage used by the build.
+type LockedModule struct {
+	Org         string `toml:"org"`
+	PackageName string `toml:"packageName"`
+	ModuleName  string `toml:"moduleName"`
+}
+
+func (pr PackageReference) String() string {
+	return pr.Org + "/" + pr.Name
+}
+
+// Reference returns the org and name of the package.
+func (lp LockedPackage) Reference() PackageReference {
+	return PackageReference{Org: lp.Org, Name: lp.Name}
+}
+
+// ReadDependencies reads the lock file at path. An error is returned only if the file cannot be read or is not
+// valid TOML; schema violations are reported through Diagnostics.
+func ReadDependencies(fsys fs.FS, path string) (*Dependencies, error) {
+	content, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return ReadDependenciesString(string(content))
+}
+
+func ReadDependenciesString(content string) (*Dependencies, error) {
+	t, err := tomlparser.ReadString(content)
+	if err != nil {
+		return &Dependencies{toml: t, diagnostics: t.Diagnostics()}, err
+	}
+	return DependenciesFromToml(t), nil
+}
+
+// DependenciesFromToml validates a parsed Dependencies.toml and decodes it. Like FromToml, the lock file is only
+// decoded if it is valid.
+func DependenciesFromToml(t *tomlparser.Toml) *Dependencies {
+	d := &Dependencies{toml: t}
+	before := len(t.Diagnostics())
+	if err := t.Validate(dependenciesSchema); err != nil {
+		d.diagnostics = append(d.diagnostics, t.Diagnostics()[before:]...)
+	}
+	d.validateUniquePackages()
+	if len(d.diagnostics) > 0 {
+		return d
+	}
+
+	before = len(t.Diagnostics())
+	t.To(d)
+	d.diagnostics = append(d.diagnostics, t.Diagnostics()[before:]...)
+	return d
+}
+
+// DependenciesFromResolution creates a lock file from the packages resolved by Central. The resolved packages are
+// the direct dependencies of the root package, and the packages in their dependency graphs are locked as
+// transitive dependencies. Central does not report the modules of a package, so Modules is left empty.
+func DependenciesFromResolution(response *models.PackageResolutionResponse, distributionVersion string) *Dependencies {
+	d := &Dependencies{
+		Ballerina: BallerinaInfo{
+			DependenciesTomlVersion: DependenciesTomlVersion,
+			DistributionVersion:     distributionVersion,
+		},
+	}
+	packages := make(map[PackageReference]*LockedPackage)
+	lock := func(org, name, version string, transitive bool) *LockedPackage {
+		reference := PackageReference{Org: org, Name: name}
+		if locked, ok := packages[reference]; ok {
+			locked.Transitive = locked.Transitive && transitive
+			return locked
+		}
+		locked := &LockedPackage{Org: org, Name: name, Version: version, Transitive: transitive}
+		packages[reference] = locked
+		return locked
+	}
+
+	for _, resolved := range response.Resolved {
+		lock(resolved.Org, resolved.Name, resolved.Version, false)
+	}
+	for _, resolved := range response.Resolved {
+		for _, node := range resolved.DependencyGraph {
+			lockGraph(node, lock)
+		}
+	}
+
+	for _, locked := range packages {
+		sortReferences(locked.Dependencies)
+		d.Packages = append(d.Packages, *locked)
+	}
+	sortPackages(d.Packages)
+	return d
+}
+
+// lockGraph locks a package of a dependency graph together with the edges to its dependencies. Central reports
+// the graph as a list of nodes, each with its direct dependencies, which may themselves carry nested dependencies.
+func lockGraph(node models.PackageResolutionResponseDependency, lock func(org, name, version string, transitive bool) *LockedPackage) {
+	locked := lock(node.Org, node.Name, node.Version, true)
+	for _, dependency := range node.Dependencies {
+		reference := PackageReference{Org: dependency.Org, Name: dependency.Name}
+		if !containsReference(locked.Dependencies, reference) {
+			locked.Dependencies = append(locked.Dependencies, reference)
+		}
+		lockGraph(dependency, lock)
+	}
+}
+
+// Toml returns the parsed document the lock file was read from, or nil if it was not read from a document.
+func (d *Dependencies) Toml() *tomlparser.Toml {
+	return d.toml
+}
+
+func (d *Dependencies) Diagnostics() []tomlparser.Diagnostic {
+	return d.diagnostics
+}
+
+// HasErrors reports whether any diagnostic of the lock file is an error.
+func (d *Dependencies) HasErrors() bool {
+	for _, diagnostic := range d.diagnostics {
+		if diagnostic.Severity == diagnostics.Error {
+			return true
+		}
+	}
+	return false
+}
+
+// Package returns the locked package with the given org and name.
+func (d *Dependencies) Package(org, name string) (LockedPackage, bool) {
+	for _, locked := range d.Packages {
+		if locked.Org == org && locked.Name == name {
+			return locked, true
+		}
+	}
+	return LockedPackage{}, false
+}
+
+// PinnedDigests returns the digests pinned for the packages of the lock file, keyed by "org/name:version", the form
+// expected by CentralAPIClient.SetPinnedDigests.
+func (d *Dependencies) PinnedDigests() map[string]string {
+	digests := make(map[string]string)
+	for _, locked := range d.Packages {
+		if locked.Digest != "" {
+			digests[locked.Org+"/"+locked.Name+":"+locked.Version] = locked.Digest
+		}
+	}
+	return digests
+}
+
+// String writes the lock file in the canonical Dependencies.toml format. Packages, their dependencies and their
+// modules are sorted so that the output does not depend on the order of resolution.
+func (d *Dependencies) String() string {
+	var sb strings.Builder
+	sb.WriteString(dependenciesHeader)
+
+	version := d.Ballerina.DependenciesTomlVersion
+	if version == "" {
+		version = DependenciesTomlVersion
+	}
+	sb.WriteString("\n[ballerina]\n")
+	writeEntry(&sb, "dependencies-toml-version", version)
+	if d.Ballerina.DistributionVersion != "" {
+		writeEntry(&sb, "distribution-version", d.Ballerina.DistributionVersion)
+	}
+
+	packages := append([]LockedPackage(nil), d.Packages...)
+	sortPackages(packages)
+	for _, locked := range packages {
+		sb.WriteString("\n[[package]]\n")
+		writeEntry(&sb, "org", locked.Org)
+		writeEntry(&sb, "name", locked.Name)
+		writeEntry(&sb, "version", locked.Version)
+		if locked.Scope != "" && locked.Scope != ScopeDefault {
+			writeEntry(&sb, "scope", locked.Scope)
+		}
+		if locked.Transitive {
+			sb.WriteString("transitive = true\n")
+		}
+		if locked.Digest != "" {
+			writeEntry(&sb, "digest", locked.Digest)
+		}
+
+		references := append([]PackageReference(nil), locked.Dependencies...)
+		sortReferences(references)
+		writeInlineTables(&sb, "dependencies", len(references), func(i int) string {
+			return fmt.Sprintf("{org = %s, name = %s}", tomlparser.FormatString(references[i].Org), tomlparser.FormatString(references[i].Name))
+		})
+
+		modules := append([]LockedModule(nil), locked.Modules...)
+		sort.Slice(modules, func(i, j int) bool {
+			return modules[i].ModuleName < modules[j].ModuleName
+		})
+		writeInlineTables(&sb, "modules", len(modules), func(i int) string {
+			return fmt.Sprintf("{org = %s, packageName = %s, moduleName = %s}", tomlparser.FormatString(modules[i].Org),
+				tomlparser.FormatString(modules[i].PackageName), tomlparser.FormatString(modules[i].ModuleName))
+		})
+	}
+	return sb.String()
+}
+
+// Write writes the lock file in the canonical Dependencies.toml format.
+func (d *Dependencies) Write(w io.Writer) error {
+	_, err := io.WriteString(w, d.String())
+	return err
+}
+
+func writeEntry(sb *strings.Builder, key, value string) {
+	sb.WriteString(key + " = " + tomlparser.FormatString(value) + "\n")
+}
+
+func writeInlineTables(sb *strings.Builder, key string, count int, table func(i int) string) {
+	if count == 0 {
+		return
+	}
+	sb.WriteString(key + " = [\n")
+	for i := 0; i < count; i++ {
+		sb.WriteString("\t" + table(i))
+		if i < count-1 {
+			sb.WriteString(",")
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("]\n")
+}
+
+// validateUniquePackages reports packages that are locked more than once.
+func (d *Dependencies) validateUniquePackages() {
+	packages, ok := d.toml.GetTables("package")
+	if !ok {
+		return
+	}
+	seen := make(map[PackageReference]bool)
+	for i, locked := range packages {
+		org, _ := locked.GetString("org")
+		name, _ := locked.GetString("name")
+		reference := PackageReference{Org: org, Name: name}
+		if org == "" || name == "" {
+			continue
+		}
+		if seen[reference] {
+			diagnostic := tomlparser.Diagnostic{
+				Message:  fmt.Sprintf("package '%s' is locked more than once", reference),
+				Severity: diagnostics.Error,
+			}
+			diagnostic.Location, _ = d.toml.KeyLocation("package", strconv.Itoa(i))
+			d.diagnostics = append(d.diagnostics, diagnostic)
+		}
+		seen[reference] = true
+	}
+}
+
+func compareReferences(a, b PackageReference) int {
+	if c := strings.Compare(a.Org, b.Org); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Name, b.Name)
+}
+
+func sortReferences(references []PackageReference) {
+	sort.Slice(references, func(i, j int) bool {
+		return compareReferences(references[i], references[j]) < 0
+	})
+}
+
+func containsReference(references []PackageReference, reference PackageReference) bool {
+	for _, other := range references {
+		if other == reference {
+			return true
+		}
+	}
+	return false
+}
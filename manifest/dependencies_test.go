@@ -0,0 +1,210 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ballerina-lang-go/centralclient/models"
+
+	"golang.org/x/tools/txtar"
+)
+
+// TestDependenciesDiagnostics runs the golden tests in testdata/dependencies. Each archive contains a
+// Dependencies.toml and the diagnostics reported for it. A lock file without diagnostics must be written back
+// exactly as it was read. Set BLESS=1 to update the expected diagnostics.
+func TestDependenciesDiagnostics(t *testing.T) {
+	bless := os.Getenv("BLESS") == "1" || os.Getenv("BLESS") == "true"
+
+	files, err := filepath.Glob(filepath.Join("testdata", "dependencies", "*.txtar"))
+	if err != nil {
+		t.Fatalf("failed to list golden files: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no golden files found in testdata/dependencies")
+	}
+
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".txtar")
+		t.Run(name, func(t *testing.T) {
+			archive, err := txtar.ParseFile(file)
+			if err != nil {
+				t.Fatalf("failed to parse %s: %v", file, err)
+			}
+			sections := make(map[string]string)
+			for _, f := range archive.Files {
+				sections[f.Name] = string(f.Data)
+			}
+
+			d, _ := ReadDependenciesString(sections[DependenciesFileName])
+			actual := formatDiagnostics(d.Diagnostics())
+
+			if bless {
+				for i := range archive.Files {
+					if archive.Files[i].Name == "diagnostics" {
+						archive.Files[i].Data = []byte(actual)
+					}
+				}
+				if err := os.WriteFile(file, txtar.Format(archive), 0o644); err != nil {
+					t.Fatalf("failed to update %s: %v", file, err)
+				}
+				return
+			}
+
+			if expected := sections["diagnostics"]; actual != expected {
+				t.Errorf("diagnostics mismatch:\nwant:\n%s\ngot:\n%s", expected, actual)
+			}
+			if actual == "" && d.String() != sections[DependenciesFileName] {
+				t.Errorf("round trip mismatch:\nwant:\n%s\ngot:\n%s", sections[DependenciesFileName], d.String())
+			}
+		})
+	}
+}
+
+func TestDependenciesFromResolution(t *testing.T) {
+	response := &models.PackageResolutionResponse{
+		Resolved: []models.PackageResolutionResponsePackage{
+			{
+				Org: "ballerina", Name: "io", Version: "1.6.0",
+				DependencyGraph: []models.PackageResolutionResponseDependency{
+					{Org: "ballerina", Name: "io", Version: "1.6.0", Dependencies: []models.PackageResolutionResponseDependency{
+						{Org: "ballerina", Name: "lang.value", Version: "0.0.0"},
+						{Org: "ballerina", Name: "jballerina.java", Version: "0.0.0"},
+					}},
+					{Org: "ballerina", Name: "lang.value", Version: "0.0.0", Dependencies: []models.PackageResolutionResponseDependency{
+						{Org: "ballerina", Name: "jballerina.java", Version: "0.0.0"},
+					}},
+				},
+			},
+			{Org: "ballerina", Name: "jballerina.java", Version: "0.0.0"},
+		},
+	}
+
+	d := DependenciesFromResolution(response, "2201.8.0")
+	expected := dependenciesHeader + `
+[ballerina]
+dependencies-toml-version = "2"
+distribution-version = "2201.8.0"
+
+[[package]]
+org = "ballerina"
+name = "io"
+version = "1.6.0"
+dependencies = [
+	{org = "ballerina", name = "jballerina.java"},
+	{org = "ballerina", name = "lang.value"}
+]
+
+[[package]]
+org = "ballerina"
+name = "jballerina.java"
+version = "0.0.0"
+
+[[package]]
+org = "ballerina"
+name = "lang.value"
+version = "0.0.0"
+transitive = true
+dependencies = [
+	{org = "ballerina", name = "jballerina.java"}
+]
+`
+	if d.String() != expected {
+		t.Fatalf("unexpected lock file:\nwant:\n%s\ngot:\n%s", expected, d.String())
+	}
+
+	read, err := ReadDependenciesString(d.String())
+	if err != nil || read.HasErrors() {
+		t.Fatalf("written lock file is invalid: %v %s", err, formatDiagnostics(read.Diagnostics()))
+	}
+	if !DiffDependencies(d, read).IsEmpty() {
+		t.Errorf("lock file changed after a round trip:\n%s", DiffDependencies(d, read))
+	}
+}
+
+func TestDiffDependencies(t *testing.T) {
+	old := &Dependencies{Packages: []LockedPackage{
+		{Org: "ballerina", Name: "io", Version: "1.6.0", Dependencies: []PackageReference{{"ballerina", "jballerina.java"}}},
+		{Org: "ballerina", Name: "mime", Version: "2.9.0"},
+		{Org: "ballerina", Name: "test", Version: "0.0.0", Scope: ScopeTestOnly},
+		{Org: "ballerina", Name: "log", Version: "2.9.0", Transitive: true},
+	}}
+	new := &Dependencies{Packages: []LockedPackage{
+		{Org: "ballerina", Name: "io", Version: "1.6.1", Dependencies: []PackageReference{{"ballerina", "lang.value"}}},
+		{Org: "ballerina", Name: "http", Version: "2.10.0", Scope: ScopeTestOnly},
+		{Org: "ballerina", Name: "test", Version: "0.0.0", Scope: ScopeTestOnly},
+		{Org: "ballerina", Name: "log", Version: "2.9.0", Digest: "sha-256=abc", Modules: []LockedModule{{"ballerina", "log", "log"}}},
+	}}
+
+	diff := DiffDependencies(old, new)
+	expected := `+ ballerina/http 2.10.0 (testOnly)
+~ ballerina/io 1.6.0 -> 1.6.1
+    - dependency ballerina/jballerina.java
+    + dependency ballerina/lang.value
+~ ballerina/log 2.9.0
+    transitive: true -> false
+    digest: none -> sha-256=abc
+    + module ballerina/log
+- ballerina/mime 2.9.0
+`
+	if diff.String() != expected {
+		t.Errorf("unexpected diff:\nwant:\n%s\ngot:\n%s", expected, diff.String())
+	}
+
+	if !DiffDependencies(old, old).IsEmpty() {
+		t.Error("expected no changes between identical lock files")
+	}
+	if got := DiffDependencies(nil, nil).String(); got != "no changes\n" {
+		t.Errorf("unexpected empty diff: %q", got)
+	}
+	if added := DiffDependencies(nil, new).Added; len(added) != 4 {
+		t.Errorf("expected every package to be added, got %d", len(added))
+	}
+}
+
+func TestPinnedDigests(t *testing.T) {
+	d := &Dependencies{Packages: []LockedPackage{
+		{Org: "ballerina", Name: "io", Version: "1.6.0", Digest: "sha-256=abc"},
+		{Org: "ballerina", Name: "log", Version: "2.9.0"},
+	}}
+	digests := d.PinnedDigests()
+	if len(digests) != 1 || digests["ballerina/io:1.6.0"] != "sha-256=abc" {
+		t.Errorf("unexpected pinned digests: %v", digests)
+	}
+}
+
+func TestWriteEscapesStrings(t *testing.T) {
+	d := &Dependencies{
+		Ballerina: BallerinaInfo{DependenciesTomlVersion: "2", DistributionVersion: "2201.8.0\x7f"},
+		Packages:  []LockedPackage{{Org: "ballerina", Name: "io", Version: "1.6.0", Modules: []LockedModule{{"ballerina", "io", "io\"\x01"}}}},
+	}
+	written := d.String()
+	if !strings.Contains(written, `distribution-version = "2201.8.0\u007F"`) || !strings.Contains(written, `moduleName = "io\"\u0001"`) {
+		t.Fatalf("expected TOML escapes in the lock file:\n%s", written)
+	}
+
+	read, err := ReadDependenciesString(written)
+	if err != nil {
+		t.Fatalf("written lock file is not valid TOML: %v", err)
+	}
+	if read.Ballerina.DistributionVersion != d.Ballerina.DistributionVersion || read.Packages[0].Modules[0].ModuleName != "io\"\x01" {
+		t.Errorf("strings changed after a round trip: %q, %q", read.Ballerina.DistributionVersion, read.Packages[0].Modules[0].ModuleName)
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package manifest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DependenciesDiff lists the differences between two lock files.
+type DependenciesDiff struct {
+	Added   []LockedPackage
+	Removed []LockedPackage
+	Changed []PackageChange
+}
+
+// PackageChange is a package locked by both lock files with a different version, scope, transitivity,
+// dependencies or modules.
+type PackageChange struct {
+	Old LockedPackage
+	New LockedPackage
+}
+
+// DiffDependencies compares an old lock file with a new one. Either may be nil, which is treated as an empty lock
+// file.
+func DiffDependencies(old, new *Dependencies) DependenciesDiff {
+	oldPackages := packagesByReference(old)
+	newPackages := packagesByReference(new)
+
+	var diff DependenciesDiff
+	for reference, oldPackage := range oldPackages {
+		newPackage, ok := newPackages[reference]
+		if !ok {
+			diff.Removed = append(diff.Removed, oldPackage)
+			continue
+		}
+		if len(packageChanges(oldPackage, newPackage)) > 0 || oldPackage.Version != newPackage.Version {
+			diff.Changed = append(diff.Changed, PackageChange{Old: oldPackage, New: newPackage})
+		}
+	}
+	for reference, newPackage := range newPackages {
+		if _, ok := oldPackages[reference]; !ok {
+			diff.Added = append(diff.Added, newPackage)
+		}
+	}
+
+	sortPackages(diff.Added)
+	sortPackages(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		return compareReferences(diff.Changed[i].New.Reference(), diff.Changed[j].New.Reference()) < 0
+	})
+	return diff
+}
+
+// IsEmpty reports whether the lock files lock the same packages in the same way.
+func (d DependenciesDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// String formats the diff for review, one package per line in the order of org and name:
+//
+//	~ ballerina/io 1.6.0 -> 1.6.1
+//	    + dependency ballerina/lang.value
+//	+ ballerina/log 2.9.0
+//	- ballerina/mime 2.9.0
+func (d DependenciesDiff) String() string {
+	if d.IsEmpty() {
+		return "no changes\n"
+	}
+	type entry struct {
+		reference PackageReference
+		lines     []string
+	}
+	var entries []entry
+	for _, added := range d.Added {
+		entries = append(entries, entry{added.Reference(), []string{"+ " + describeLockedPackage(added)}})
+	}
+	for _, removed := range d.Removed {
+		entries = append(entries, entry{removed.Reference(), []string{"- " + describeLockedPackage(removed)}})
+	}
+	for _, change := range d.Changed {
+		version := change.Old.Version
+		if change.Old.Version != change.New.Version {
+			version += " -> " + change.New.Version
+		}
+		lines := []string{fmt.Sprintf("~ %s %s", change.New.Reference(), version)}
+		for _, line := range packageChanges(change.Old, change.New) {
+			lines = append(lines, "    "+line)
+		}
+		entries = append(entries, entry{change.New.Reference(), lines})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return compareReferences(entries[i].reference, entries[j].reference) < 0
+	})
+
+	var sb strings.Builder
+	for _, e := range entries {
+		for _, line := range e.lines {
+			sb.WriteString(line + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// packageChanges describes the differences between two versions of a locked package other than its version.
+func packageChanges(old, new LockedPackage) []string {
+	var changes []string
+	if scopeOf(old) != scopeOf(new) {
+		changes = append(changes, fmt.Sprintf("scope: %s -> %s", scopeOf(old), scopeOf(new)))
+	}
+	if old.Transitive != new.Transitive {
+		changes = append(changes, fmt.Sprintf("transitive: %t -> %t", old.Transitive, new.Transitive))
+	}
+	if old.Digest != new.Digest {
+		changes = append(changes, fmt.Sprintf("digest: %s -> %s", digestOf(old), digestOf(new)))
+	}
+
+	oldDependencies := referenceNames(old.Dependencies)
+	newDependencies := referenceNames(new.Dependencies)
+	changes = append(changes, setChanges("dependency", oldDependencies, newDependencies)...)
+
+	oldModules := moduleNames(old.Modules)
+	newModules := moduleNames(new.Modules)
+	changes = append(changes, setChanges("module", oldModules, newModules)...)
+	return changes
+}
+
+func setChanges(kind string, old, new map[string]bool) []string {
+	var changes []string
+	for name := range new {
+		if !old[name] {
+			changes = append(changes, "+ "+kind+" "+name)
+		}
+	}
+	for name := range old {
+		if !new[name] {
+			changes = append(changes, "- "+kind+" "+name)
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		// Order by name, then list additions before removals.
+		if changes[i][2:] != changes[j][2:] {
+			return changes[i][2:] < changes[j][2:]
+		}
+		return changes[i] < changes[j]
+	})
+	return changes
+}
+
+func describeLockedPackage(locked LockedPackage) string {
+	description := locked.Reference().String() + " " + locked.Version
+	if scope := scopeOf(locked); scope != ScopeDefault {
+		description += " (" + scope + ")"
+	}
+	return description
+}
+
+func digestOf(locked LockedPackage) string {
+	if locked.Digest == "" {
+		return "none"
+	}
+	return locked.Digest
+}
+
+func scopeOf(locked LockedPackage) string {
+	if locked.Scope == "" {
+		return ScopeDefault
+	}
+	return locked.Scope
+}
+
+func packagesByReference(d *Dependencies) map[PackageReference]LockedPackage {
+	packages := make(map[PackageReference]LockedPackage)
+	if d == nil {
+		return packages
+	}
+	for _, locked := range d.Packages {
+		packages[locked.Reference()] = locked
+	}
+	return packages
+}
+
+func referenceNames(references []PackageReference) map[string]bool {
+	names := make(map[string]bool, len(references))
+	for _, reference := range references {
+		names[reference.String()] = true
+	}
+	return names
+}
+
+func moduleNames(modules []LockedModule) map[string]bool {
+	names := make(map[string]bool, len(modules))
+	for _, module := range modules {
+		names[module.Org+"/"+module.ModuleName] = true
+	}
+	return names
+}
+
+func sortPackages(packages []LockedPackage) {
+	sort.Slice(packages, func(i, j int) bool {
+		return compareReferences(packages[i].Reference(), packages[j].Reference()) < 0
+	})
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package manifest reads the Ballerina.toml manifest of a package into typed structs.
+package manifest
+
+import (
+	_ "embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+
+	"ballerina-lang-go/tomlparser"
+	"ballerina-lang-go/tools/diagnostics"
+)
+
+const FileName = "Ballerina.toml"
+
+//go:embed schema.json
+var schemaContent string
+
+var manifestSchema = func() tomlparser.Schema {
+	schema, err := tomlparser.NewSchemaFromString(schemaContent)
+	if err != nil {
+		panic(fmt.Sprintf("invalid manifest schema: %v", err))
+	}
+	return schema
+}()
+
+// Schema returns the schema of Ballerina.toml, which also describes its keys for editor completions.
+func Schema() tomlparser.Schema {
+	return manifestSchema
+}
+
+type Manifest struct {
+	Package      Package             `toml:"package"`
+	BuildOptions BuildOptions        `toml:"build-options"`
+	Platforms    map[string]Platform `toml:"platform"`
+	Dependencies []Dependency        `toml:"dependency"`
+
+	toml        *tomlparser.Toml
+	diagnostics []tomlparser.Diagnostic
+}
+
+type Package struct {
+	Org          string   `toml:"org"`
+	Name         string   `toml:"name"`
+	Version      string   `toml:"version"`
+	Distribution string   `toml:"distribution"`
+	License      []string `toml:"license"`
+	Authors      []string `toml:"authors"`
+	Repository   string   `toml:"repository"`
+	Keywords     []string `toml:"keywords"`
+	Exported     []string `toml:"exported"`
+	Icon         string   `toml:"icon"`
+	Readme       string   `toml:"readme"`
+	Visibility   string   `toml:"visibility"`
+	Template     bool     `toml:"template"`
+}
+
+type BuildOptions struct {
+	ObservabilityIncluded bool   `toml:"observabilityIncluded"`
+	Offline               bool   `toml:"offline"`
+	SkipTests             bool   `toml:"skipTests"`
+	TestReport            bool   `toml:"testReport"`
+	CodeCoverage          bool   `toml:"codeCoverage"`
+	Experimental          bool   `toml:"experimental"`
+	Sticky                bool   `toml:"sticky"`
+	GraalVM               bool   `toml:"graalvm"`
+	Cloud                 string `toml:"cloud"`
+}
+
+// Platform holds the platform-specific settings of a package, such as the [[platform.java21.dependency]] entries.
+type Platform struct {
+	GraalVMCompatible bool                 `toml:"graalvmCompatible"`
+	Dependencies      []PlatformDependency `toml:"dependency"`
+}
+
+// PlatformDependency is a platform library given either by its path or by its Maven coordinates.
+type PlatformDependency struct {
+	Path       string `toml:"path"`
+	GroupID    string `toml:"groupId"`
+	ArtifactID string `toml:"artifactId"`
+	Version    string `toml:"version"`
+	Scope      string `toml:"scope"`
+}
+
+// Dependency pins the version of a Ballerina package dependency.
+type Dependency struct {
+	Org        string `toml:"org"`
+	Name       string `toml:"name"`
+	Version    string `toml:"version"`
+	Repository string `toml:"repository"`
+}
+
+// Read reads the manifest at path. An error is returned only if the file cannot be read or is not valid TOML;
+// schema violations are reported through Diagnostics.
+func Read(fsys fs.FS, path string) (*Manifest, error) {
+	content, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return ReadString(string(content))
+}
+
+func ReadString(content string) (*Manifest, error) {
+	t, err := tomlparser.ReadString(content)
+	if err != nil {
+		return &Manifest{toml: t, diagnostics: t.Diagnostics()}, err
+	}
+	return FromToml(t), nil
+}
+
+// FromToml validates a parsed Ballerina.toml against the manifest schema and decodes it. The manifest is only
+// decoded if it is valid, since a partially decoded manifest depends on the order in which keys are decoded.
+func FromToml(t *tomlparser.Toml) *Manifest {
+	m := &Manifest{toml: t}
+	before := len(t.Diagnostics())
+	if err := t.Validate(manifestSchema); err != nil {
+		m.diagnostics = append(m.diagnostics, t.Diagnostics()[before:]...)
+	}
+	m.validatePlatformDependencies()
+	if len(m.diagnostics) > 0 {
+		return m
+	}
+
+	before = len(t.Diagnostics())
+	t.To(m)
+	m.diagnostics = append(m.diagnostics, t.Diagnostics()[before:]...)
+	return m
+}
+
+// Toml returns the parsed document the manifest was read from.
+func (m *Manifest) Toml() *tomlparser.Toml {
+	return m.toml
+}
+
+func (m *Manifest) Diagnostics() []tomlparser.Diagnostic {
+	return m.diagnostics
+}
+
+// HasErrors reports whether any diagnostic of the manifest is an error.
+func (m *Manifest) HasErrors() bool {
+	for _, diagnostic := range m.diagnostics {
+		if diagnostic.Severity == diagnostics.Error {
+			return true
+		}
+	}
+	return false
+}
+
+// validatePlatformDependencies checks the platform dependencies on the parsed document, so that they are checked
+// even when the manifest cannot be decoded.
+func (m *Manifest) validatePlatformDependencies() {
+	platforms, ok := m.toml.GetTable("platform")
+	if !ok {
+		return
+	}
+	names := make([]string, 0, len(platforms.ToMap()))
+	for name := range platforms.ToMap() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		dependencies, ok := platforms.GetTables(name + ".dependency")
+		if !ok {
+			continue
+		}
+		for i, dependency := range dependencies {
+			if _, ok := dependency.GetString("path"); ok {
+				continue
+			}
+			_, hasGroup := dependency.GetString("groupId")
+			_, hasArtifact := dependency.GetString("artifactId")
+			_, hasVersion := dependency.GetString("version")
+			if hasGroup && hasArtifact && hasVersion {
+				continue
+			}
+			m.diagnostics = append(m.diagnostics, m.errorAt(
+				"platform dependency must specify either 'path' or 'groupId', 'artifactId' and 'version'",
+				"platform", name, "dependency", strconv.Itoa(i)))
+		}
+	}
+}
+
+func (m *Manifest) errorAt(msg string, path ...string) tomlparser.Diagnostic {
+	diagnostic := tomlparser.Diagnostic{Message: msg, Severity: diagnostics.Error}
+	if m.toml != nil {
+		diagnostic.Location, _ = m.toml.KeyLocation(path...)
+	}
+	return diagnostic
+}
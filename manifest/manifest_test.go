@@ -0,0 +1,144 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ballerina-lang-go/tomlparser"
+
+	"golang.org/x/tools/txtar"
+)
+
+// TestManifestDiagnostics runs the golden tests in testdata. Each archive contains a Ballerina.toml and the
+// diagnostics reported for it. Set BLESS=1 to update the expected diagnostics.
+func TestManifestDiagnostics(t *testing.T) {
+	bless := os.Getenv("BLESS") == "1" || os.Getenv("BLESS") == "true"
+
+	files, err := filepath.Glob(filepath.Join("testdata", "*.txtar"))
+	if err != nil {
+		t.Fatalf("failed to list golden files: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no golden files found in testdata")
+	}
+
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".txtar")
+		t.Run(name, func(t *testing.T) {
+			archive, err := txtar.ParseFile(file)
+			if err != nil {
+				t.Fatalf("failed to parse %s: %v", file, err)
+			}
+			sections := make(map[string]string)
+			for _, f := range archive.Files {
+				sections[f.Name] = string(f.Data)
+			}
+
+			m, _ := ReadString(sections[FileName])
+			actual := formatDiagnostics(m.Diagnostics())
+
+			if bless {
+				for i := range archive.Files {
+					if archive.Files[i].Name == "diagnostics" {
+						archive.Files[i].Data = []byte(actual)
+					}
+				}
+				if err := os.WriteFile(file, txtar.Format(archive), 0o644); err != nil {
+					t.Fatalf("failed to update %s: %v", file, err)
+				}
+				return
+			}
+
+			if expected := sections["diagnostics"]; actual != expected {
+				t.Errorf("diagnostics mismatch:\nwant:\n%s\ngot:\n%s", expected, actual)
+			}
+		})
+	}
+}
+
+func TestReadManifest(t *testing.T) {
+	m, err := Read(os.DirFS("testdata"), "project/Ballerina.toml")
+	if err != nil {
+		t.Fatalf("Read() error: %v", err)
+	}
+	if m.HasErrors() {
+		t.Fatalf("unexpected diagnostics:\n%s", formatDiagnostics(m.Diagnostics()))
+	}
+
+	if m.Package.Org != "wso2" || m.Package.Name != "winery" || m.Package.Version != "0.1.0" {
+		t.Errorf("unexpected package: %+v", m.Package)
+	}
+	if len(m.Package.License) != 2 || m.Package.License[1] != "Apache-2.0" {
+		t.Errorf("unexpected license: %v", m.Package.License)
+	}
+	if !m.BuildOptions.ObservabilityIncluded || m.BuildOptions.Cloud != "k8s" {
+		t.Errorf("unexpected build options: %+v", m.BuildOptions)
+	}
+
+	java := m.Platforms["java21"]
+	if len(java.Dependencies) != 2 {
+		t.Fatalf("expected 2 java21 dependencies, got %d", len(java.Dependencies))
+	}
+	if got := java.Dependencies[0]; got.GroupID != "com.mysql" || got.ArtifactID != "mysql-connector-j" || got.Version != "8.0.33" {
+		t.Errorf("unexpected platform dependency: %+v", got)
+	}
+	if got := java.Dependencies[1]; got.Path != "./libs/driver.jar" || got.Scope != "provided" {
+		t.Errorf("unexpected platform dependency: %+v", got)
+	}
+
+	if len(m.Dependencies) != 1 || m.Dependencies[0] != (Dependency{Org: "ballerinax", Name: "mysql", Version: "1.11.0"}) {
+		t.Errorf("unexpected dependencies: %+v", m.Dependencies)
+	}
+}
+
+func formatDiagnostics(diags []tomlparser.Diagnostic) string {
+	var sb strings.Builder
+	for _, d := range diags {
+		location := "-"
+		if d.Location != nil {
+			location = fmt.Sprintf("%d:%d-%d:%d", d.Location.StartLine, d.Location.StartColumn, d.Location.EndLine, d.Location.EndColumn)
+		}
+		fmt.Fprintf(&sb, "%s %s %s\n", d.Severity, location, d.Message)
+	}
+	return sb.String()
+}
+
+func TestSchemaCompletions(t *testing.T) {
+	doc, _ := tomlparser.ReadString("[package]\norg = \"wso2\"\n\n")
+
+	completion := Schema().Completions(doc, tomlparser.Position{Line: 3, Column: 1})
+	var labels []string
+	for _, item := range completion.Items {
+		if item.Description == "" {
+			t.Errorf("key %s has no description", item.Label)
+		}
+		labels = append(labels, item.Label)
+	}
+	if got := strings.Join(labels, " "); !strings.HasPrefix(got, "authors distribution exported icon keywords license name") {
+		t.Errorf("unexpected completions: %s", got)
+	}
+
+	hover, ok := Schema().Hover(doc, tomlparser.Position{Line: 2, Column: 2})
+	if !ok || hover.Description != "The organization that owns the package." {
+		t.Errorf("unexpected hover: %+v", hover)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package quickfix
+
+import (
+	"fmt"
+	"strings"
+
+	"ballerina-lang-go/diagnosticcodes"
+	"ballerina-lang-go/tools/codeaction"
+	"ballerina-lang-go/tools/diagnostics"
+	"ballerina-lang-go/tools/text"
+)
+
+// addImportProvider adds the import of a module whose prefix is undefined. The diagnostic carries the prefix as
+// its first property and the module to import as its second.
+type addImportProvider struct{}
+
+func (p *addImportProvider) SupportedDiagnosticCodes() []string {
+	return []string{diagnosticcodes.UndefinedModule.DiagnosticId()}
+}
+
+func (p *addImportProvider) CodeActions(ctx codeaction.CodeActionContext) []codeaction.CodeAction {
+	prefix, ok := codeaction.Property[string](ctx.Diagnostic, 0, diagnostics.Symbolic)
+	if !ok {
+		return nil
+	}
+	module, ok := codeaction.Property[string](ctx.Diagnostic, 1, diagnostics.String)
+	if !ok || ctx.Document == nil {
+		return nil
+	}
+
+	declaration := "import " + module
+	if defaultPrefix(module) != prefix {
+		declaration += " as " + prefix
+	}
+	declaration += ";"
+
+	// New imports go after the last existing import, or at the start of the file if there is none.
+	lineMap := ctx.Document.Lines()
+	insertAt := 0
+	newText := declaration + "\n\n"
+	for i, line := range lineMap.TextLines() {
+		if strings.TrimSpace(line) == declaration {
+			return nil
+		}
+		if !strings.HasPrefix(line, "import ") {
+			continue
+		}
+		textLine, err := lineMap.TextLine(i)
+		if err != nil {
+			return nil
+		}
+		insertAt = textLine.EndOffsetWithNewLines()
+		newText = declaration + "\n"
+		if textLine.LengthWithNewLineChars() == textLine.Length() {
+			newText = "\n" + declaration
+		}
+	}
+
+	edit := text.TextEditFromTextRangeAndText(text.TextRangeFromStartOffsetAndLength(insertAt, 0), newText)
+	return []codeaction.CodeAction{
+		codeaction.NewCodeAction(fmt.Sprintf("Import module '%s'", module), ctx.Diagnostic,
+			text.TextDocumentChangeFromTextEdits([]text.TextEdit{edit})),
+	}
+}
+
+// defaultPrefix returns the prefix a module is imported with when no prefix is given, which is the last
+// component of its name.
+func defaultPrefix(module string) string {
+	name := module[strings.LastIndex(module, "/")+1:]
+	return name[strings.LastIndex(name, ".")+1:]
+}
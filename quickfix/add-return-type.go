@@ -0,0 +1,70 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package quickfix
+
+import (
+	"fmt"
+	"strings"
+
+	"ballerina-lang-go/diagnosticcodes"
+	"ballerina-lang-go/tools/codeaction"
+	"ballerina-lang-go/tools/diagnostics"
+	"ballerina-lang-go/tools/text"
+)
+
+// addReturnTypeProvider declares the return type of a function that returns a value without declaring one. The
+// diagnostic carries the function name as its first property and the type of the returned value as its second.
+type addReturnTypeProvider struct{}
+
+func (p *addReturnTypeProvider) SupportedDiagnosticCodes() []string {
+	return []string{diagnosticcodes.MissingReturnType.DiagnosticId()}
+}
+
+func (p *addReturnTypeProvider) CodeActions(ctx codeaction.CodeActionContext) []codeaction.CodeAction {
+	name, ok := codeaction.Property[string](ctx.Diagnostic, 0, diagnostics.Symbolic)
+	if !ok {
+		return nil
+	}
+	returnType, ok := codeaction.Property[string](ctx.Diagnostic, 1, diagnostics.Symbolic)
+	if !ok || ctx.Document == nil {
+		return nil
+	}
+	offset, ok := ctx.Offset()
+	if !ok {
+		return nil
+	}
+
+	source := ctx.Document.String()
+	declaration, ok := findFunctionDeclaration(source, name, offset)
+	if !ok {
+		return nil
+	}
+	closeParen, ok := matchingParen(source, declaration.openParen)
+	if !ok {
+		return nil
+	}
+	body := strings.IndexByte(source[closeParen:], '{')
+	if body < 0 || strings.Contains(source[closeParen:closeParen+body], "returns") {
+		return nil
+	}
+
+	edit := text.TextEditFromTextRangeAndText(text.TextRangeFromStartOffsetAndLength(closeParen+1, 0), " returns "+returnType)
+	return []codeaction.CodeAction{
+		codeaction.NewCodeAction(fmt.Sprintf("Add return type '%s'", returnType), ctx.Diagnostic,
+			text.TextDocumentChangeFromTextEdits([]text.TextEdit{edit})),
+	}
+}
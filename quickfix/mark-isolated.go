@@ -0,0 +1,66 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package quickfix
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"ballerina-lang-go/diagnosticcodes"
+	"ballerina-lang-go/tools/codeaction"
+	"ballerina-lang-go/tools/diagnostics"
+	"ballerina-lang-go/tools/text"
+)
+
+var isolatedQualifier = regexp.MustCompile(`\bisolated\b`)
+
+// markIsolatedProvider adds the isolated qualifier to a function. The diagnostic carries the name of the function
+// to mark as its first property: the function itself for a hint that it can be isolated, and the called function
+// for a call to a non-isolated function from an isolated one.
+type markIsolatedProvider struct{}
+
+func (p *markIsolatedProvider) SupportedDiagnosticCodes() []string {
+	return []string{
+		diagnosticcodes.FunctionCanBeIsolated.DiagnosticId(),
+		diagnosticcodes.NonIsolatedCallInIsolated.DiagnosticId(),
+	}
+}
+
+func (p *markIsolatedProvider) CodeActions(ctx codeaction.CodeActionContext) []codeaction.CodeAction {
+	name, ok := codeaction.Property[string](ctx.Diagnostic, 0, diagnostics.Symbolic)
+	if !ok || ctx.Document == nil {
+		return nil
+	}
+	offset, _ := ctx.Offset()
+
+	source := ctx.Document.String()
+	declaration, ok := findFunctionDeclaration(source, name, offset)
+	if !ok {
+		return nil
+	}
+	lineStart := strings.LastIndexByte(source[:declaration.keyword], '\n') + 1
+	if isolatedQualifier.MatchString(source[lineStart:declaration.keyword]) {
+		return nil
+	}
+
+	edit := text.TextEditFromTextRangeAndText(text.TextRangeFromStartOffsetAndLength(declaration.keyword, 0), "isolated ")
+	return []codeaction.CodeAction{
+		codeaction.NewCodeAction(fmt.Sprintf("Mark function '%s' as isolated", name), ctx.Diagnostic,
+			text.TextDocumentChangeFromTextEdits([]text.TextEdit{edit})),
+	}
+}
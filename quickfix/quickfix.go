@@ -0,0 +1,86 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package quickfix provides the code actions offered for compiler diagnostics.
+package quickfix
+
+import (
+	"regexp"
+
+	"ballerina-lang-go/tools/codeaction"
+)
+
+// Providers returns the code action providers for compiler diagnostics.
+func Providers() []codeaction.CodeActionProvider {
+	return []codeaction.CodeActionProvider{
+		&addImportProvider{},
+		&addReturnTypeProvider{},
+		&markIsolatedProvider{},
+	}
+}
+
+// NewRegistry returns a code action registry with every provider of this package registered.
+func NewRegistry() codeaction.CodeActionRegistry {
+	registry := codeaction.NewCodeActionRegistry()
+	for _, provider := range Providers() {
+		registry.Register(provider)
+	}
+	return registry
+}
+
+// functionDeclaration is the position of a function definition found in the source text.
+type functionDeclaration struct {
+	// keyword is the offset of the function keyword.
+	keyword int
+	// openParen is the offset of the parenthesis that starts the parameter list.
+	openParen int
+}
+
+// findFunctionDeclaration finds the definition of the named function. The last definition starting before the
+// given offset is preferred, since the diagnostic is usually reported inside or on the function; otherwise the
+// first definition after it is used.
+func findFunctionDeclaration(source, name string, offset int) (functionDeclaration, bool) {
+	pattern := regexp.MustCompile(`\bfunction\s+` + regexp.QuoteMeta(name) + `\s*\(`)
+	matches := pattern.FindAllStringIndex(source, -1)
+	if len(matches) == 0 {
+		return functionDeclaration{}, false
+	}
+	match := matches[0]
+	for _, m := range matches {
+		if m[0] > offset {
+			break
+		}
+		match = m
+	}
+	return functionDeclaration{keyword: match[0], openParen: match[1] - 1}, true
+}
+
+// matchingParen returns the offset of the parenthesis closing the one at open.
+func matchingParen(source string, open int) (int, bool) {
+	depth := 0
+	for i := open; i < len(source); i++ {
+		switch source[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
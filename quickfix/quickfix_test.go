@@ -0,0 +1,145 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package quickfix
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ballerina-lang-go/diagnosticcodes"
+	"ballerina-lang-go/tools/diagnostics"
+	"ballerina-lang-go/tools/text"
+
+	"golang.org/x/tools/txtar"
+)
+
+// TestQuickFixes runs the golden tests in testdata. Each archive contains a source, the diagnostic reported on it,
+// the titles of the offered code actions and the source after applying the first action. Set BLESS=1 to update the
+// expected output.
+func TestQuickFixes(t *testing.T) {
+	bless := os.Getenv("BLESS") == "1" || os.Getenv("BLESS") == "true"
+
+	files, err := filepath.Glob(filepath.Join("testdata", "*.txtar"))
+	if err != nil {
+		t.Fatalf("failed to list golden files: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no golden files found in testdata")
+	}
+
+	registry := NewRegistry()
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".txtar")
+		t.Run(name, func(t *testing.T) {
+			archive, err := txtar.ParseFile(file)
+			if err != nil {
+				t.Fatalf("failed to parse %s: %v", file, err)
+			}
+			sections := make(map[string]string)
+			for _, f := range archive.Files {
+				sections[f.Name] = string(f.Data)
+			}
+
+			document := text.TextDocumentFromText(sections["source"])
+			diagnostic, err := parseDiagnostic(strings.TrimSpace(sections["diagnostic"]), sections["source"])
+			if err != nil {
+				t.Fatalf("invalid diagnostic: %v", err)
+			}
+
+			actions := registry.CodeActions(diagnostic, document)
+			var titles strings.Builder
+			for _, action := range actions {
+				titles.WriteString(action.Title() + "\n")
+			}
+			fixed := ""
+			if len(actions) > 0 {
+				fixed = document.Apply(actions[0].Change()).String()
+			}
+
+			if bless {
+				for i := range archive.Files {
+					switch archive.Files[i].Name {
+					case "actions":
+						archive.Files[i].Data = []byte(titles.String())
+					case "expected":
+						archive.Files[i].Data = []byte(fixed)
+					}
+				}
+				if err := os.WriteFile(file, txtar.Format(archive), 0o644); err != nil {
+					t.Fatalf("failed to update %s: %v", file, err)
+				}
+				return
+			}
+
+			if titles.String() != sections["actions"] {
+				t.Errorf("actions mismatch:\nwant:\n%s\ngot:\n%s", sections["actions"], titles.String())
+			}
+			if fixed != sections["expected"] {
+				t.Errorf("fixed source mismatch:\nwant:\n%s\ngot:\n%s", sections["expected"], fixed)
+			}
+		})
+	}
+}
+
+// parseDiagnostic reads a diagnostic in the form
+//
+//	<code> <anchor> [<KIND>=<value> ...]
+//
+// where the diagnostic is located at the first occurrence of anchor in the source.
+func parseDiagnostic(line, source string) (diagnostics.Diagnostic, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("malformed diagnostic: %q", line)
+	}
+	definition, ok := diagnosticcodes.Lookup(fields[0])
+	if !ok {
+		return nil, fmt.Errorf("unknown diagnostic code %q", fields[0])
+	}
+	start := strings.Index(source, fields[1])
+	if start < 0 {
+		return nil, fmt.Errorf("anchor %q not found in source", fields[1])
+	}
+
+	var properties []diagnostics.DiagnosticProperty[any]
+	var args []any
+	for _, field := range fields[2:] {
+		kindName, value, _ := strings.Cut(field, "=")
+		kind, ok := diagnostics.DiagnosticPropertyKindFromString(kindName)
+		if !ok {
+			return nil, fmt.Errorf("unknown property kind %q", kindName)
+		}
+		properties = append(properties, diagnostics.NewDiagnosticProperty[any](kind, value))
+		args = append(args, value)
+	}
+
+	document := text.TextDocumentFromText(source)
+	lineMap := document.Lines()
+	startPosition, err := lineMap.LinePositionFromPosition(start)
+	if err != nil {
+		return nil, err
+	}
+	endPosition, err := lineMap.LinePositionFromPosition(start + len(fields[1]))
+	if err != nil {
+		return nil, err
+	}
+	location := diagnostics.NewLocation(text.LineRangeFromLinePositions("main.bal", startPosition, endPosition),
+		text.TextRangeFromStartOffsetAndLength(start, len(fields[1])))
+	return diagnostics.CreateDiagnosticWithProperties(definition.DiagnosticInfo(), location, properties, args...), nil
+}
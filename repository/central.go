@@ -0,0 +1,70 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package repository
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+
+	"ballerina-lang-go/centralclient"
+)
+
+type centralRepository struct {
+	client            centralclient.CentralAPIClient
+	supportedPlatform string
+	ballerinaVersion  string
+	clientContext     centralclient.ClientContext
+}
+
+// NewCentralRepository returns a repository of the packages of Ballerina Central, accessed through client.
+// clientContext receives the progress and the warnings of the pulls.
+func NewCentralRepository(client centralclient.CentralAPIClient, supportedPlatform, ballerinaVersion string, clientContext centralclient.ClientContext) PackageRepository {
+	return &centralRepository{
+		client:            client,
+		supportedPlatform: supportedPlatform,
+		ballerinaVersion:  ballerinaVersion,
+		clientContext:     clientContext,
+	}
+}
+
+func (r *centralRepository) Name() string {
+	return "central"
+}
+
+func (r *centralRepository) GetPackageVersions(ctx context.Context, org, name string) ([]string, error) {
+	versions, err := r.client.GetPackageVersionsCtx(ctx, org, name, r.supportedPlatform, r.ballerinaVersion)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, packageNotFound(org, name, "")
+	}
+	return sortVersions(versions), nil
+}
+
+func (r *centralRepository) Resolve(ctx context.Context, org, name, version string) (string, error) {
+	versions, err := r.GetPackageVersions(ctx, org, name)
+	if err != nil {
+		return "", err
+	}
+	return resolveVersion(versions, org, name, version)
+}
+
+func (r *centralRepository) Pull(ctx context.Context, org, name, version string, fsys fs.FS, balaCacheDir string) error {
+	return r.client.PullPackageCtx(ctx, org, name, version, fsys, filepath.Join(balaCacheDir, org, name), r.supportedPlatform, r.ballerinaVersion, r.clientContext)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+
+	"ballerina-lang-go/centralclient"
+	"ballerina-lang-go/common/bfs"
+)
+
+// newTestCentral serves versions 1.0.0 and 1.1.0 of wso2/winery.
+func newTestCentral(t *testing.T) *httptest.Server {
+	t.Helper()
+	bala := newTestBala(t, map[string]string{"bala.json": "{}"})
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/registry/packages/wso2/winery":
+			w.Header().Set(centralclient.ContentType, centralclient.ApplicationJSON)
+			_, _ = w.Write([]byte(`["1.1.0", "1.0.0"]`))
+		case "/registry/packages/wso2/missing":
+			w.Header().Set(centralclient.ContentType, centralclient.ApplicationJSON)
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "package not found: wso2/missing"}`))
+		case "/registry/packages/wso2/winery/1.1.0":
+			w.Header().Set(centralclient.Location, "http://"+r.Host+"/bala/wso2/winery/1.1.0/winery-any-1.1.0.bala")
+			w.Header().Set(centralclient.ContentDisposition, "attachment; filename=winery-any-1.1.0.bala")
+			w.WriteHeader(http.StatusFound)
+		case "/bala/wso2/winery/1.1.0/winery-any-1.1.0.bala":
+			w.Header().Set("Content-Length", strconv.Itoa(len(bala)))
+			_, _ = w.Write(bala)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestCentralRepository(t *testing.T) {
+	server := newTestCentral(t)
+	defer server.Close()
+
+	client := centralclient.NewCentralAPIClientFull(server.URL+"/registry", "", "", "", "", 0, 0, 0, 0, 0)
+	repository := NewCentralRepository(client, "any", "slp5", centralclient.ClientContext{})
+	ctx := context.Background()
+
+	versions, err := repository.GetPackageVersions(ctx, "wso2", "winery")
+	if err != nil || !slices.Equal(versions, []string{"1.0.0", "1.1.0"}) {
+		t.Errorf("unexpected versions: %v, %v", versions, err)
+	}
+	if _, err := repository.GetPackageVersions(ctx, "wso2", "missing"); !errors.Is(err, ErrPackageNotFound) {
+		t.Errorf("expected ErrPackageNotFound, got %v", err)
+	}
+	if version, err := repository.Resolve(ctx, "wso2", "winery", "1.0.0"); err != nil || version != "1.1.0" {
+		t.Errorf("unexpected resolved version: %q, %v", version, err)
+	}
+
+	memFS := bfs.NewMemFS()
+	if err := repository.Pull(ctx, "wso2", "winery", "1.1.0", memFS, "cache"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := fs.Stat(memFS, "cache/wso2/winery/1.1.0/any/bala.json"); err != nil {
+		t.Errorf("expected the package to be pulled: %v", err)
+	}
+}
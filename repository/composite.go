@@ -0,0 +1,113 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+
+	"ballerina-lang-go/centralclient"
+)
+
+type compositeRepository struct {
+	repositories []PackageRepository
+}
+
+// NewCompositeRepository returns a repository that queries repositories in order. The versions of a package are
+// those of all the repositories, while a version is resolved and pulled from the first repository that has it. A
+// repository that fails, such as Central without network access, is skipped. Pull with an empty version pulls the
+// latest version of the first repository that has the package.
+func NewCompositeRepository(repositories ...PackageRepository) PackageRepository {
+	return &compositeRepository{repositories: repositories}
+}
+
+func (r *compositeRepository) Name() string {
+	names := make([]string, len(r.repositories))
+	for i, repository := range r.repositories {
+		names[i] = repository.Name()
+	}
+	return strings.Join(names, ", ")
+}
+
+func (r *compositeRepository) GetPackageVersions(ctx context.Context, org, name string) ([]string, error) {
+	var versions []string
+	var errs []error
+	for _, repository := range r.repositories {
+		found, err := repository.GetPackageVersions(ctx, org, name)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			errs = append(errs, repositoryError(repository, err))
+			continue
+		}
+		versions = append(versions, found...)
+	}
+	if len(versions) == 0 {
+		return nil, r.notFound(org, name, "", errs)
+	}
+	return slices.Compact(sortVersions(versions)), nil
+}
+
+func (r *compositeRepository) Resolve(ctx context.Context, org, name, version string) (string, error) {
+	var errs []error
+	for _, repository := range r.repositories {
+		resolved, err := repository.Resolve(ctx, org, name, version)
+		if err == nil {
+			return resolved, nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return "", ctxErr
+		}
+		errs = append(errs, repositoryError(repository, err))
+	}
+	return "", r.notFound(org, name, version, errs)
+}
+
+func (r *compositeRepository) Pull(ctx context.Context, org, name, version string, fsys fs.FS, balaCacheDir string) error {
+	var errs []error
+	for _, repository := range r.repositories {
+		err := repository.Pull(ctx, org, name, version, fsys, balaCacheDir)
+		if _, exists := err.(*centralclient.PackageAlreadyExistsError); err == nil || exists {
+			return err
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		errs = append(errs, repositoryError(repository, err))
+	}
+	return r.notFound(org, name, version, errs)
+}
+
+// notFound returns the error of a package that no repository could provide, which is ErrPackageNotFound when no
+// repository failed for another reason.
+func (r *compositeRepository) notFound(org, name, version string, errs []error) error {
+	for _, err := range errs {
+		if !errors.Is(err, ErrPackageNotFound) {
+			return errors.Join(errs...)
+		}
+	}
+	return packageNotFound(org, name, version)
+}
+
+func repositoryError(repository PackageRepository, err error) error {
+	return fmt.Errorf("%s: %w", repository.Name(), err)
+}
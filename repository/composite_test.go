@@ -0,0 +1,131 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"ballerina-lang-go/centralclient"
+	"ballerina-lang-go/common/bfs"
+)
+
+// unreachableRepository is a repository whose source cannot be reached, like Central in an air-gapped network.
+type unreachableRepository struct {
+	calls int
+}
+
+var errUnreachable = errors.New("connection refused")
+
+func (r *unreachableRepository) Name() string {
+	return "unreachable"
+}
+
+func (r *unreachableRepository) GetPackageVersions(ctx context.Context, org, name string) ([]string, error) {
+	r.calls++
+	return nil, errUnreachable
+}
+
+func (r *unreachableRepository) Resolve(ctx context.Context, org, name, version string) (string, error) {
+	r.calls++
+	return "", errUnreachable
+}
+
+func (r *unreachableRepository) Pull(ctx context.Context, org, name, version string, fsys fs.FS, balaCacheDir string) error {
+	r.calls++
+	return errUnreachable
+}
+
+func TestCompositeRepository(t *testing.T) {
+	bala := newTestBala(t, map[string]string{"bala.json": `{"source": "mirror"}`})
+	mirror := NewMirrorRepository(fstest.MapFS{
+		"mirror/wso2/winery/1.0.0/winery-any-1.0.0.bala": {Data: bala},
+		"mirror/wso2/winery/1.2.0/winery-any-1.2.0.bala": {Data: bala},
+	}, "mirror", "any")
+	local := newTestLocalRepository()
+	unreachable := &unreachableRepository{}
+	repository := NewCompositeRepository(mirror, unreachable, local)
+	ctx := context.Background()
+
+	if name := repository.Name(); name != "mirror, unreachable, local" {
+		t.Errorf("unexpected name: %s", name)
+	}
+
+	versions, err := repository.GetPackageVersions(ctx, "wso2", "winery")
+	if err != nil || !slices.Equal(versions, []string{"1.0.0", "1.1.0", "1.2.0", "2.0.0"}) {
+		t.Errorf("expected the versions of every repository, got %v, %v", versions, err)
+	}
+	// The first repository that resolves a version wins, even when a later one has a newer version.
+	if version, err := repository.Resolve(ctx, "wso2", "winery", "1.0.0"); err != nil || version != "1.2.0" {
+		t.Errorf("expected the version of the mirror, got %q, %v", version, err)
+	}
+	if version, err := repository.Resolve(ctx, "wso2", "winery", "2.0.0"); err != nil || version != "2.0.0" {
+		t.Errorf("expected the version of the local repository, got %q, %v", version, err)
+	}
+
+	memFS := bfs.NewMemFS()
+	if err := repository.Pull(ctx, "wso2", "winery", "1.0.0", memFS, "cache"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, _ := fs.ReadFile(memFS, "cache/wso2/winery/1.0.0/any/bala.json")
+	if string(data) != `{"source": "mirror"}` {
+		t.Errorf("expected the bala of the mirror, got %q", data)
+	}
+	if err := repository.Pull(ctx, "wso2", "winery", "2.0.0", memFS, "cache"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var existsErr *centralclient.PackageAlreadyExistsError
+	calls := unreachable.calls
+	if err := repository.Pull(ctx, "wso2", "winery", "1.0.0", memFS, "cache"); !errors.As(err, &existsErr) {
+		t.Errorf("expected a package already exists error, got %v", err)
+	}
+	if unreachable.calls != calls {
+		t.Error("expected an installed package not to be pulled from the other repositories")
+	}
+}
+
+func TestCompositeRepositoryErrors(t *testing.T) {
+	ctx := context.Background()
+	mirror := NewMirrorRepository(fstest.MapFS{}, "mirror", "any")
+
+	// Only repositories without the package: the error is ErrPackageNotFound.
+	repository := NewCompositeRepository(mirror, newTestLocalRepository())
+	if _, err := repository.Resolve(ctx, "wso2", "missing", ""); !errors.Is(err, ErrPackageNotFound) {
+		t.Errorf("expected ErrPackageNotFound, got %v", err)
+	}
+	if _, err := repository.GetPackageVersions(ctx, "wso2", "missing"); !errors.Is(err, ErrPackageNotFound) {
+		t.Errorf("expected ErrPackageNotFound, got %v", err)
+	}
+
+	// A failing repository: its error is reported.
+	repository = NewCompositeRepository(mirror, &unreachableRepository{})
+	err := repository.Pull(ctx, "wso2", "winery", "1.0.0", bfs.NewMemFS(), "cache")
+	if !errors.Is(err, errUnreachable) || !strings.Contains(err.Error(), "unreachable: connection refused") {
+		t.Errorf("expected the error of the unreachable repository, got %v", err)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := repository.Resolve(canceled, "wso2", "winery", ""); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
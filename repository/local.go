@@ -0,0 +1,150 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+
+	"ballerina-lang-go/common/bfs"
+)
+
+type localRepository struct {
+	fsys              fs.FS
+	root              string
+	supportedPlatform string
+}
+
+// NewLocalRepository returns a repository of the extracted balas under root in fsys, laid out as
+// bala/<org>/<name>/<version>/<platform> like the bala cache.
+func NewLocalRepository(fsys fs.FS, root, supportedPlatform string) PackageRepository {
+	return &localRepository{fsys: fsys, root: root, supportedPlatform: supportedPlatform}
+}
+
+func (r *localRepository) Name() string {
+	return "local"
+}
+
+func (r *localRepository) GetPackageVersions(ctx context.Context, org, name string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	entries, err := fs.ReadDir(r.fsys, filepath.Join(r.root, "bala", org, name))
+	if err != nil {
+		return nil, packageNotFound(org, name, "")
+	}
+
+	var versions []string
+	for _, entry := range entries {
+		if entry.IsDir() && r.platform(org, name, entry.Name()) != "" {
+			versions = append(versions, entry.Name())
+		}
+	}
+	versions = sortVersions(versions)
+	if len(versions) == 0 {
+		return nil, packageNotFound(org, name, "")
+	}
+	return versions, nil
+}
+
+func (r *localRepository) Resolve(ctx context.Context, org, name, version string) (string, error) {
+	versions, err := r.GetPackageVersions(ctx, org, name)
+	if err != nil {
+		return "", err
+	}
+	return resolveVersion(versions, org, name, version)
+}
+
+func (r *localRepository) Pull(ctx context.Context, org, name, version string, fsys fs.FS, balaCacheDir string) error {
+	if version == "" {
+		latest, err := r.Resolve(ctx, org, name, "")
+		if err != nil {
+			return err
+		}
+		version = latest
+	}
+	platform := r.platform(org, name, version)
+	if platform == "" {
+		return packageNotFound(org, name, version)
+	}
+	src := filepath.Join(r.root, "bala", org, name, version, platform)
+	return install(fsys, balaCacheDir, org, name, version, platform, func(dest string) error {
+		return copyDir(ctx, r.fsys, src, fsys, dest)
+	})
+}
+
+// platform returns the platform of the bala of a version usable on the supported platform, or "" when there is
+// none.
+func (r *localRepository) platform(org, name, version string) string {
+	for _, platform := range platforms(r.supportedPlatform) {
+		entries, err := fs.ReadDir(r.fsys, filepath.Join(r.root, "bala", org, name, version, platform))
+		if err == nil && len(entries) > 0 {
+			return platform
+		}
+	}
+	return ""
+}
+
+// copyDir copies the files under src in srcFS to dest in destFS.
+func copyDir(ctx context.Context, srcFS fs.FS, src string, destFS fs.FS, dest string) error {
+	return fs.WalkDir(srcFS, src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, rel)
+		if entry.IsDir() {
+			return bfs.MkdirAll(destFS, target, 0o755)
+		}
+		if !entry.Type().IsRegular() {
+			return fmt.Errorf("unsupported file in bala: %s", path)
+		}
+		return copyFile(srcFS, path, destFS, target)
+	})
+}
+
+func copyFile(srcFS fs.FS, src string, destFS fs.FS, dest string) error {
+	in, err := srcFS.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := bfs.Create(destFS, dest)
+	if err != nil {
+		return err
+	}
+	writer, ok := out.(io.Writer)
+	if !ok {
+		out.Close()
+		return &fs.PathError{Op: "write", Path: dest, Err: fs.ErrInvalid}
+	}
+	if _, err := io.Copy(writer, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"ballerina-lang-go/common/bfs"
+)
+
+func newTestLocalRepository() PackageRepository {
+	fsys := fstest.MapFS{
+		"repo/bala/wso2/winery/1.0.0/any/bala.json":            {Data: []byte(`{"version": "1.0.0"}`)},
+		"repo/bala/wso2/winery/1.0.0/any/modules/winery/a.bal": {Data: []byte("public function main() {}")},
+		"repo/bala/wso2/winery/1.1.0/java21/bala.json":         {Data: []byte(`{"version": "1.1.0"}`)},
+		"repo/bala/wso2/winery/1.2.0/java11/bala.json":         {Data: []byte(`{"version": "1.2.0"}`)},
+		"repo/bala/wso2/winery/2.0.0/any/.keep":                {Data: nil},
+		"repo/bala/wso2/winery/latest/any/bala.json":           {Data: []byte("{}")},
+		"repo/bala/wso2/winery/3.0.0/any":                      {Mode: fs.ModeDir},
+	}
+	return NewLocalRepository(fsys, "repo", "java21")
+}
+
+func TestLocalRepository(t *testing.T) {
+	repository := newTestLocalRepository()
+	ctx := context.Background()
+
+	versions, err := repository.GetPackageVersions(ctx, "wso2", "winery")
+	if err != nil || !slices.Equal(versions, []string{"1.0.0", "1.1.0", "2.0.0"}) {
+		t.Errorf("unexpected versions: %v, %v", versions, err)
+	}
+	if _, err := repository.GetPackageVersions(ctx, "wso2", "missing"); !errors.Is(err, ErrPackageNotFound) {
+		t.Errorf("expected ErrPackageNotFound, got %v", err)
+	}
+	if version, err := repository.Resolve(ctx, "wso2", "winery", "1.0.0"); err != nil || version != "1.1.0" {
+		t.Errorf("unexpected resolved version: %q, %v", version, err)
+	}
+
+	memFS := bfs.NewMemFS()
+	if err := repository.Pull(ctx, "wso2", "winery", "1.0.0", memFS, "cache"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := fs.ReadFile(memFS, "cache/wso2/winery/1.0.0/any/modules/winery/a.bal")
+	if err != nil || string(data) != "public function main() {}" {
+		t.Errorf("expected the package to be copied, got %q, %v", data, err)
+	}
+	if err := repository.Pull(ctx, "wso2", "winery", "1.1.0", memFS, "cache"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := fs.Stat(memFS, "cache/wso2/winery/1.1.0/java21/bala.json"); err != nil {
+		t.Errorf("expected the java21 bala to be copied: %v", err)
+	}
+	if err := repository.Pull(ctx, "wso2", "winery", "1.2.0", memFS, "cache"); !errors.Is(err, ErrPackageNotFound) {
+		t.Errorf("expected ErrPackageNotFound for a bala of another platform, got %v", err)
+	}
+	if err := repository.Pull(ctx, "wso2", "winery", "", memFS, "cache"); err != nil {
+		t.Fatalf("unexpected error pulling the latest version: %v", err)
+	}
+	if _, err := fs.Stat(memFS, "cache/wso2/winery/2.0.0/any/.keep"); err != nil {
+		t.Errorf("expected the latest version to be copied: %v", err)
+	}
+}
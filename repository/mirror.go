@@ -0,0 +1,125 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"ballerina-lang-go/centralclient"
+)
+
+type mirrorRepository struct {
+	fsys              fs.FS
+	root              string
+	supportedPlatform string
+	limits            centralclient.ExtractionLimits
+}
+
+// NewMirrorRepository returns a repository of the balas under root in fsys, laid out like the file server of
+// Central as <org>/<name>/<version>/<name>-<platform>-<version>.bala, so that a mirror can be filled by copying
+// the balas pulled from Central. The balas are extracted with the checks applied to the balas of Central.
+func NewMirrorRepository(fsys fs.FS, root, supportedPlatform string) PackageRepository {
+	return &mirrorRepository{
+		fsys:              fsys,
+		root:              root,
+		supportedPlatform: supportedPlatform,
+		limits:            centralclient.DefaultExtractionLimits,
+	}
+}
+
+func (r *mirrorRepository) Name() string {
+	return "mirror"
+}
+
+func (r *mirrorRepository) GetPackageVersions(ctx context.Context, org, name string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	entries, err := fs.ReadDir(r.fsys, filepath.Join(r.root, org, name))
+	if err != nil {
+		return nil, packageNotFound(org, name, "")
+	}
+
+	var versions []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			if _, platform := r.bala(org, name, entry.Name()); platform != "" {
+				versions = append(versions, entry.Name())
+			}
+		}
+	}
+	versions = sortVersions(versions)
+	if len(versions) == 0 {
+		return nil, packageNotFound(org, name, "")
+	}
+	return versions, nil
+}
+
+func (r *mirrorRepository) Resolve(ctx context.Context, org, name, version string) (string, error) {
+	versions, err := r.GetPackageVersions(ctx, org, name)
+	if err != nil {
+		return "", err
+	}
+	return resolveVersion(versions, org, name, version)
+}
+
+func (r *mirrorRepository) Pull(ctx context.Context, org, name, version string, fsys fs.FS, balaCacheDir string) error {
+	if version == "" {
+		latest, err := r.Resolve(ctx, org, name, "")
+		if err != nil {
+			return err
+		}
+		version = latest
+	}
+	src, platform := r.bala(org, name, version)
+	if platform == "" {
+		return packageNotFound(org, name, version)
+	}
+	return install(fsys, balaCacheDir, org, name, version, platform, func(dest string) error {
+		balaPath := filepath.Join(dest, filepath.Base(src))
+		if err := copyFile(r.fsys, src, fsys, balaPath); err != nil {
+			return fmt.Errorf("error copying bala of %s/%s:%s: %w", org, name, version, err)
+		}
+		return centralclient.ExtractBala(ctx, fsys, balaPath, dest, r.limits)
+	})
+}
+
+// bala returns the path and the platform of the bala of a version usable on the supported platform, or "" when
+// there is none.
+func (r *mirrorRepository) bala(org, name, version string) (string, string) {
+	dir := filepath.Join(r.root, org, name, version)
+	entries, err := fs.ReadDir(r.fsys, dir)
+	if err != nil {
+		return "", ""
+	}
+	for _, platform := range platforms(r.supportedPlatform) {
+		for _, entry := range entries {
+			if !entry.IsDir() && entry.Name() == balaFileName(name, platform, version) {
+				return filepath.Join(dir, entry.Name()), platform
+			}
+		}
+	}
+	return "", ""
+}
+
+func balaFileName(name, platform, version string) string {
+	return strings.Join([]string{name, platform, version}, "-") + ".bala"
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"ballerina-lang-go/centralclient"
+	"ballerina-lang-go/common/bfs"
+)
+
+func TestMirrorRepository(t *testing.T) {
+	bala := newTestBala(t, map[string]string{"bala.json": "{}", "modules/winery/a.bal": "public function main() {}"})
+	hostile := newTestBala(t, map[string]string{"../../evil.txt": "evil"})
+	fsys := fstest.MapFS{
+		"mirror/wso2/winery/1.0.0/winery-any-1.0.0.bala":      {Data: bala},
+		"mirror/wso2/winery/1.1.0/winery-java21-1.1.0.bala":   {Data: bala},
+		"mirror/wso2/winery/1.2.0/winery-java11-1.2.0.bala":   {Data: bala},
+		"mirror/wso2/winery/1.3.0/winery-any-1.3.0.bala":      {Data: []byte("not a zip")},
+		"mirror/wso2/winery/1.4.0/winery-any-1.4.0.bala":      {Data: hostile},
+		"mirror/wso2/winery/2.0.0/README.md":                  {Data: []byte("not a bala")},
+		"mirror/wso2/winery/2.1.0-beta/winery-any-2.1.0.bala": {Data: bala},
+	}
+	repository := NewMirrorRepository(fsys, "mirror", "java21")
+	ctx := context.Background()
+
+	versions, err := repository.GetPackageVersions(ctx, "wso2", "winery")
+	if err != nil || !slices.Equal(versions, []string{"1.0.0", "1.1.0", "1.3.0", "1.4.0"}) {
+		t.Errorf("unexpected versions: %v, %v", versions, err)
+	}
+	if version, err := repository.Resolve(ctx, "wso2", "winery", ""); err != nil || version != "1.4.0" {
+		t.Errorf("unexpected resolved version: %q, %v", version, err)
+	}
+
+	memFS := bfs.NewMemFS()
+	if err := repository.Pull(ctx, "wso2", "winery", "1.1.0", memFS, "cache"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, name := range []string{"winery-java21-1.1.0.bala", "bala.json", "modules/winery/a.bal"} {
+		if _, err := fs.Stat(memFS, "cache/wso2/winery/1.1.0/java21/"+name); err != nil {
+			t.Errorf("expected %s to be installed: %v", name, err)
+		}
+	}
+
+	if err := repository.Pull(ctx, "wso2", "winery", "1.3.0", memFS, "cache"); err == nil {
+		t.Error("expected an error for an invalid bala")
+	}
+	var unsafeErr *centralclient.UnsafeBalaEntryError
+	if err := repository.Pull(ctx, "wso2", "winery", "1.4.0", memFS, "cache"); !errors.As(err, &unsafeErr) {
+		t.Errorf("expected an unsafe entry error, got %v", err)
+	}
+	entries, _ := fs.ReadDir(memFS, "cache/wso2/winery")
+	if len(entries) != 1 || entries[0].Name() != "1.1.0" {
+		t.Errorf("expected failed pulls to leave nothing behind, found %v", entries)
+	}
+	if err := repository.Pull(ctx, "wso2", "winery", "2.0.0", memFS, "cache"); !errors.Is(err, ErrPackageNotFound) {
+		t.Errorf("expected ErrPackageNotFound, got %v", err)
+	}
+
+	// The latest version is 1.4.0, whose bala is rejected.
+	if err := repository.Pull(ctx, "wso2", "winery", "", memFS, "cache"); !errors.As(err, &unsafeErr) {
+		t.Errorf("expected the latest version to be pulled, got %v", err)
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package repository abstracts the sources packages are resolved and pulled from: Ballerina Central, a local
+// repository of extracted balas, a file mirror of Central and an ordered composition of these, so that a build can
+// be served by an internal mirror without access to Central.
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+
+	"ballerina-lang-go/centralclient"
+	"ballerina-lang-go/common/bfs"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// AnyPlatform is the platform of the balas that run on every platform.
+const AnyPlatform = "any"
+
+// ErrPackageNotFound is returned by a PackageRepository that does not have a package, or a version of it.
+var ErrPackageNotFound = errors.New("package not found")
+
+// PackageRepository is a source of packages. Pull installs a package into a bala cache with the
+// <org>/<name>/<version>/<platform> layout used by the Central client, and returns a
+// *centralclient.PackageAlreadyExistsError when the package is already there.
+type PackageRepository interface {
+	// Name identifies the repository in messages.
+	Name() string
+	GetPackageVersions(ctx context.Context, org, name string) ([]string, error)
+	// Resolve returns the version of a package to use for version: the latest version when version is empty, and
+	// otherwise the latest version compatible with version.
+	Resolve(ctx context.Context, org, name, version string) (string, error)
+	// Pull pulls a version of a package, or the latest version of the repository when version is empty. A version is
+	// pulled as it is, so a version to be resolved is passed through Resolve first.
+	Pull(ctx context.Context, org, name, version string, fsys fs.FS, balaCacheDir string) error
+}
+
+// resolveVersion returns the latest of versions compatible with version, that is not older than version and has
+// the same major version, or the same minor version for a 0.x version. Pre-release versions are only used when
+// version is one, or when there is no other compatible version.
+func resolveVersion(versions []string, org, name, version string) (string, error) {
+	var requested *semver.Version
+	if version != "" {
+		v, err := semver.StrictNewVersion(version)
+		if err != nil {
+			return "", fmt.Errorf("invalid version '%s' of %s/%s: %w", version, org, name, err)
+		}
+		requested = v
+	}
+
+	var best, bestPrerelease *semver.Version
+	for _, candidate := range versions {
+		v, err := semver.StrictNewVersion(candidate)
+		if err != nil || requested != nil && !compatible(requested, v) {
+			continue
+		}
+		if v.Prerelease() != "" && (requested == nil || requested.Prerelease() == "") {
+			if bestPrerelease == nil || v.GreaterThan(bestPrerelease) {
+				bestPrerelease = v
+			}
+			continue
+		}
+		if best == nil || v.GreaterThan(best) {
+			best = v
+		}
+	}
+
+	if best == nil {
+		best = bestPrerelease
+	}
+	if best == nil {
+		return "", packageNotFound(org, name, version)
+	}
+	return best.Original(), nil
+}
+
+func compatible(requested, candidate *semver.Version) bool {
+	if candidate.LessThan(requested) || candidate.Major() != requested.Major() {
+		return false
+	}
+	return requested.Major() != 0 || candidate.Minor() == requested.Minor()
+}
+
+func packageNotFound(org, name, version string) error {
+	if version == "" {
+		return fmt.Errorf("%w: %s/%s", ErrPackageNotFound, org, name)
+	}
+	return fmt.Errorf("%w: %s/%s:%s", ErrPackageNotFound, org, name, version)
+}
+
+// platforms returns the platforms of the balas usable on supportedPlatform, in order of preference.
+func platforms(supportedPlatform string) []string {
+	if supportedPlatform == "" || supportedPlatform == AnyPlatform {
+		return []string{AnyPlatform}
+	}
+	return []string{supportedPlatform, AnyPlatform}
+}
+
+// sortVersions sorts versions from the oldest to the latest, ignoring the names that are not versions.
+func sortVersions(names []string) []string {
+	var versions []*semver.Version
+	for _, name := range names {
+		if v, err := semver.StrictNewVersion(name); err == nil {
+			versions = append(versions, v)
+		}
+	}
+	slices.SortFunc(versions, func(a, b *semver.Version) int { return a.Compare(b) })
+	sorted := make([]string, len(versions))
+	for i, v := range versions {
+		sorted[i] = v.Original()
+	}
+	return sorted
+}
+
+// install installs a package into <balaCacheDir>/<org>/<name>/<version>/<platform> of fsys with the staging of the
+// Central client: write fills a fresh <version>_temp/<platform> directory, which becomes <version>/<platform> once
+// it is complete. A failed install leaves nothing behind, and the balas of the other platforms of the version stay.
+func install(fsys fs.FS, balaCacheDir, org, name, version, platform string, write func(dir string) error) error {
+	pkgDir := filepath.Join(balaCacheDir, org, name)
+	dest := filepath.Join(pkgDir, version, platform)
+	if entries, err := fs.ReadDir(fsys, dest); err == nil && len(entries) > 0 {
+		return centralclient.NewPackageAlreadyExistsError(fmt.Sprintf("package already exists in the home repository: %s", dest), version)
+	}
+
+	tempDir := filepath.Join(pkgDir, version+"_temp")
+	tempPath := filepath.Join(tempDir, platform)
+	if err := bfs.RemoveIfExists(fsys, tempDir); err != nil {
+		return fmt.Errorf("error creating directory for bala: %w", err)
+	}
+	created := bfs.FirstMissingDir(fsys, tempPath)
+	if err := bfs.MkdirAll(fsys, tempPath, 0o755); err != nil {
+		return fmt.Errorf("error creating directory for bala: %w", err)
+	}
+	cleanUp := func() {
+		_ = bfs.RemoveIfExists(fsys, tempDir)
+		_ = bfs.RemoveIfExists(fsys, created)
+	}
+	if err := write(tempPath); err != nil {
+		cleanUp()
+		return err
+	}
+	if err := bfs.MoveDir(fsys, tempPath, dest); err != nil {
+		cleanUp()
+		return fmt.Errorf("error installing %s/%s:%s: %w", org, name, version, err)
+	}
+	_ = bfs.RemoveIfExists(fsys, tempDir)
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package repository
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io/fs"
+	"testing"
+
+	"ballerina-lang-go/centralclient"
+	"ballerina-lang-go/common/bfs"
+)
+
+func TestResolveVersion(t *testing.T) {
+	versions := []string{"0.9.0", "0.9.3", "0.10.0", "1.0.0", "1.2.0", "1.3.0-beta.1", "2.0.0", "3.0.0-alpha"}
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "", want: "2.0.0"},
+		{version: "1.0.0", want: "1.2.0"},
+		{version: "1.2.0", want: "1.2.0"},
+		{version: "1.3.0-beta.1", want: "1.3.0-beta.1"},
+		{version: "0.9.1", want: "0.9.3"},
+		{version: "0.10.0", want: "0.10.0"},
+		{version: "2.1.0", want: ""},
+		{version: "3.0.0-alpha", want: "3.0.0-alpha"},
+		// Pre-release versions are used when there is no other compatible version.
+		{version: "3.0.0-0", want: "3.0.0-alpha"},
+	}
+
+	for _, test := range tests {
+		got, err := resolveVersion(versions, "wso2", "winery", test.version)
+		if test.want == "" {
+			if !errors.Is(err, ErrPackageNotFound) {
+				t.Errorf("resolveVersion(%q): expected ErrPackageNotFound, got %q, %v", test.version, got, err)
+			}
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("resolveVersion(%q) = %q, %v, expected %q", test.version, got, err, test.want)
+		}
+	}
+
+	if _, err := resolveVersion(versions, "wso2", "winery", "1.0"); err == nil || errors.Is(err, ErrPackageNotFound) {
+		t.Errorf("expected an invalid version error, got %v", err)
+	}
+	if got, err := resolveVersion([]string{"1.0.0-rc1"}, "wso2", "winery", ""); err != nil || got != "1.0.0-rc1" {
+		t.Errorf("expected the only pre-release version, got %q, %v", got, err)
+	}
+}
+
+func TestInstall(t *testing.T) {
+	memFS := bfs.NewMemFS()
+	write := func(dir string) error {
+		return bfs.WriteFile(memFS, dir+"/bala.json", []byte("{}"), 0o644)
+	}
+	if err := install(memFS, "bala", "wso2", "winery", "1.0.0", "any", write); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := fs.Stat(memFS, "bala/wso2/winery/1.0.0/any/bala.json"); err != nil {
+		t.Errorf("expected the package to be installed: %v", err)
+	}
+	if _, err := fs.Stat(memFS, "bala/wso2/winery/1.0.0_temp"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected the temp directory to be moved, got %v", err)
+	}
+
+	var existsErr *centralclient.PackageAlreadyExistsError
+	if err := install(memFS, "bala", "wso2", "winery", "1.0.0", "any", write); !errors.As(err, &existsErr) {
+		t.Errorf("expected a package already exists error, got %v", err)
+	}
+
+	failure := errors.New("failed")
+	err := install(memFS, "bala", "ballerina", "io", "1.0.0", "any", func(dir string) error {
+		_ = write(dir)
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Errorf("expected the error of write, got %v", err)
+	}
+	if _, err := fs.Stat(memFS, "bala/ballerina"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected a failed install to leave nothing behind, got %v", err)
+	}
+}
+
+func TestInstallSecondPlatform(t *testing.T) {
+	// A dirFS is used as the Move of memFS merges into an existing directory, which the rename of a dirFS does not.
+	dirFS := bfs.NewDirFS(t.TempDir())
+	write := func(dir string) error {
+		return bfs.WriteFile(dirFS, dir+"/bala.json", []byte("{}"), 0o644)
+	}
+	if err := install(dirFS, "bala", "wso2", "winery", "1.0.0", "any", write); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A <version>_temp directory left behind by an install that crashed must not be moved into the bala cache.
+	if err := bfs.WriteFile(dirFS, "bala/wso2/winery/1.0.0_temp/java21/stale.txt", []byte("stale"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := install(dirFS, "bala", "wso2", "winery", "1.0.0", "java21", write); err != nil {
+		t.Fatalf("unexpected error installing a second platform: %v", err)
+	}
+	for _, path := range []string{"bala/wso2/winery/1.0.0/any/bala.json", "bala/wso2/winery/1.0.0/java21/bala.json"} {
+		if _, err := fs.Stat(dirFS, path); err != nil {
+			t.Errorf("expected %s to be installed: %v", path, err)
+		}
+	}
+	if _, err := fs.Stat(dirFS, "bala/wso2/winery/1.0.0/java21/stale.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected the stale temp directory to be cleared, got %v", err)
+	}
+	if _, err := fs.Stat(dirFS, "bala/wso2/winery/1.0.0_temp"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected the temp directory to be removed, got %v", err)
+	}
+}
+
+// newTestBala returns a bala with files, keyed by their path in the bala.
+func newTestBala(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for name, content := range files {
+		w, err := writer.Create(name)
+		if err != nil {
+			t.Fatalf("failed to create entry %s: %v", name, err)
+		}
+		_, _ = w.Write([]byte(content))
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to write bala: %v", err)
+	}
+	return buffer.Bytes()
+}
@@ -0,0 +1,260 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package settings reads the user-level Settings.toml, which holds the Ballerina Central access token, the
+// timeouts used to connect to Central and the proxy to connect through.
+package settings
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"ballerina-lang-go/centralclient"
+	"ballerina-lang-go/tomlparser"
+	"ballerina-lang-go/tools/diagnostics"
+)
+
+const (
+	FileName = "Settings.toml"
+	// HomeDirName is the directory under the user home that holds Settings.toml.
+	HomeDirName = ".ballerina"
+)
+
+// Environment variables that override the values of Settings.toml.
+const (
+	AccessTokenEnv    = "BALLERINA_CENTRAL_ACCESS_TOKEN"
+	ConnectTimeoutEnv = "BALLERINA_CENTRAL_CONNECT_TIMEOUT"
+	ReadTimeoutEnv    = "BALLERINA_CENTRAL_READ_TIMEOUT"
+	WriteTimeoutEnv   = "BALLERINA_CENTRAL_WRITE_TIMEOUT"
+	CallTimeoutEnv    = "BALLERINA_CENTRAL_CALL_TIMEOUT"
+	MaxRetriesEnv     = "BALLERINA_CENTRAL_MAX_RETRIES"
+	ProxyHostEnv      = "BALLERINA_PROXY_HOST"
+	ProxyPortEnv      = "BALLERINA_PROXY_PORT"
+	ProxyUsernameEnv  = "BALLERINA_PROXY_USERNAME"
+	ProxyPasswordEnv  = "BALLERINA_PROXY_PASSWORD"
+)
+
+//go:embed schema.json
+var schemaContent string
+
+var settingsSchema = func() tomlparser.Schema {
+	schema, err := tomlparser.NewSchemaFromString(schemaContent)
+	if err != nil {
+		panic(fmt.Sprintf("invalid settings schema: %v", err))
+	}
+	return schema
+}()
+
+type Settings struct {
+	Central Central `toml:"central"`
+	Proxy   Proxy   `toml:"proxy"`
+
+	toml        *tomlparser.Toml
+	diagnostics []tomlparser.Diagnostic
+}
+
+// Central holds the [central] table. Timeouts are in seconds, and a timeout of zero means no timeout.
+type Central struct {
+	AccessToken    string `toml:"accesstoken"`
+	ConnectTimeout int    `toml:"connecttimeout"`
+	ReadTimeout    int    `toml:"readtimeout"`
+	WriteTimeout   int    `toml:"writetimeout"`
+	CallTimeout    int    `toml:"calltimeout"`
+	MaxRetries     int    `toml:"maxretries"`
+}
+
+// Proxy holds the [proxy] table. An empty host means that no proxy is used.
+type Proxy struct {
+	Host     string `toml:"host"`
+	Port     int    `toml:"port"`
+	Username string `toml:"username"`
+	Password string `toml:"password"`
+}
+
+// Default returns the settings used when Settings.toml does not exist or does not set a value.
+func Default() *Settings {
+	return &Settings{
+		Central: Central{
+			ConnectTimeout: centralclient.DefaultConnectTimeout,
+			ReadTimeout:    centralclient.DefaultReadTimeout,
+			WriteTimeout:   centralclient.DefaultWriteTimeout,
+			CallTimeout:    centralclient.DefaultCallTimeout,
+			MaxRetries:     centralclient.MaxRetry,
+		},
+	}
+}
+
+// Path returns the path of the Settings.toml of the current user.
+func Path() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the user home directory: %w", err)
+	}
+	return filepath.Join(home, HomeDirName, FileName), nil
+}
+
+// Load reads the Settings.toml of the current user and applies the environment overrides. The default settings
+// are used if the file does not exist.
+func Load() (*Settings, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	s, err := Read(os.DirFS(filepath.Dir(path)), filepath.Base(path))
+	if errors.Is(err, fs.ErrNotExist) {
+		s, err = Default(), nil
+	}
+	if err != nil {
+		return s, err
+	}
+	s.ApplyEnv(os.LookupEnv)
+	return s, nil
+}
+
+// Read reads the settings at path. An error is returned only if the file cannot be read or is not valid TOML;
+// schema violations are reported through Diagnostics.
+func Read(fsys fs.FS, path string) (*Settings, error) {
+	content, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return ReadString(string(content))
+}
+
+func ReadString(content string) (*Settings, error) {
+	t, err := tomlparser.ReadString(content)
+	if err != nil {
+		s := Default()
+		s.toml, s.diagnostics = t, t.Diagnostics()
+		return s, err
+	}
+	return FromToml(t), nil
+}
+
+// FromToml validates a parsed Settings.toml and decodes it over the default settings. Invalid settings are not
+// decoded, so that a mistake in the file does not result in a half-configured client.
+func FromToml(t *tomlparser.Toml) *Settings {
+	s := Default()
+	s.toml = t
+	before := len(t.Diagnostics())
+	if err := t.Validate(settingsSchema); err != nil {
+		s.diagnostics = append(s.diagnostics, t.Diagnostics()[before:]...)
+		return s
+	}
+
+	before = len(t.Diagnostics())
+	t.To(s)
+	s.diagnostics = append(s.diagnostics, t.Diagnostics()[before:]...)
+	return s
+}
+
+// ApplyEnv overrides the settings with the environment variables returned by lookupEnv, typically os.LookupEnv.
+// Variables with invalid values are ignored and reported as diagnostics.
+func (s *Settings) ApplyEnv(lookupEnv func(key string) (string, bool)) {
+	if value, ok := lookupEnv(AccessTokenEnv); ok {
+		s.Central.AccessToken = value
+	}
+	if value, ok := lookupEnv(ProxyHostEnv); ok {
+		s.Proxy.Host = value
+	}
+	if value, ok := lookupEnv(ProxyUsernameEnv); ok {
+		s.Proxy.Username = value
+	}
+	if value, ok := lookupEnv(ProxyPasswordEnv); ok {
+		s.Proxy.Password = value
+	}
+
+	integers := []struct {
+		env    string
+		target *int
+		max    int
+	}{
+		{ConnectTimeoutEnv, &s.Central.ConnectTimeout, -1},
+		{ReadTimeoutEnv, &s.Central.ReadTimeout, -1},
+		{WriteTimeoutEnv, &s.Central.WriteTimeout, -1},
+		{CallTimeoutEnv, &s.Central.CallTimeout, -1},
+		{MaxRetriesEnv, &s.Central.MaxRetries, -1},
+		{ProxyPortEnv, &s.Proxy.Port, 65535},
+	}
+	for _, integer := range integers {
+		value, ok := lookupEnv(integer.env)
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || n < 0 || (integer.max >= 0 && n > integer.max) {
+			expected := "a non-negative integer"
+			if integer.max >= 0 {
+				expected = fmt.Sprintf("an integer between 0 and %d", integer.max)
+			}
+			s.diagnostics = append(s.diagnostics, tomlparser.Diagnostic{
+				Message:  fmt.Sprintf("invalid value '%s' for environment variable '%s': expected %s", value, integer.env, expected),
+				Severity: diagnostics.Error,
+			})
+			continue
+		}
+		*integer.target = n
+	}
+}
+
+// Toml returns the parsed document the settings were read from, or nil if they were not read from a document.
+func (s *Settings) Toml() *tomlparser.Toml {
+	return s.toml
+}
+
+func (s *Settings) Diagnostics() []tomlparser.Diagnostic {
+	return s.diagnostics
+}
+
+// HasErrors reports whether any diagnostic of the settings is an error.
+func (s *Settings) HasErrors() bool {
+	for _, diagnostic := range s.diagnostics {
+		if diagnostic.Severity == diagnostics.Error {
+			return true
+		}
+	}
+	return false
+}
+
+// ProxyURL returns the URL of the proxy, or an empty string if no proxy is configured. A host without a scheme is
+// treated as an HTTP proxy.
+func (s *Settings) ProxyURL() string {
+	host := strings.TrimSpace(s.Proxy.Host)
+	if host == "" {
+		return ""
+	}
+	if !strings.Contains(host, "://") {
+		host = "http://" + host
+	}
+	if s.Proxy.Port > 0 {
+		host = strings.TrimSuffix(host, "/") + ":" + strconv.Itoa(s.Proxy.Port)
+	}
+	return host
+}
+
+// NewCentralAPIClient creates a client for the Central API at baseURL configured with the access token, timeouts,
+// retries and proxy of the settings.
+func (s *Settings) NewCentralAPIClient(baseURL string) centralclient.CentralAPIClient {
+	return centralclient.NewCentralAPIClientFull(baseURL, s.ProxyURL(), s.Proxy.Username, s.Proxy.Password,
+		s.Central.AccessToken, s.Central.ConnectTimeout, s.Central.ReadTimeout, s.Central.WriteTimeout,
+		s.Central.CallTimeout, s.Central.MaxRetries)
+}
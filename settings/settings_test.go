@@ -0,0 +1,178 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package settings
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"ballerina-lang-go/centralclient"
+	"ballerina-lang-go/tomlparser"
+)
+
+func TestReadSettings(t *testing.T) {
+	s, err := Read(os.DirFS("testdata"), FileName)
+	if err != nil {
+		t.Fatalf("Read() error: %v", err)
+	}
+	if s.HasErrors() {
+		t.Fatalf("unexpected diagnostics:\n%s", formatDiagnostics(s.Diagnostics()))
+	}
+
+	expected := Central{
+		AccessToken:    "0f6b4e2c-access-token",
+		ConnectTimeout: 30,
+		ReadTimeout:    centralclient.DefaultReadTimeout,
+		WriteTimeout:   centralclient.DefaultWriteTimeout,
+		CallTimeout:    120,
+		MaxRetries:     3,
+	}
+	if s.Central != expected {
+		t.Errorf("unexpected central settings: %+v", s.Central)
+	}
+	if s.ProxyURL() != "http://proxy.example.com:3128" || s.Proxy.Username != "alice" || s.Proxy.Password != "secret" {
+		t.Errorf("unexpected proxy settings: %+v", s.Proxy)
+	}
+
+	client := s.NewCentralAPIClient("https://api.central.ballerina.io/2.0/registry")
+	if client.AccessToken() != expected.AccessToken {
+		t.Errorf("client access token = %q, want %q", client.AccessToken(), expected.AccessToken)
+	}
+}
+
+func TestReadMissingSettings(t *testing.T) {
+	_, err := Read(os.DirFS("testdata"), "missing/"+FileName)
+	if err == nil {
+		t.Fatal("expected an error for a missing file")
+	}
+}
+
+func TestSettingsDiagnostics(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:    "empty",
+			content: "",
+		},
+		{
+			name:     "negative values",
+			content:  "[central]\nconnecttimeout = -1\nmaxretries = -2\n\n[proxy]\nport = 70000\n",
+			expected: "ERROR 2:1-2:15 invalid 'connecttimeout' under [central]: timeouts are given in seconds and cannot be negative\nERROR 3:1-3:11 invalid 'maxretries' under [central]: the number of retries cannot be negative\nERROR 6:1-6:5 invalid 'port' under [proxy]: the port must be between 0 and 65535\n",
+		},
+		{
+			name:     "unknown keys and types",
+			content:  "[central]\naccesstoken = 42\ntoken = \"x\"\n\n[cloud]\n",
+			expected: "ERROR 2:1-2:12 invalid type for key 'central.accesstoken': expected string, found integer\nERROR 3:1-3:6 unknown key 'token'\nERROR 5:2-5:7 unknown key 'cloud'\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := ReadString(test.content)
+			if err != nil {
+				t.Fatalf("ReadString() error: %v", err)
+			}
+			if actual := formatDiagnostics(s.Diagnostics()); actual != test.expected {
+				t.Errorf("diagnostics mismatch:\nwant:\n%s\ngot:\n%s", test.expected, actual)
+			}
+			if s.HasErrors() && (s.Central != Default().Central || s.Proxy != (Proxy{})) {
+				t.Errorf("invalid settings must not be decoded: %+v", s.Central)
+			}
+		})
+	}
+}
+
+func TestReadSettingsSyntaxError(t *testing.T) {
+	s, err := ReadString("[central\n")
+	if err == nil || !s.HasErrors() {
+		t.Fatalf("expected a syntax error, got %v", err)
+	}
+	if s.Central != Default().Central {
+		t.Errorf("expected the default settings, got %+v", s.Central)
+	}
+}
+
+func TestApplyEnv(t *testing.T) {
+	s, err := Read(os.DirFS("testdata"), FileName)
+	if err != nil {
+		t.Fatalf("Read() error: %v", err)
+	}
+	env := map[string]string{
+		AccessTokenEnv:    "from-env",
+		ReadTimeoutEnv:    "10",
+		MaxRetriesEnv:     "many",
+		ProxyHostEnv:      "https://corporate.proxy/",
+		ProxyPortEnv:      "8443",
+		CallTimeoutEnv:    "-5",
+		ProxyPasswordEnv:  "",
+		ConnectTimeoutEnv: " 15 ",
+	}
+	s.ApplyEnv(func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	})
+
+	if s.Central.AccessToken != "from-env" || s.Central.ReadTimeout != 10 || s.Central.ConnectTimeout != 15 {
+		t.Errorf("environment overrides not applied: %+v", s.Central)
+	}
+	if s.Central.MaxRetries != 3 || s.Central.CallTimeout != 120 {
+		t.Errorf("invalid environment values must be ignored: %+v", s.Central)
+	}
+	if s.ProxyURL() != "https://corporate.proxy:8443" || s.Proxy.Password != "" || s.Proxy.Username != "alice" {
+		t.Errorf("unexpected proxy settings: %+v", s.Proxy)
+	}
+
+	expected := "ERROR - invalid value '-5' for environment variable 'BALLERINA_CENTRAL_CALL_TIMEOUT': expected a non-negative integer\n" +
+		"ERROR - invalid value 'many' for environment variable 'BALLERINA_CENTRAL_MAX_RETRIES': expected a non-negative integer\n"
+	if actual := formatDiagnostics(s.Diagnostics()); actual != expected {
+		t.Errorf("diagnostics mismatch:\nwant:\n%s\ngot:\n%s", expected, actual)
+	}
+}
+
+func TestProxyURL(t *testing.T) {
+	tests := []struct {
+		proxy    Proxy
+		expected string
+	}{
+		{Proxy{}, ""},
+		{Proxy{Host: "  "}, ""},
+		{Proxy{Host: "proxy.local"}, "http://proxy.local"},
+		{Proxy{Host: "socks5://proxy.local", Port: 1080}, "socks5://proxy.local:1080"},
+	}
+	for _, test := range tests {
+		s := &Settings{Proxy: test.proxy}
+		if actual := s.ProxyURL(); actual != test.expected {
+			t.Errorf("ProxyURL() for %+v = %q, want %q", test.proxy, actual, test.expected)
+		}
+	}
+}
+
+func formatDiagnostics(diags []tomlparser.Diagnostic) string {
+	var sb strings.Builder
+	for _, d := range diags {
+		location := "-"
+		if d.Location != nil {
+			location = fmt.Sprintf("%d:%d-%d:%d", d.Location.StartLine, d.Location.StartColumn, d.Location.EndLine, d.Location.EndColumn)
+		}
+		fmt.Fprintf(&sb, "%s %s %s\n", d.Severity, location, d.Message)
+	}
+	return sb.String()
+}
@@ -0,0 +1,529 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tomlparser
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Position is a position in a document. Lines and columns are one-based like Location, and columns are counted in
+// runes.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// CompletionKind is the kind of text completed at a position.
+type CompletionKind uint8
+
+const (
+	// NoCompletion is used at positions where nothing can be completed, such as in comments.
+	NoCompletion CompletionKind = iota
+	// KeyCompletion completes the key of a key-value.
+	KeyCompletion
+	// TableCompletion completes the name of a [table] or [[table]] header.
+	TableCompletion
+	// ValueCompletion completes the value of a key-value or the element of an array.
+	ValueCompletion
+)
+
+func (ck CompletionKind) String() string {
+	switch ck {
+	case NoCompletion:
+		return "NONE"
+	case KeyCompletion:
+		return "KEY"
+	case TableCompletion:
+		return "TABLE"
+	case ValueCompletion:
+		return "VALUE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Completion holds the keys or values the schema allows at a position of a document.
+type Completion struct {
+	Kind CompletionKind
+	// Path is the path of the table whose keys are completed, or of the key whose value is completed.
+	Path []string
+	// Prefix is the text of the key or value being completed, from its start up to the position.
+	Prefix string
+	Items  []CompletionItem
+}
+
+type CompletionItem struct {
+	// Label is the key or value as written in TOML, quoted if needed.
+	Label string
+	// Type is the TOML type of the key, or empty if the schema does not restrict it.
+	Type        string
+	Description string
+	// Required reports whether the key must be defined.
+	Required bool
+}
+
+// Hover describes the key at a position of a document.
+type Hover struct {
+	Path []string
+	// Type is the TOML type of the key, or empty if the schema does not restrict it.
+	Type        string
+	Description string
+	// Location is the location of the key segment at the position.
+	Location Location
+}
+
+// Completions returns the keys or values that the schema allows at the position of the document, which must have
+// been read with one of the Read functions. The document does not need to be valid: keys are completed as they are
+// typed. Keys that are already defined are not offered; items are not filtered by the prefix.
+func (s *schemaImpl) Completions(toml *Toml, position Position) Completion {
+	content := toml.content
+	root := parseSyntax(content)
+	offset, ok := positionOffset(content, position)
+	if !ok {
+		return Completion{Kind: NoCompletion}
+	}
+	context := locateCompletion(content, root, offset)
+	completion := Completion{Kind: context.kind, Path: context.path, Prefix: context.prefix}
+
+	nodes := s.schemaAt(context.path)
+	switch context.kind {
+	case KeyCompletion, TableCompletion:
+		defined := definedKeys(root, context.current)
+		for _, property := range s.properties(nodes) {
+			if context.kind == TableCompletion && !isTableType(property.typ, context.arrayTable) {
+				continue
+			}
+			// Arrays of tables are defined by repeating their header.
+			if !context.arrayTable && defined[joinKeyPath(append(clonePath(context.path), property.name))] {
+				continue
+			}
+			completion.Items = append(completion.Items, CompletionItem{
+				Label:       formatKey(property.name),
+				Type:        property.typ,
+				Description: property.description,
+				Required:    property.required,
+			})
+		}
+	case ValueCompletion:
+		completion.Items = s.values(nodes)
+	}
+	return completion
+}
+
+// Hover describes the key at the position of the document, which must have been read with one of the Read functions.
+// It reports false if there is no key at the position or the schema does not describe it.
+func (s *schemaImpl) Hover(toml *Toml, position Position) (Hover, bool) {
+	content := toml.content
+	offset, ok := positionOffset(content, position)
+	if !ok {
+		return Hover{}, false
+	}
+	segment := segmentAt(parseSyntax(content), offset)
+	if segment == nil {
+		return Hover{}, false
+	}
+	nodes := s.schemaAt(segment.path)
+	if len(nodes) == 0 {
+		return Hover{}, false
+	}
+	return Hover{
+		Path:        segment.Path(),
+		Type:        schemaType(nodes),
+		Description: schemaDescription(nodes),
+		Location:    newLineIndex(content).location(content, segment.start, segment.end),
+	}, true
+}
+
+// completionContext is the key or value being completed at an offset of a document.
+type completionContext struct {
+	kind   CompletionKind
+	path   []string
+	prefix string
+	// arrayTable reports whether a [[table]] header is completed.
+	arrayTable bool
+	// current is the key being completed, whose segments are not considered as already defined.
+	current *SyntaxNode
+}
+
+func locateCompletion(src string, root *SyntaxNode, offset int) completionContext {
+	lineStart := strings.LastIndexByte(src[:offset], '\n') + 1
+	before := strings.TrimSpace(src[lineStart:offset])
+	if strings.HasPrefix(before, "#") {
+		return completionContext{kind: NoCompletion}
+	}
+
+	var section *SyntaxNode
+	for _, child := range root.children {
+		if (child.kind == TableNode || child.kind == ArrayTableNode) && child.start <= offset {
+			section = child
+		}
+	}
+	if section == nil {
+		return completionContext{kind: NoCompletion}
+	}
+
+	if strings.HasPrefix(before, "[") && !strings.Contains(before, "]") {
+		context := completionContext{kind: TableCompletion, arrayTable: strings.HasPrefix(before, "[[")}
+		if section.key != nil && section.key.start >= lineStart {
+			context.current = section.key
+			context.path, context.prefix = keyContext(src, section.key, nil, offset)
+		}
+		return context
+	}
+	context, ok := locateInTable(src, section, section.path, offset)
+	if !ok && before == "" {
+		return completionContext{kind: KeyCompletion, path: section.Path()}
+	}
+	return context
+}
+
+// locateInTable finds the completion context among the key-values of a table or inline table with the given path.
+func locateInTable(src string, table *SyntaxNode, path []string, offset int) (completionContext, bool) {
+	for _, keyValue := range table.keyValues() {
+		if offset < keyValue.start || offset > keyValue.end {
+			continue
+		}
+		if offset <= keyValue.key.end {
+			context := completionContext{kind: KeyCompletion, current: keyValue.key}
+			context.path, context.prefix = keyContext(src, keyValue.key, path, offset)
+			return context, true
+		}
+		if keyValue.value != nil && offset >= keyValue.value.start {
+			return locateInValue(src, keyValue.value, offset), true
+		}
+		return completionContext{kind: NoCompletion}, true
+	}
+	return completionContext{kind: NoCompletion}, false
+}
+
+func locateInValue(src string, value *SyntaxNode, offset int) completionContext {
+	inside := offset > value.start && (offset < value.end || !strings.HasSuffix(src[value.start:value.end], closing(value)))
+	switch {
+	case value.kind == InlineTableNode && inside:
+		if context, ok := locateInTable(src, value, value.path, offset); ok {
+			return context
+		}
+		return completionContext{kind: KeyCompletion, path: value.Path()}
+	case value.kind == ArrayNode && inside:
+		elements := value.elements()
+		for _, element := range elements {
+			if offset >= element.start && offset <= element.end {
+				return locateInValue(src, element, offset)
+			}
+		}
+		// An element that is not typed yet is completed as the next element of the array.
+		index := 0
+		for _, element := range elements {
+			if element.end <= offset {
+				index++
+			}
+		}
+		return completionContext{kind: ValueCompletion, path: append(value.Path(), strconv.Itoa(index))}
+	case value.kind == ValueNode:
+		return completionContext{kind: ValueCompletion, path: value.Path(), prefix: src[value.start:offset]}
+	default:
+		return completionContext{kind: NoCompletion}
+	}
+}
+
+func closing(value *SyntaxNode) string {
+	if value.kind == InlineTableNode {
+		return "}"
+	}
+	return "]"
+}
+
+// keyContext returns the path of the table in which the key segment at the offset is defined, and the text of the
+// segment up to the offset. base is the path of the table in which the key is defined, or nil for table headers.
+func keyContext(src string, key *SyntaxNode, base []string, offset int) ([]string, string) {
+	path := clonePath(base)
+	for _, segment := range key.children {
+		if segment.kind != KeySegmentNode {
+			continue
+		}
+		if offset <= segment.end {
+			if offset < segment.start {
+				return path, ""
+			}
+			return path, src[segment.start:offset]
+		}
+		path = segment.Path()
+	}
+	return path, ""
+}
+
+// segmentAt returns the key segment at the offset.
+func segmentAt(node *SyntaxNode, offset int) *SyntaxNode {
+	if node.kind == KeySegmentNode {
+		if offset >= node.start && offset <= node.end {
+			return node
+		}
+		return nil
+	}
+	for _, child := range node.children {
+		if offset < child.start || offset > child.end {
+			continue
+		}
+		if segment := segmentAt(child, offset); segment != nil {
+			return segment
+		}
+	}
+	return nil
+}
+
+// definedKeys returns the joined paths of the keys and tables defined in a document, other than the segments of the
+// key being completed.
+func definedKeys(root *SyntaxNode, current *SyntaxNode) map[string]bool {
+	defined := make(map[string]bool)
+	var visit func(node *SyntaxNode)
+	visit = func(node *SyntaxNode) {
+		if node == current {
+			return
+		}
+		if node.kind == KeySegmentNode {
+			defined[joinKeyPath(node.path)] = true
+		}
+		for _, child := range node.children {
+			visit(child)
+		}
+	}
+	visit(root)
+	return defined
+}
+
+// positionOffset returns the byte offset of a position, or false if the position is not in the document. Columns
+// past the end of a line are moved to its end.
+func positionOffset(src string, position Position) (int, bool) {
+	lines := newLineIndex(src)
+	if position.Line < 1 || position.Line > len(lines) || position.Column < 1 {
+		return 0, false
+	}
+	offset := lines[position.Line-1]
+	for column := 1; column < position.Column && offset < len(src) && src[offset] != '\n'; column++ {
+		_, size := utf8.DecodeRuneInString(src[offset:])
+		offset += size
+	}
+	return offset, true
+}
+
+// maxSchemaDepth bounds the references and combinations followed when resolving a schema, so that recursive schemas
+// terminate.
+const maxSchemaDepth = 32
+
+// schemaAt returns the schemas that apply to the value at the path, with references and combinations resolved.
+func (s *schemaImpl) schemaAt(path []string) []map[string]any {
+	nodes := s.resolve(s.document, 0)
+	for _, segment := range path {
+		var children []map[string]any
+		for _, node := range nodes {
+			for _, child := range schemaChildren(node, segment) {
+				children = append(children, s.resolve(child, 0)...)
+			}
+		}
+		nodes = children
+	}
+	return nodes
+}
+
+// resolve returns the schema with its $ref and the alternatives of allOf, anyOf and oneOf.
+func (s *schemaImpl) resolve(schema any, depth int) []map[string]any {
+	node, ok := schema.(map[string]any)
+	if !ok || depth > maxSchemaDepth {
+		return nil
+	}
+	nodes := []map[string]any{node}
+	if ref, ok := node["$ref"].(string); ok {
+		if target, ok := s.pointer(ref); ok {
+			nodes = append(nodes, s.resolve(target, depth+1)...)
+		}
+	}
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		alternatives, _ := node[keyword].([]any)
+		for _, alternative := range alternatives {
+			nodes = append(nodes, s.resolve(alternative, depth+1)...)
+		}
+	}
+	return nodes
+}
+
+// pointer resolves a reference to a location of the schema document, such as #/definitions/package.
+func (s *schemaImpl) pointer(ref string) (any, bool) {
+	fragment, ok := strings.CutPrefix(ref, "#")
+	if !ok {
+		return nil, false
+	}
+	current := s.document
+	for _, token := range strings.Split(strings.TrimPrefix(fragment, "/"), "/") {
+		if token == "" {
+			continue
+		}
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		node, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = node[token]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// schemaChildren returns the schemas of the property or array element named by a path segment.
+func schemaChildren(node map[string]any, segment string) []any {
+	if _, err := strconv.Atoi(segment); err == nil {
+		if items, ok := node["items"].(map[string]any); ok {
+			return []any{items}
+		}
+	}
+	if properties, ok := node["properties"].(map[string]any); ok {
+		if property, ok := properties[segment]; ok {
+			return []any{property}
+		}
+	}
+	var children []any
+	if patterns, ok := node["patternProperties"].(map[string]any); ok {
+		for pattern, property := range patterns {
+			if re, err := regexp.Compile(pattern); err == nil && re.MatchString(segment) {
+				children = append(children, property)
+			}
+		}
+	}
+	if len(children) == 0 {
+		if additional, ok := node["additionalProperties"].(map[string]any); ok {
+			children = append(children, additional)
+		}
+	}
+	return children
+}
+
+type schemaProperty struct {
+	name        string
+	typ         string
+	description string
+	required    bool
+}
+
+// properties returns the named properties of the schemas, sorted by name.
+func (s *schemaImpl) properties(nodes []map[string]any) []schemaProperty {
+	required := make(map[string]bool)
+	names := make(map[string]bool)
+	for _, node := range nodes {
+		list, _ := node["required"].([]any)
+		for _, name := range list {
+			if name, ok := name.(string); ok {
+				required[name] = true
+			}
+		}
+		properties, _ := node["properties"].(map[string]any)
+		for name := range properties {
+			names[name] = true
+		}
+	}
+
+	result := make([]schemaProperty, 0, len(names))
+	for _, name := range sortedKeys(names) {
+		var property []map[string]any
+		for _, node := range nodes {
+			if properties, ok := node["properties"].(map[string]any); ok {
+				property = append(property, s.resolve(properties[name], 0)...)
+			}
+		}
+		result = append(result, schemaProperty{
+			name:        name,
+			typ:         schemaType(property),
+			description: schemaDescription(property),
+			required:    required[name],
+		})
+	}
+	return result
+}
+
+// values returns the values allowed by the schemas: their enum and const values, or true and false for booleans.
+func (s *schemaImpl) values(nodes []map[string]any) []CompletionItem {
+	var items []CompletionItem
+	seen := make(map[string]bool)
+	add := func(value any, description string) {
+		if f, ok := value.(float64); ok && f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+			value = int64(f)
+		}
+		label, err := formatValue(value)
+		if err != nil || seen[label] {
+			return
+		}
+		seen[label] = true
+		items = append(items, CompletionItem{Label: label, Type: tomlValueType(value), Description: description})
+	}
+	for _, node := range nodes {
+		description, _ := node["description"].(string)
+		if value, ok := node["const"]; ok {
+			add(value, description)
+		}
+		enum, _ := node["enum"].([]any)
+		for _, value := range enum {
+			add(value, description)
+		}
+	}
+	if len(items) == 0 && schemaType(nodes) == "boolean" {
+		add(true, schemaDescription(nodes))
+		add(false, schemaDescription(nodes))
+	}
+	return items
+}
+
+// schemaType returns the TOML type allowed by the schemas, or an empty string if the type is not restricted.
+func schemaType(nodes []map[string]any) string {
+	var types []string
+	for _, node := range nodes {
+		switch t := node["type"].(type) {
+		case string:
+			types = append(types, t)
+		case []any:
+			for _, item := range t {
+				if name, ok := item.(string); ok {
+					types = append(types, name)
+				}
+			}
+		}
+	}
+	return joinTypes(types)
+}
+
+func schemaDescription(nodes []map[string]any) string {
+	for _, node := range nodes {
+		if description, ok := node["description"].(string); ok {
+			return description
+		}
+	}
+	return ""
+}
+
+// isTableType reports whether a property of the given type can be defined by a [table] header, or by a [[table]]
+// header if arrayTable is set.
+func isTableType(typ string, arrayTable bool) bool {
+	if typ == "" {
+		return true
+	}
+	if arrayTable {
+		return strings.Contains(typ, "array")
+	}
+	return strings.Contains(typ, "table")
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tomlparser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/txtar"
+)
+
+// cursorMarker marks the position of the cursor in the input of the completion golden files.
+const cursorMarker = "<cursor>"
+
+func TestCompletions(t *testing.T) {
+	bless := os.Getenv("BLESS") == "1" || os.Getenv("BLESS") == "true"
+
+	schema, err := NewSchemaFromPath(fsys, "testdata/completion/schema.json")
+	if err != nil {
+		t.Fatalf("failed to load schema: %v", err)
+	}
+	files, err := filepath.Glob(filepath.Join("testdata", "completion", "*.txtar"))
+	if err != nil {
+		t.Fatalf("failed to list golden files: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no golden files found in testdata/completion")
+	}
+
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".txtar")
+		t.Run(name, func(t *testing.T) {
+			archive, err := txtar.ParseFile(file)
+			if err != nil {
+				t.Fatalf("failed to parse %s: %v", file, err)
+			}
+			sections := make(map[string]string)
+			for _, f := range archive.Files {
+				sections[f.Name] = string(f.Data)
+			}
+
+			input := sections["input.toml"]
+			offset := strings.Index(input, cursorMarker)
+			if offset < 0 {
+				t.Fatalf("input has no %s marker", cursorMarker)
+			}
+			before := input[:offset]
+			position := Position{
+				Line:   strings.Count(before, "\n") + 1,
+				Column: len([]rune(before[strings.LastIndexByte(before, '\n')+1:])) + 1,
+			}
+			doc, _ := ReadString(strings.Replace(input, cursorMarker, "", 1))
+
+			actual := map[string]string{
+				"completion": formatCompletion(schema.Completions(doc, position)),
+				"hover":      formatHover(schema.Hover(doc, position)),
+			}
+
+			if bless {
+				for i := range archive.Files {
+					if data, ok := actual[archive.Files[i].Name]; ok {
+						archive.Files[i].Data = []byte(data)
+					}
+				}
+				if err := os.WriteFile(file, txtar.Format(archive), 0o644); err != nil {
+					t.Fatalf("failed to update %s: %v", file, err)
+				}
+				return
+			}
+
+			for _, section := range []string{"completion", "hover"} {
+				if expected := sections[section]; actual[section] != expected {
+					t.Errorf("%s mismatch:\nwant:\n%s\ngot:\n%s", section, expected, actual[section])
+				}
+			}
+		})
+	}
+}
+
+func TestCompletionsOutsideDocument(t *testing.T) {
+	schema, err := NewSchemaFromPath(fsys, "testdata/completion/schema.json")
+	if err != nil {
+		t.Fatalf("failed to load schema: %v", err)
+	}
+	doc, _ := ReadString("[package]\n")
+	for _, position := range []Position{{Line: 0, Column: 1}, {Line: 3, Column: 1}, {Line: 1, Column: 0}} {
+		if completion := schema.Completions(doc, position); completion.Kind != NoCompletion || len(completion.Items) != 0 {
+			t.Errorf("expected no completion at %v, got %+v", position, completion)
+		}
+		if _, ok := schema.Hover(doc, position); ok {
+			t.Errorf("expected no hover at %v", position)
+		}
+	}
+}
+
+func formatCompletion(completion Completion) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %s prefix=%q\n", completion.Kind, formatPath(completion.Path), completion.Prefix)
+	for _, item := range completion.Items {
+		typ := item.Type
+		if typ == "" {
+			typ = "-"
+		}
+		fmt.Fprintf(&sb, "%s %s", item.Label, typ)
+		if item.Required {
+			sb.WriteString(" required")
+		}
+		if item.Description != "" {
+			fmt.Fprintf(&sb, " %q", item.Description)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func formatHover(hover Hover, ok bool) string {
+	if !ok {
+		return "-\n"
+	}
+	location := hover.Location
+	return fmt.Sprintf("%s %s %d:%d-%d:%d %q\n", formatPath(hover.Path), hover.Type,
+		location.StartLine, location.StartColumn, location.EndLine, location.EndColumn, hover.Description)
+}
+
+func formatPath(path []string) string {
+	if len(path) == 0 {
+		return "<root>"
+	}
+	return strings.Join(path, "/")
+}
@@ -0,0 +1,307 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tomlparser
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const conformanceDir = "testdata/toml-test"
+
+// knownAccepted lists the invalid documents of the toml-test corpus that ReadString accepts. They all define a table
+// more than once, through dotted keys, inline tables or arrays of tables, which only strict mode rejects. If ReadString
+// starts rejecting one of them, it should be removed from this list.
+var knownAccepted = map[string]bool{
+	"invalid/array/extend-defined-aot":               true,
+	"invalid/inline-table/duplicate-key-3":           true,
+	"invalid/inline-table/overwrite-02":              true,
+	"invalid/inline-table/overwrite-08":              true,
+	"invalid/spec/inline-table-2-0":                  true,
+	"invalid/spec/table-9-1":                         true,
+	"invalid/table/append-to-array-with-dotted-keys": true,
+	"invalid/table/append-with-dotted-keys-1":        true,
+	"invalid/table/append-with-dotted-keys-2":        true,
+	"invalid/table/duplicate-key-dotted-table":       true,
+	"invalid/table/duplicate-key-dotted-table2":      true,
+	"invalid/table/redefine-2":                       true,
+	"invalid/table/redefine-3":                       true,
+}
+
+func TestConformanceValid(t *testing.T) {
+	for _, name := range conformanceTests(t, "valid") {
+		t.Run(name, func(t *testing.T) {
+			content, err := os.ReadFile(path.Join(conformanceDir, name+".toml"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected, err := os.ReadFile(path.Join(conformanceDir, name+".json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var want any
+			if err := json.Unmarshal(expected, &want); err != nil {
+				t.Fatalf("failed to read expected output: %v", err)
+			}
+
+			toml, err := ReadStringWithOptions(string(content), ReadOptions{Strict: true})
+			if err != nil {
+				t.Fatalf("failed to read valid document: %v", err)
+			}
+			if err := compareTagged("", want, tagValue(toml.ToMap())); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestConformanceInvalid(t *testing.T) {
+	for _, name := range conformanceTests(t, "invalid") {
+		t.Run(name, func(t *testing.T) {
+			content, err := os.ReadFile(path.Join(conformanceDir, name+".toml"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = ReadString(string(content))
+			if knownAccepted[name] {
+				if err != nil {
+					t.Fatalf("known accepted document is now rejected, remove it from knownAccepted: %v", err)
+				}
+			} else if err == nil {
+				t.Fatal("expected invalid document to be rejected")
+			}
+
+			toml, err := ReadStringWithOptions(string(content), ReadOptions{Strict: true})
+			if err == nil {
+				t.Fatal("expected invalid document to be rejected in strict mode")
+			}
+			if len(toml.Diagnostics()) == 0 {
+				t.Fatal("expected at least one diagnostic")
+			}
+			for _, diagnostic := range toml.Diagnostics() {
+				if diagnostic.Location == nil {
+					t.Errorf("diagnostic %q has no location", diagnostic.Message)
+				}
+			}
+		})
+	}
+}
+
+// conformanceTests returns the names of the documents in the given directory of the corpus, without extension.
+func conformanceTests(t *testing.T, dir string) []string {
+	t.Helper()
+	var names []string
+	err := fs.WalkDir(os.DirFS(conformanceDir), dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(name, ".toml") {
+			names = append(names, strings.TrimSuffix(name, ".toml"))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) == 0 {
+		t.Fatalf("no documents found in %s", path.Join(conformanceDir, dir))
+	}
+	return names
+}
+
+// tagValue converts a decoded document to the JSON encoding of the toml-test corpus, where every value is a table
+// holding its type and its value as a string.
+func tagValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		tagged := make(map[string]any, len(v))
+		for key, element := range v {
+			tagged[key] = tagValue(element)
+		}
+		return tagged
+	case []map[string]any:
+		tagged := make([]any, len(v))
+		for i, element := range v {
+			tagged[i] = tagValue(element)
+		}
+		return tagged
+	case []any:
+		tagged := make([]any, len(v))
+		for i, element := range v {
+			tagged[i] = tagValue(element)
+		}
+		return tagged
+	case string:
+		return tag("string", v)
+	case int64:
+		return tag("integer", strconv.FormatInt(v, 10))
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			return tag("float", "nan")
+		case math.IsInf(v, 1):
+			return tag("float", "inf")
+		case math.IsInf(v, -1):
+			return tag("float", "-inf")
+		}
+		return tag("float", strconv.FormatFloat(v, 'f', -1, 64))
+	case bool:
+		return tag("bool", strconv.FormatBool(v))
+	case time.Time:
+		// Local dates and times are decoded in fixed zones named after their TOML type.
+		switch kind := v.Location().String(); kind {
+		case "datetime-local", "date-local", "time-local":
+			return tag(kind, v.Format(datetimeLayouts[kind]))
+		}
+		return tag("datetime", v.Format(datetimeLayouts["datetime"]))
+	default:
+		panic(fmt.Sprintf("unexpected decoded value of type %T", value))
+	}
+}
+
+func tag(typ, value string) map[string]any {
+	return map[string]any{"type": typ, "value": value}
+}
+
+var datetimeLayouts = map[string]string{
+	"datetime":       time.RFC3339Nano,
+	"datetime-local": "2006-01-02T15:04:05.999999999",
+	"date-local":     "2006-01-02",
+	"time-local":     "15:04:05.999999999",
+}
+
+// compareTagged compares two documents in the JSON encoding of the toml-test corpus.
+func compareTagged(key string, want, have any) error {
+	switch w := want.(type) {
+	case []any:
+		h, ok := have.([]any)
+		if !ok {
+			return fmt.Errorf("key '%s': expected an array, found %v", key, have)
+		}
+		if len(w) != len(h) {
+			return fmt.Errorf("key '%s': expected %d elements, found %d", key, len(w), len(h))
+		}
+		for i := range w {
+			if err := compareTagged(fmt.Sprintf("%s[%d]", key, i), w[i], h[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case map[string]any:
+		h, ok := have.(map[string]any)
+		if !ok {
+			return fmt.Errorf("key '%s': expected a table, found %v", key, have)
+		}
+		if isTagged(w) {
+			if !isTagged(h) {
+				return fmt.Errorf("key '%s': expected a value, found a table", key)
+			}
+			return compareValues(key, w, h)
+		}
+		if isTagged(h) {
+			return fmt.Errorf("key '%s': expected a table, found a value", key)
+		}
+		keys := make([]string, 0, len(w))
+		for k := range w {
+			keys = append(keys, k)
+		}
+		for k := range h {
+			if _, ok := w[k]; !ok {
+				return fmt.Errorf("key '%s': unexpected key '%s'", key, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			child := k
+			if key != "" {
+				child = key + "." + k
+			}
+			if _, ok := h[k]; !ok {
+				return fmt.Errorf("key '%s': missing key", child)
+			}
+			if err := compareTagged(child, w[k], h[k]); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("key '%s': unexpected expected value %v", key, want)
+	}
+}
+
+func isTagged(table map[string]any) bool {
+	if len(table) != 2 {
+		return false
+	}
+	_, hasType := table["type"].(string)
+	_, hasValue := table["value"]
+	return hasType && hasValue
+}
+
+func compareValues(key string, want, have map[string]any) error {
+	wantType, haveType := want["type"].(string), have["type"].(string)
+	wantValue, _ := want["value"].(string)
+	haveValue, _ := have["value"].(string)
+	if wantType != haveType {
+		return fmt.Errorf("key '%s': expected %s, found %s", key, wantType, haveType)
+	}
+	switch wantType {
+	case "float":
+		if strings.HasSuffix(wantValue, "nan") {
+			if haveValue != "nan" {
+				return fmt.Errorf("key '%s': expected nan, found %s", key, haveValue)
+			}
+			return nil
+		}
+		w, err := strconv.ParseFloat(wantValue, 64)
+		if err != nil {
+			return fmt.Errorf("key '%s': invalid expected float %s", key, wantValue)
+		}
+		h, _ := strconv.ParseFloat(haveValue, 64)
+		if w != h {
+			return fmt.Errorf("key '%s': expected %v, found %v", key, w, h)
+		}
+		return nil
+	case "datetime", "datetime-local", "date-local", "time-local":
+		layout := datetimeLayouts[wantType]
+		normalized := strings.NewReplacer(" ", "T", "t", "T", "z", "Z").Replace(wantValue)
+		w, err := time.Parse(layout, normalized)
+		if err != nil {
+			return fmt.Errorf("key '%s': invalid expected %s %s", key, wantType, wantValue)
+		}
+		h, err := time.Parse(layout, haveValue)
+		if err != nil || !w.Equal(h) {
+			return fmt.Errorf("key '%s': expected %s, found %s", key, wantValue, haveValue)
+		}
+		return nil
+	default:
+		if wantValue != haveValue {
+			return fmt.Errorf("key '%s': expected %q, found %q", key, wantValue, haveValue)
+		}
+		return nil
+	}
+}
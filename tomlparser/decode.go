@@ -0,0 +1,371 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tomlparser
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"ballerina-lang-go/tools/diagnostics"
+
+	"github.com/BurntSushi/toml"
+)
+
+// defaultTag is the struct tag holding the value of a field whose key is not defined, written as it would be in a
+// TOML string, such as `default:"30s"` for a Duration.
+const defaultTag = "default"
+
+// requiredOption marks a field whose key must be defined, as in `toml:"org,required"`.
+const requiredOption = "required"
+
+// DecodeInto decodes the document into a value of type T, typically a struct. Fields are matched to keys by their
+// `toml` tag, or by their name ignoring case. Fields implementing toml.Unmarshaler or encoding.TextUnmarshaler, such
+// as Version and Duration, decode themselves.
+//
+// Decoding does not stop at the first failure: every key that cannot be decoded, and every missing required key, is
+// reported as a diagnostic located at the key, in the order of the document, and the fields that could be decoded are
+// set.
+func DecodeInto[T any](t *Toml) (T, []Diagnostic) {
+	var result T
+	d := &decoder{toml: t}
+	d.decode(nil, t.rootNode, reflect.ValueOf(&result).Elem())
+	sortDiagnostics(d.diagnostics)
+	return result, d.diagnostics
+}
+
+// To decodes the document into target, which must be a non-nil pointer, like DecodeInto. Keys that are not defined
+// leave the existing values of their fields unchanged, unless the fields have a default. Decode failures are added to
+// the diagnostics of the document.
+func (t *Toml) To(target any) {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		t.diagnostics = append(t.diagnostics, Diagnostic{
+			Message:  fmt.Sprintf("cannot decode into %T: expected a non-nil pointer", target),
+			Severity: diagnostics.Error,
+		})
+		return
+	}
+	d := &decoder{toml: t}
+	d.decode(nil, t.rootNode, value.Elem())
+	sortDiagnostics(d.diagnostics)
+	t.diagnostics = append(t.diagnostics, d.diagnostics...)
+}
+
+type decoder struct {
+	toml        *Toml
+	diagnostics []Diagnostic
+}
+
+var (
+	unmarshalerType     = reflect.TypeFor[toml.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	timeType            = reflect.TypeFor[time.Time]()
+)
+
+// decode decodes the value at the path into target.
+func (d *decoder) decode(path []string, value any, target reflect.Value) {
+	if target.CanAddr() {
+		if unmarshaler, ok := target.Addr().Interface().(toml.Unmarshaler); ok {
+			if err := unmarshaler.UnmarshalTOML(value); err != nil {
+				d.invalidValue(path, err)
+			}
+			return
+		}
+	}
+
+	switch {
+	case target.Type() == timeType:
+		t, ok := value.(time.Time)
+		if !ok {
+			d.typeMismatch(path, "datetime", value)
+			return
+		}
+		target.Set(reflect.ValueOf(t))
+		return
+	case target.CanAddr() && reflect.PointerTo(target.Type()).Implements(textUnmarshalerType):
+		s, ok := value.(string)
+		if !ok {
+			d.typeMismatch(path, "string", value)
+			return
+		}
+		if err := target.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+			d.invalidValue(path, err)
+		}
+		return
+	}
+
+	switch target.Kind() {
+	case reflect.Pointer:
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		d.decode(path, value, target.Elem())
+	case reflect.Interface:
+		if target.NumMethod() != 0 {
+			d.typeMismatch(path, target.Type().String(), value)
+			return
+		}
+		target.Set(reflect.ValueOf(value))
+	case reflect.Struct:
+		table, ok := value.(map[string]any)
+		if !ok {
+			d.typeMismatch(path, "table", value)
+			return
+		}
+		d.decodeStruct(path, table, target, true)
+	case reflect.Map:
+		table, ok := value.(map[string]any)
+		if !ok {
+			d.typeMismatch(path, "table", value)
+			return
+		}
+		if target.Type().Key().Kind() != reflect.String {
+			d.typeMismatch(path, target.Type().String(), value)
+			return
+		}
+		if target.IsNil() {
+			target.Set(reflect.MakeMapWithSize(target.Type(), len(table)))
+		}
+		for _, key := range sortedKeys(table) {
+			element := reflect.New(target.Type().Elem()).Elem()
+			d.decode(append(clonePath(path), key), table[key], element)
+			target.SetMapIndex(reflect.ValueOf(key).Convert(target.Type().Key()), element)
+		}
+	case reflect.Slice, reflect.Array:
+		elements, ok := arrayElements(value)
+		if !ok {
+			d.typeMismatch(path, "array", value)
+			return
+		}
+		if target.Kind() == reflect.Array && len(elements) != target.Len() {
+			d.addAt(path, fmt.Sprintf("invalid value for %s: expected %d elements, found %d",
+				describePath(d.toml.childPath(path...)), target.Len(), len(elements)))
+			return
+		}
+		if target.Kind() == reflect.Slice {
+			target.Set(reflect.MakeSlice(target.Type(), len(elements), len(elements)))
+		}
+		for i, element := range elements {
+			d.decode(append(clonePath(path), strconv.Itoa(i)), element, target.Index(i))
+		}
+	case reflect.String:
+		s, ok := value.(string)
+		if !ok {
+			d.typeMismatch(path, "string", value)
+			return
+		}
+		target.SetString(s)
+	case reflect.Bool:
+		b, ok := value.(bool)
+		if !ok {
+			d.typeMismatch(path, "boolean", value)
+			return
+		}
+		target.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := value.(int64)
+		if !ok {
+			d.typeMismatch(path, "integer", value)
+			return
+		}
+		if target.OverflowInt(i) {
+			d.outOfRange(path, value, target)
+			return
+		}
+		target.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		i, ok := value.(int64)
+		if !ok {
+			d.typeMismatch(path, "integer", value)
+			return
+		}
+		if i < 0 || target.OverflowUint(uint64(i)) {
+			d.outOfRange(path, value, target)
+			return
+		}
+		target.SetUint(uint64(i))
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		switch v := value.(type) {
+		case float64:
+			f = v
+		case int64:
+			f = float64(v)
+		default:
+			d.typeMismatch(path, "float", value)
+			return
+		}
+		if target.OverflowFloat(f) && !math.IsInf(f, 0) {
+			d.outOfRange(path, value, target)
+			return
+		}
+		target.SetFloat(f)
+	default:
+		d.typeMismatch(path, target.Type().String(), value)
+	}
+}
+
+// decodeStruct decodes a table into the fields of a struct. If the table is not defined, only the defaults of the
+// fields are set: a table that is not defined has no required keys.
+func (d *decoder) decodeStruct(path []string, table map[string]any, target reflect.Value, defined bool) {
+	for i := 0; i < target.NumField(); i++ {
+		field := target.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(field.Tag.Get("toml"), ",")
+		if name == "-" {
+			continue
+		}
+		fieldValue := target.Field(i)
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct && !decodesItself(field.Type) {
+			d.decodeStruct(path, table, fieldValue, defined)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		key, ok := lookupKey(table, name)
+		keyPath := append(clonePath(path), key)
+		switch {
+		case ok:
+			d.decode(keyPath, table[key], fieldValue)
+		case field.Tag.Get(defaultTag) != "":
+			d.decodeDefault(keyPath, field, fieldValue)
+		case defined && hasOption(options, requiredOption):
+			d.addAt(keyPath, fmt.Sprintf("missing required key '%s'", name))
+		case fieldValue.Kind() == reflect.Struct && !decodesItself(fieldValue.Type()):
+			d.decodeStruct(keyPath, nil, fieldValue, false)
+		}
+	}
+}
+
+// decodeDefault sets a field to the value of its default tag, which is decoded like a TOML string, or like a number
+// or boolean for fields of those kinds.
+func (d *decoder) decodeDefault(path []string, field reflect.StructField, target reflect.Value) {
+	literal := field.Tag.Get(defaultTag)
+	var value any = literal
+	kind := field.Type.Kind()
+	if kind == reflect.Pointer {
+		kind = field.Type.Elem().Kind()
+	}
+	if !decodesItself(field.Type) {
+		var err error
+		switch kind {
+		case reflect.Bool:
+			value, err = strconv.ParseBool(literal)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			value, err = strconv.ParseInt(literal, 10, 64)
+		case reflect.Float32, reflect.Float64:
+			value, err = strconv.ParseFloat(literal, 64)
+		}
+		if err != nil {
+			d.diagnostics = append(d.diagnostics, Diagnostic{
+				Message:  fmt.Sprintf("invalid default value '%s' of field %s: %v", literal, field.Name, err),
+				Severity: diagnostics.Error,
+			})
+			return
+		}
+	}
+	d.decode(path, value, target)
+}
+
+// decodesItself reports whether values of the type are decoded by their own unmarshaler, or as a datetime.
+func decodesItself(t reflect.Type) bool {
+	pointer := reflect.PointerTo(t)
+	return t == timeType || pointer.Implements(unmarshalerType) || pointer.Implements(textUnmarshalerType)
+}
+
+// lookupKey returns the key of the table that a field named name decodes, preferring an exact match.
+func lookupKey(table map[string]any, name string) (string, bool) {
+	if _, ok := table[name]; ok {
+		return name, true
+	}
+	for _, key := range sortedKeys(table) {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+	return name, false
+}
+
+func hasOption(options, option string) bool {
+	for _, o := range strings.Split(options, ",") {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func arrayElements(value any) ([]any, bool) {
+	switch v := value.(type) {
+	case []any:
+		return v, true
+	case []map[string]any:
+		elements := make([]any, len(v))
+		for i, table := range v {
+			elements[i] = table
+		}
+		return elements, true
+	default:
+		return nil, false
+	}
+}
+
+func (d *decoder) typeMismatch(path []string, expected string, value any) {
+	d.addAt(path, fmt.Sprintf("invalid type for %s: expected %s, found %s",
+		describePath(d.toml.childPath(path...)), expected, tomlValueType(value)))
+}
+
+func (d *decoder) outOfRange(path []string, value any, target reflect.Value) {
+	d.addAt(path, fmt.Sprintf("invalid value for %s: %v does not fit in %s",
+		describePath(d.toml.childPath(path...)), value, target.Type()))
+}
+
+func (d *decoder) invalidValue(path []string, err error) {
+	d.addAt(path, fmt.Sprintf("invalid value for %s: %v", describePath(d.toml.childPath(path...)), err))
+}
+
+// addAt reports a diagnostic located at the key of the path or, if it is not defined, at its closest defined parent.
+func (d *decoder) addAt(path []string, msg string) {
+	diagnostic := Diagnostic{Message: msg, Severity: diagnostics.Error}
+	for i := len(path); i > 0; i-- {
+		if location, ok := d.toml.KeyLocation(path[:i]...); ok {
+			diagnostic.Location = location
+			break
+		}
+	}
+	d.diagnostics = append(d.diagnostics, diagnostic)
+}
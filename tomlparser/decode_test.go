@@ -0,0 +1,234 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tomlparser
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type decodeConfig struct {
+	Package struct {
+		Org     string  `toml:"org,required"`
+		Name    string  `toml:"name,required"`
+		Version Version `toml:"version,required"`
+	} `toml:"package"`
+	Server struct {
+		Host    string   `toml:"host" default:"localhost"`
+		Port    uint16   `toml:"port" default:"9090"`
+		Timeout Duration `toml:"timeout" default:"30s"`
+		Retries *int     `toml:"retries" default:"3"`
+	} `toml:"server"`
+	Routes []struct {
+		Path    string   `toml:"path,required"`
+		Methods []string `toml:"methods"`
+	} `toml:"route"`
+	Labels  map[string]string `toml:"labels"`
+	Ratio   float64           `toml:"ratio"`
+	Created time.Time         `toml:"created"`
+	Extra   any               `toml:"extra"`
+	Ignored string            `toml:"-"`
+}
+
+func TestDecodeInto(t *testing.T) {
+	doc, err := ReadString(`
+created = 2025-01-02T03:04:05Z
+ratio = 2
+extra = [1, "two"]
+Ignored = "not decoded"
+
+[package]
+org = "wso2"
+name = "winery"
+version = "1.2.3-beta.1"
+
+[server]
+timeout = "1m30s"
+
+[[route]]
+path = "/a"
+methods = ["GET", "POST"]
+
+[[route]]
+path = "/b"
+
+[labels]
+team = "core"
+`)
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	config, diags := DecodeInto[decodeConfig](doc)
+	if len(diags) != 0 {
+		t.Fatalf("unexpected diagnostics:\n%s", formatDecodeDiagnostics(diags))
+	}
+	if config.Package.Org != "wso2" || config.Package.Name != "winery" {
+		t.Errorf("unexpected package: %+v", config.Package)
+	}
+	if got := config.Package.Version.String(); got != "1.2.3-beta.1" || config.Package.Version.Minor() != 2 {
+		t.Errorf("unexpected version: %s", got)
+	}
+	if config.Server.Host != "localhost" || config.Server.Port != 9090 || config.Server.Retries == nil || *config.Server.Retries != 3 {
+		t.Errorf("expected defaults, got %+v", config.Server)
+	}
+	if config.Server.Timeout.Duration != 90*time.Second {
+		t.Errorf("expected timeout of 1m30s, got %v", config.Server.Timeout)
+	}
+	if len(config.Routes) != 2 || config.Routes[0].Path != "/a" || len(config.Routes[0].Methods) != 2 || config.Routes[1].Path != "/b" {
+		t.Errorf("unexpected routes: %+v", config.Routes)
+	}
+	if config.Labels["team"] != "core" || config.Ratio != 2 || config.Created.Year() != 2025 {
+		t.Errorf("unexpected values: %+v", config)
+	}
+	if extra, ok := config.Extra.([]any); !ok || len(extra) != 2 {
+		t.Errorf("unexpected extra value: %#v", config.Extra)
+	}
+	if config.Ignored != "" {
+		t.Errorf("expected ignored field to be skipped, got %q", config.Ignored)
+	}
+}
+
+func TestDecodeIntoDiagnostics(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:  "missing required keys",
+			input: "[package]\norg = \"wso2\"\n\n[[route]]\nmethods = []\n",
+			expected: `ERROR 1:2-1:9 missing required key 'name'
+ERROR 1:2-1:9 missing required key 'version'
+ERROR 4:3-4:8 missing required key 'path'
+`,
+		},
+		{
+			name:     "missing root table",
+			input:    "ratio = 1.5\n",
+			expected: "",
+		},
+		{
+			name:  "type mismatches",
+			input: "ratio = \"high\"\ncreated = 1\nlabels = [\"a\"]\n\n[server]\nhost = 8080\nport = \"9090\"\n",
+			expected: `ERROR 1:1-1:6 invalid type for key 'ratio': expected float, found string
+ERROR 2:1-2:8 invalid type for key 'created': expected datetime, found integer
+ERROR 3:1-3:7 invalid type for key 'labels': expected table, found array
+ERROR 6:1-6:5 invalid type for key 'server.host': expected string, found integer
+ERROR 7:1-7:5 invalid type for key 'server.port': expected integer, found string
+`,
+		},
+		{
+			name:  "array elements",
+			input: "[[route]]\npath = \"/a\"\nmethods = [\"GET\", 1]\n\n[[route]]\npath = 2\n",
+			expected: `ERROR 3:19-3:20 invalid type for element 'route[0].methods[1]': expected string, found integer
+ERROR 6:1-6:5 invalid type for key 'route[1].path': expected string, found integer
+`,
+		},
+		{
+			name:  "out of range",
+			input: "[server]\nport = 70000\nretries = 9223372036854775807\n",
+			expected: `ERROR 2:1-2:5 invalid value for key 'server.port': 70000 does not fit in uint16
+`,
+		},
+		{
+			name:  "custom unmarshalers",
+			input: "[package]\norg = \"wso2\"\nname = \"winery\"\nversion = \"1.2\"\n\n[server]\ntimeout = \"soon\"\n",
+			expected: `ERROR 4:1-4:8 invalid value for key 'package.version': '1.2' is not a semantic version
+ERROR 7:1-7:8 invalid value for key 'server.timeout': 'soon' is not a duration
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc, err := ReadString(test.input)
+			if err != nil {
+				t.Fatalf("failed to parse: %v", err)
+			}
+			_, diags := DecodeInto[decodeConfig](doc)
+			if got := formatDecodeDiagnostics(diags); got != test.expected {
+				t.Errorf("diagnostics mismatch:\nwant:\n%s\ngot:\n%s", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestDecodeIntoTable(t *testing.T) {
+	doc, err := ReadString("[server]\nhost = \"example.com\"\nport = true\n")
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	server, ok := doc.GetTable("server")
+	if !ok {
+		t.Fatal("expected server table")
+	}
+
+	type serverConfig struct {
+		Host string `toml:"host"`
+		Port int    `toml:"port"`
+	}
+	config, diags := DecodeInto[serverConfig](server)
+	if config.Host != "example.com" {
+		t.Errorf("expected host to be decoded, got %q", config.Host)
+	}
+	expected := "ERROR 3:1-3:5 invalid type for key 'server.port': expected integer, found boolean\n"
+	if got := formatDecodeDiagnostics(diags); got != expected {
+		t.Errorf("diagnostics mismatch:\nwant:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestDurationUnmarshal(t *testing.T) {
+	doc, err := ReadString("seconds = 45\ntext = \"250ms\"\ninvalid = true\n")
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	durations, diags := DecodeInto[map[string]Duration](doc)
+	if durations["seconds"].Duration != 45*time.Second || durations["text"].Duration != 250*time.Millisecond {
+		t.Errorf("unexpected durations: %v", durations)
+	}
+	expected := "ERROR 3:1-3:8 invalid value for key 'invalid': expected a duration string or a number of seconds, found boolean\n"
+	if got := formatDecodeDiagnostics(diags); got != expected {
+		t.Errorf("diagnostics mismatch:\nwant:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestToNonPointer(t *testing.T) {
+	doc, err := ReadString("a = 1\n")
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	var target struct{ A int }
+	doc.To(target)
+	if len(doc.Diagnostics()) != 1 {
+		t.Errorf("expected a diagnostic for a non-pointer target, got %v", doc.Diagnostics())
+	}
+}
+
+func formatDecodeDiagnostics(diags []Diagnostic) string {
+	var sb strings.Builder
+	for _, d := range diags {
+		location := "-"
+		if d.Location != nil {
+			location = fmt.Sprintf("%d:%d-%d:%d", d.Location.StartLine, d.Location.StartColumn, d.Location.EndLine, d.Location.EndColumn)
+		}
+		fmt.Fprintf(&sb, "%s %s %s\n", d.Severity, location, d.Message)
+	}
+	return sb.String()
+}
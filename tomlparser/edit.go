@@ -0,0 +1,566 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tomlparser
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"ballerina-lang-go/tools/text"
+
+	"github.com/BurntSushi/toml"
+)
+
+// SyntaxTree is a lossless syntax tree of a TOML document. Its edit operations change only the text they affect, so
+// comments, blank lines and the formatting of untouched values are preserved. Every edit is checked to produce valid
+// TOML and leaves the tree unchanged if it does not.
+//
+// Paths address keys in the same way as Toml.KeyLocation: one segment per key, with elements of arrays and arrays
+// of tables addressed by their decimal index.
+type SyntaxTree struct {
+	original string
+	source   string
+	root     *SyntaxNode
+	// nodes indexes tables, key-values and array elements by their joined path.
+	nodes map[string]*SyntaxNode
+	// arrayTables indexes the sections of every array of tables by the joined path of the array.
+	arrayTables map[string][]*SyntaxNode
+}
+
+// Entry is a key-value of a table created by SyntaxTree.AppendTable.
+type Entry struct {
+	Key   string
+	Value any
+}
+
+// textSpan replaces the source between start and end with text.
+type textSpan struct {
+	start int
+	end   int
+	text  string
+}
+
+func ParseSyntaxTree(content string) (*SyntaxTree, error) {
+	if err := checkSyntax(content); err != nil {
+		return nil, err
+	}
+	st := &SyntaxTree{original: content}
+	st.reset(content)
+	return st, nil
+}
+
+func checkSyntax(content string) error {
+	var data map[string]any
+	_, err := toml.Decode(content, &data)
+	return err
+}
+
+func (st *SyntaxTree) reset(source string) {
+	st.source = source
+	st.root = parseSyntax(source)
+	st.nodes = make(map[string]*SyntaxNode)
+	st.arrayTables = make(map[string][]*SyntaxNode)
+
+	var visit func(node *SyntaxNode)
+	visit = func(node *SyntaxNode) {
+		switch node.kind {
+		case TableNode:
+			st.nodes[joinKeyPath(node.path)] = node
+		case ArrayTableNode:
+			st.nodes[joinKeyPath(node.path)] = node
+			arrayKey := joinKeyPath(node.path[:len(node.path)-1])
+			st.arrayTables[arrayKey] = append(st.arrayTables[arrayKey], node)
+		case KeyValueNode:
+			st.nodes[joinKeyPath(node.path)] = node
+		case ArrayNode:
+			for _, element := range node.elements() {
+				st.nodes[joinKeyPath(element.path)] = element
+			}
+		}
+		for _, child := range node.children {
+			visit(child)
+		}
+	}
+	visit(st.root)
+}
+
+// Root returns the DocumentNode of the tree.
+func (st *SyntaxTree) Root() *SyntaxNode {
+	return st.root
+}
+
+// Text returns the source text of a node.
+func (st *SyntaxTree) Text(node *SyntaxNode) string {
+	return st.source[node.start:node.end]
+}
+
+// String returns the current text of the document.
+func (st *SyntaxTree) String() string {
+	return st.source
+}
+
+// Toml parses the current text of the document.
+func (st *SyntaxTree) Toml() (*Toml, error) {
+	return ReadString(st.source)
+}
+
+// Change returns the edit that turns the document the tree was parsed from into its current text.
+func (st *SyntaxTree) Change() text.TextDocumentChange {
+	if st.source == st.original {
+		return text.TextDocumentChangeFromTextEdits(nil)
+	}
+	prefix := 0
+	for prefix < len(st.original) && prefix < len(st.source) && st.original[prefix] == st.source[prefix] {
+		prefix++
+	}
+	for prefix > 0 && prefix < len(st.original) && !utf8.RuneStart(st.original[prefix]) {
+		prefix--
+	}
+	suffix := 0
+	for suffix < len(st.original)-prefix && suffix < len(st.source)-prefix &&
+		st.original[len(st.original)-1-suffix] == st.source[len(st.source)-1-suffix] {
+		suffix++
+	}
+	for suffix > 0 && !utf8.RuneStart(st.original[len(st.original)-suffix]) {
+		suffix--
+	}
+	textRange := text.TextRangeFromStartOffsetAndLength(prefix, len(st.original)-prefix-suffix)
+	edit := text.TextEditFromTextRangeAndText(textRange, st.source[prefix:len(st.source)-suffix])
+	return text.TextDocumentChangeFromTextEdits([]text.TextEdit{edit})
+}
+
+// Set sets the value of a key or an array element. A missing key is added after the last key-value of its table,
+// and a missing table is appended to the end of the document.
+func (st *SyntaxTree) Set(path []string, value any) error {
+	if len(path) == 0 {
+		return errors.New("cannot set the document")
+	}
+	formatted, err := formatValue(value)
+	if err != nil {
+		return err
+	}
+
+	if node, ok := st.nodes[joinKeyPath(path)]; ok {
+		switch node.kind {
+		case KeyValueNode:
+			return st.edit(textSpan{node.value.start, node.value.end, formatted})
+		case TableNode, ArrayTableNode:
+			return fmt.Errorf("cannot set table %s", describePath(path))
+		default:
+			return st.edit(textSpan{node.start, node.end, formatted})
+		}
+	}
+
+	parent, key := path[:len(path)-1], path[len(path)-1]
+	entry := formatKey(key) + " = " + formatted
+	node, ok := st.nodes[joinKeyPath(parent)]
+	if !ok {
+		for i := 1; i <= len(parent); i++ {
+			if _, isArray := st.arrayTables[joinKeyPath(parent[:i])]; isArray {
+				return fmt.Errorf("cannot create a table inside the array of tables %s", describePath(parent[:i]))
+			}
+		}
+		return st.edit(st.appendSection("["+formatKeyPath(parent)+"]\n"+entry+"\n", len(st.source)))
+	}
+	if node.kind == KeyValueNode {
+		node = node.value
+	}
+	switch node.kind {
+	case TableNode, ArrayTableNode:
+		return st.edit(st.insertKeyValue(node, entry))
+	case InlineTableNode:
+		keyValues := node.keyValues()
+		if len(keyValues) == 0 {
+			return st.edit(textSpan{node.start, node.end, "{ " + entry + " }"})
+		}
+		last := keyValues[len(keyValues)-1]
+		return st.edit(textSpan{last.end, last.end, ", " + entry})
+	default:
+		return fmt.Errorf("cannot add %s: %s is not a table", describePath(path), describePath(parent))
+	}
+}
+
+// Delete removes a key, table, array of tables or array element. Deleting a table also removes its sub-tables.
+func (st *SyntaxTree) Delete(path []string) error {
+	if len(path) == 0 {
+		return errors.New("cannot delete the document")
+	}
+	if node, ok := st.nodes[joinKeyPath(path)]; ok && node.parent != nil {
+		switch node.parent.kind {
+		case ArrayNode:
+			return st.edit(st.removeListItem(node.parent, node.parent.elements(), node))
+		case InlineTableNode:
+			return st.edit(st.removeListItem(node.parent, node.parent.keyValues(), node))
+		}
+	}
+
+	var spans []textSpan
+	for _, section := range st.root.children {
+		if section.kind == TextNode {
+			continue
+		}
+		if hasPathPrefix(section.path, path) {
+			spans = append(spans, textSpan{section.start, section.end, ""})
+			continue
+		}
+		for _, keyValue := range section.keyValues() {
+			if hasPathPrefix(keyValue.path, path) {
+				spans = append(spans, st.lineSpan(keyValue))
+			}
+		}
+	}
+	if len(spans) == 0 {
+		return fmt.Errorf("%s not found", describePath(path))
+	}
+	return st.edit(spans...)
+}
+
+// AppendTable appends an element to an array of tables, after its last existing element or at the end of the
+// document. The entries are written in the given order.
+func (st *SyntaxTree) AppendTable(path []string, entries ...Entry) error {
+	if len(path) == 0 {
+		return errors.New("cannot append a table to the document")
+	}
+	var sb strings.Builder
+	sb.WriteString("[[" + formatKeyPath(path) + "]]\n")
+	for _, entry := range entries {
+		formatted, err := formatValue(entry.Value)
+		if err != nil {
+			return err
+		}
+		sb.WriteString(formatKey(entry.Key) + " = " + formatted + "\n")
+	}
+
+	at := len(st.source)
+	if sections := st.arrayTables[joinKeyPath(path)]; len(sections) > 0 {
+		at = st.subTablesEnd(sections[len(sections)-1])
+	}
+	return st.edit(st.appendSection(sb.String(), at))
+}
+
+// InsertArrayItem inserts a value into an array at the given index. An index equal to the length of the array
+// appends the value. Multi-line arrays keep one element per line.
+func (st *SyntaxTree) InsertArrayItem(path []string, index int, value any) error {
+	node, ok := st.nodes[joinKeyPath(path)]
+	if !ok {
+		return fmt.Errorf("%s not found", describePath(path))
+	}
+	if node.kind == KeyValueNode {
+		node = node.value
+	}
+	if node.kind != ArrayNode {
+		return fmt.Errorf("%s is not an array", describePath(path))
+	}
+	formatted, err := formatValue(value)
+	if err != nil {
+		return err
+	}
+	elements := node.elements()
+	if index < 0 || index > len(elements) {
+		return fmt.Errorf("index %d is out of range for %s of length %d", index, describePath(path), len(elements))
+	}
+	if len(elements) == 0 {
+		return st.edit(textSpan{node.start + 1, node.end - 1, formatted})
+	}
+
+	multiLine := strings.Contains(st.Text(node), "\n")
+	if index < len(elements) {
+		element := elements[index]
+		if multiLine {
+			return st.edit(textSpan{element.start, element.start, formatted + ",\n" + st.indentation(element)})
+		}
+		return st.edit(textSpan{element.start, element.start, formatted + ", "})
+	}
+
+	last := elements[len(elements)-1]
+	separator := ", "
+	if multiLine {
+		separator = ",\n" + st.indentation(last)
+	}
+	trailing := st.source[last.end : node.end-1]
+	if comment := strings.IndexByte(trailing, '#'); comment >= 0 {
+		trailing = trailing[:comment]
+	}
+	if comma := strings.IndexByte(trailing, ','); comma >= 0 {
+		at := last.end + comma + 1
+		// Keep a comment that follows the trailing comma on the line of the element it describes.
+		if lineEnd := st.lineEnd(at); multiLine && lineEnd <= node.end-1 {
+			return st.edit(textSpan{lineEnd, lineEnd, st.indentation(last) + formatted + ",\n"})
+		}
+		return st.edit(textSpan{at, at, strings.TrimPrefix(separator, ",") + formatted + ","})
+	}
+	return st.edit(textSpan{last.end, last.end, separator + formatted})
+}
+
+// edit applies non-overlapping spans to the source and reparses it, provided the result is valid TOML.
+func (st *SyntaxTree) edit(spans ...textSpan) error {
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].start > spans[j].start
+	})
+	source := st.source
+	for _, span := range spans {
+		source = source[:span.start] + span.text + source[span.end:]
+	}
+	if err := checkSyntax(source); err != nil {
+		return fmt.Errorf("edit produces invalid TOML: %w", err)
+	}
+	st.reset(source)
+	return nil
+}
+
+// insertKeyValue adds a key-value line after the last key-value of a section, or after its header.
+func (st *SyntaxTree) insertKeyValue(section *SyntaxNode, entry string) textSpan {
+	at := section.start
+	if keyValues := section.keyValues(); len(keyValues) > 0 {
+		at = st.lineEnd(keyValues[len(keyValues)-1].end)
+	} else if section.key != nil {
+		at = st.lineEnd(section.key.end)
+	} else if at < len(st.source) {
+		// A key-value added to an empty root table is separated from the first header by a blank line.
+		return textSpan{at, at, entry + "\n\n"}
+	}
+	if at > 0 && st.source[at-1] != '\n' {
+		return textSpan{at, at, "\n" + entry + "\n"}
+	}
+	return textSpan{at, at, entry + "\n"}
+}
+
+// appendSection inserts a section at the start of a line, separated from the surrounding text by blank lines.
+func (st *SyntaxTree) appendSection(section string, at int) textSpan {
+	before := st.source[:at]
+	prefix := ""
+	if before != "" {
+		if !strings.HasSuffix(before, "\n") {
+			prefix = "\n"
+		}
+		if !strings.HasSuffix(before+prefix, "\n\n") {
+			prefix += "\n"
+		}
+	}
+	if at < len(st.source) {
+		section += "\n"
+	}
+	return textSpan{at, at, prefix + section}
+}
+
+// subTablesEnd returns the offset at which the last of the sections nested in a section ends.
+func (st *SyntaxTree) subTablesEnd(section *SyntaxNode) int {
+	end := section.end
+	for _, other := range st.root.children {
+		if other.start >= end && other.kind != TextNode && len(other.path) > len(section.path) &&
+			hasPathPrefix(other.path, section.path) {
+			end = other.end
+		}
+	}
+	return end
+}
+
+// lineSpan removes the lines of a key-value, including its trailing comment.
+func (st *SyntaxTree) lineSpan(node *SyntaxNode) textSpan {
+	start := strings.LastIndexByte(st.source[:node.start], '\n') + 1
+	if strings.TrimSpace(st.source[start:node.start]) != "" {
+		start = node.start
+	}
+	return textSpan{start, st.lineEnd(node.end), ""}
+}
+
+// removeListItem removes an element of an array or an entry of an inline table together with its separator.
+func (st *SyntaxTree) removeListItem(list *SyntaxNode, items []*SyntaxNode, item *SyntaxNode) textSpan {
+	if len(items) == 1 {
+		return textSpan{list.start + 1, list.end - 1, ""}
+	}
+	for i, other := range items {
+		if other != item {
+			continue
+		}
+		if i < len(items)-1 {
+			return textSpan{item.start, items[i+1].start, ""}
+		}
+		return textSpan{items[i-1].end, item.end, ""}
+	}
+	return textSpan{item.start, item.end, ""}
+}
+
+// lineEnd returns the offset after the newline that ends the line containing offset.
+func (st *SyntaxTree) lineEnd(offset int) int {
+	if newline := strings.IndexByte(st.source[offset:], '\n'); newline >= 0 {
+		return offset + newline + 1
+	}
+	return len(st.source)
+}
+
+// indentation returns the leading whitespace of the line containing a node.
+func (st *SyntaxTree) indentation(node *SyntaxNode) string {
+	start := strings.LastIndexByte(st.source[:node.start], '\n') + 1
+	line := st.source[start:node.start]
+	return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+}
+
+func hasPathPrefix(path, prefix []string) bool {
+	if len(path) < len(prefix) {
+		return false
+	}
+	for i, segment := range prefix {
+		if path[i] != segment {
+			return false
+		}
+	}
+	return true
+}
+
+func formatKeyPath(path []string) string {
+	keys := make([]string, len(path))
+	for i, key := range path {
+		keys[i] = formatKey(key)
+	}
+	return strings.Join(keys, ".")
+}
+
+// formatKey writes a key as a bare key when possible and as a quoted key otherwise.
+func formatKey(key string) string {
+	if key == "" {
+		return `""`
+	}
+	for i := 0; i < len(key); i++ {
+		if !isBareKeyChar(key[i]) {
+			return FormatString(key)
+		}
+	}
+	return key
+}
+
+// formatValue writes a Go value as a TOML value. Maps are written as inline tables with sorted keys.
+func formatValue(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return FormatString(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int8:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int16:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int32:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint8:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case float32:
+		return formatFloat(float64(v)), nil
+	case float64:
+		return formatFloat(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case []string:
+		values := make([]any, len(v))
+		for i, s := range v {
+			values[i] = s
+		}
+		return formatValue(values)
+	case []any:
+		elements := make([]string, len(v))
+		for i, element := range v {
+			formatted, err := formatValue(element)
+			if err != nil {
+				return "", err
+			}
+			elements[i] = formatted
+		}
+		return "[" + strings.Join(elements, ", ") + "]", nil
+	case map[string]any:
+		if len(v) == 0 {
+			return "{}", nil
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		entries := make([]string, len(keys))
+		for i, key := range keys {
+			formatted, err := formatValue(v[key])
+			if err != nil {
+				return "", err
+			}
+			entries[i] = formatKey(key) + " = " + formatted
+		}
+		return "{ " + strings.Join(entries, ", ") + " }", nil
+	default:
+		return "", fmt.Errorf("cannot write a value of type %T to TOML", value)
+	}
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "nan"
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	}
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".eE") {
+		s += ".0"
+	}
+	return s
+}
+
+// FormatString returns s as a TOML basic string, escaping the characters TOML does not allow in one.
+func FormatString(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\b':
+			sb.WriteString(`\b`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\f':
+			sb.WriteString(`\f`)
+		case '\r':
+			sb.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&sb, `\u%04X`, r)
+				continue
+			}
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
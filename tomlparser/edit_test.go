@@ -0,0 +1,223 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tomlparser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"ballerina-lang-go/tools/text"
+
+	"github.com/BurntSushi/toml"
+	"golang.org/x/tools/txtar"
+)
+
+func TestSyntaxTreeRoundTrip(t *testing.T) {
+	inputs := map[string]string{}
+	for _, name := range []string{"sample.toml", "ballerina-package.toml", "missing-required.toml"} {
+		content, err := os.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		inputs[name] = string(content)
+	}
+	archives, err := filepath.Glob(filepath.Join("testdata", "*", "*.txtar"))
+	if err != nil {
+		t.Fatalf("failed to list golden files: %v", err)
+	}
+	for _, file := range archives {
+		archive, err := txtar.ParseFile(file)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", file, err)
+		}
+		for _, f := range archive.Files {
+			if !strings.HasSuffix(f.Name, ".toml") {
+				continue
+			}
+			// Syntax trees are only built for valid documents.
+			if _, err := ReadString(string(f.Data)); err == nil {
+				inputs[file+"/"+f.Name] = string(f.Data)
+			}
+		}
+	}
+	inputs["no trailing newline"] = "a = 1 # comment\n[b]\nc = [1, 2,]"
+	inputs["multi-line strings"] = "a = \"\"\"\n[not.a.table]\n\"\"\"\nb = '''x = \"y\"'''\n\n# comment\n[c] # table\nd = 1979-05-27 07:32:00Z\n"
+
+	for name, input := range inputs {
+		t.Run(name, func(t *testing.T) {
+			tree, err := ParseSyntaxTree(input)
+			if err != nil {
+				t.Fatalf("failed to parse: %v", err)
+			}
+			var sb strings.Builder
+			var write func(node *SyntaxNode)
+			write = func(node *SyntaxNode) {
+				if len(node.Children()) == 0 {
+					sb.WriteString(tree.Text(node))
+				}
+				for _, child := range node.Children() {
+					write(child)
+				}
+			}
+			write(tree.Root())
+			if sb.String() != input {
+				t.Errorf("leaves do not reproduce the input:\nwant:\n%s\ngot:\n%s", input, sb.String())
+			}
+			if tree.String() != input {
+				t.Errorf("tree does not reproduce the input")
+			}
+			if count := tree.Change().GetTextEditCount(); count != 0 {
+				t.Errorf("expected no edits, got %d", count)
+			}
+		})
+	}
+}
+
+func TestParseSyntaxTreeInvalid(t *testing.T) {
+	if _, err := ParseSyntaxTree("a = "); err == nil {
+		t.Error("expected an error for invalid TOML")
+	}
+}
+
+func TestSyntaxTreeEdits(t *testing.T) {
+	bless := os.Getenv("BLESS") == "1" || os.Getenv("BLESS") == "true"
+
+	files, err := filepath.Glob(filepath.Join("testdata", "edit", "*.txtar"))
+	if err != nil {
+		t.Fatalf("failed to list golden files: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no golden files found in testdata/edit")
+	}
+
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".txtar")
+		t.Run(name, func(t *testing.T) {
+			archive, err := txtar.ParseFile(file)
+			if err != nil {
+				t.Fatalf("failed to parse %s: %v", file, err)
+			}
+			sections := make(map[string]string)
+			for _, f := range archive.Files {
+				sections[f.Name] = string(f.Data)
+			}
+
+			input := sections["input.toml"]
+			tree, err := ParseSyntaxTree(input)
+			if err != nil {
+				t.Fatalf("failed to parse input: %v", err)
+			}
+			var errs strings.Builder
+			for _, line := range strings.Split(strings.TrimSpace(sections["edits"]), "\n") {
+				if err := applyEdit(tree, line); err != nil {
+					fmt.Fprintf(&errs, "%s: %v\n", line, err)
+				}
+			}
+			actual := map[string]string{"output.toml": tree.String(), "errors": errs.String()}
+
+			changed := text.NewStringTextDocument(input).Apply(tree.Change()).String()
+			if changed != tree.String() {
+				t.Errorf("applying the change does not reproduce the output:\nwant:\n%s\ngot:\n%s", tree.String(), changed)
+			}
+
+			if bless {
+				archive.Files = archive.Files[:0]
+				for _, section := range []string{"input.toml", "edits", "output.toml", "errors"} {
+					content, ok := actual[section]
+					if !ok {
+						content = sections[section]
+					}
+					if section == "errors" && content == "" {
+						continue
+					}
+					archive.Files = append(archive.Files, txtar.File{Name: section, Data: []byte(content)})
+				}
+				if err := os.WriteFile(file, txtar.Format(archive), 0o644); err != nil {
+					t.Fatalf("failed to update %s: %v", file, err)
+				}
+				return
+			}
+
+			for _, section := range []string{"output.toml", "errors"} {
+				if expected := sections[section]; actual[section] != expected {
+					t.Errorf("%s mismatch:\nwant:\n%s\ngot:\n%s", section, expected, actual[section])
+				}
+			}
+		})
+	}
+}
+
+// applyEdit runs an edit written as one of
+//
+//	set <path> <value>
+//	delete <path>
+//	append-table <path> <inline table>
+//	insert <path> <index> <value>
+//
+// where paths are dotted and values are TOML literals.
+func applyEdit(tree *SyntaxTree, line string) error {
+	op, rest, _ := strings.Cut(line, " ")
+	rawPath, rest, _ := strings.Cut(rest, " ")
+	path := strings.Split(rawPath, ".")
+	switch op {
+	case "set":
+		return tree.Set(path, parseLiteral(rest))
+	case "delete":
+		return tree.Delete(path)
+	case "append-table":
+		entries, err := parseEntries(rest)
+		if err != nil {
+			return err
+		}
+		return tree.AppendTable(path, entries...)
+	case "insert":
+		rawIndex, literal, _ := strings.Cut(rest, " ")
+		index, err := strconv.Atoi(rawIndex)
+		if err != nil {
+			return err
+		}
+		return tree.InsertArrayItem(path, index, parseLiteral(literal))
+	default:
+		return fmt.Errorf("unknown edit '%s'", op)
+	}
+}
+
+func parseLiteral(literal string) any {
+	var data map[string]any
+	if _, err := toml.Decode("v = "+literal, &data); err != nil {
+		panic(fmt.Sprintf("invalid literal %s: %v", literal, err))
+	}
+	return data["v"]
+}
+
+// parseEntries reads the entries of an inline table in the order they are written.
+func parseEntries(inlineTable string) ([]Entry, error) {
+	tree, err := ParseSyntaxTree("v = " + inlineTable)
+	if err != nil {
+		return nil, err
+	}
+	var entries []Entry
+	table := tree.nodes[joinKeyPath([]string{"v"})].value
+	for _, keyValue := range table.keyValues() {
+		entries = append(entries, Entry{Key: keyValue.path[1], Value: parseLiteral(tree.Text(keyValue.value))})
+	}
+	return entries, nil
+}
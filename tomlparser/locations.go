@@ -0,0 +1,66 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tomlparser
+
+import (
+	"strings"
+)
+
+// keyLocations maps the path of every key, table and array element defined in a TOML document to its location in
+// the source. Paths are joined with keyPathSeparator; elements of arrays and arrays of tables are addressed by their
+// decimal index, matching the instance locations reported by jsonschema.
+type keyLocations map[string]Location
+
+const keyPathSeparator = "\x00"
+
+func joinKeyPath(path []string) string {
+	return strings.Join(path, keyPathSeparator)
+}
+
+// indexKeyLocations finds the locations of keys in a document that BurntSushi/toml has already parsed successfully,
+// since toml.MetaData does not expose key positions. Lines and columns are one-based like toml.ParseError positions.
+func indexKeyLocations(content string) keyLocations {
+	locations := make(keyLocations)
+	lines := newLineIndex(content)
+	record := func(path []string, start, end int) {
+		key := joinKeyPath(path)
+		if _, ok := locations[key]; !ok {
+			locations[key] = lines.location(content, start, end)
+		}
+	}
+
+	var visit func(node *SyntaxNode, isElement bool)
+	visit = func(node *SyntaxNode, isElement bool) {
+		if isElement {
+			record(node.path, node.start, node.end)
+		}
+		switch node.kind {
+		case KeySegmentNode:
+			record(node.path, node.start, node.end)
+		case ArrayTableNode:
+			if node.key != nil {
+				last := node.key.children[len(node.key.children)-1]
+				record(node.path, last.start, last.end)
+			}
+		}
+		for _, child := range node.children {
+			visit(child, node.kind == ArrayNode && child.kind != TextNode)
+		}
+	}
+	visit(parseSyntax(content), false)
+	return locations
+}
@@ -21,18 +21,25 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"time"
 
 	"github.com/santhosh-tekuri/jsonschema/v6"
 )
 
 type Schema interface {
 	Validate(data any) error
+	// Completions returns the keys or values that the schema allows at a position of a document.
+	Completions(toml *Toml, position Position) Completion
+	// Hover describes the key at a position of a document, using the description of its schema.
+	Hover(toml *Toml, position Position) (Hover, bool)
 	FromPath(fsys fs.FS, path string) (Schema, error)
 	FromString(content string) (Schema, error)
 }
 
 type schemaImpl struct {
 	compiled *jsonschema.Schema
+	// document is the decoded schema JSON, used to look up the custom "message" keyword of a failing schema.
+	document any
 }
 
 func NewSchemaFromPath(fsys fs.FS, path string) (Schema, error) {
@@ -62,6 +69,7 @@ func NewSchemaFromString(content string) (Schema, error) {
 
 	return &schemaImpl{
 		compiled: schema,
+		document: schemaDoc,
 	}, nil
 }
 
@@ -78,12 +86,43 @@ func NewSchemaFromFile(file fs.File) (Schema, error) {
 }
 
 func (s *schemaImpl) Validate(data any) error {
-	if err := s.compiled.Validate(data); err != nil {
+	if err := s.compiled.Validate(toJSONValue(data)); err != nil {
 		return fmt.Errorf("schema validation failed: %w", err)
 	}
 	return nil
 }
 
+// toJSONValue converts the values decoded by BurntSushi/toml that jsonschema does not accept: arrays of tables are
+// decoded as []map[string]any and date-times as time.Time or toml.Local* values, which are validated as strings.
+func toJSONValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for key, item := range v {
+			result[key] = toJSONValue(item)
+		}
+		return result
+	case []map[string]any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = toJSONValue(item)
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = toJSONValue(item)
+		}
+		return result
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return v
+	}
+}
+
 func (s *schemaImpl) FromPath(fsys fs.FS, path string) (Schema, error) {
 	return NewSchemaFromPath(fsys, path)
 }
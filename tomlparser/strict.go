@@ -0,0 +1,298 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tomlparser
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"ballerina-lang-go/tools/diagnostics"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ReadOptions configures how ReadStringWithOptions reads a document.
+type ReadOptions struct {
+	// Strict reports every syntax error of the document instead of only the first one. It also rejects the tables
+	// that are defined more than once through dotted keys, inline tables or arrays of tables, such as a [fruit.apple]
+	// header after apple.color = "red" under [fruit], which ReadString accepts.
+	Strict bool
+	// Schema, if not nil, is used to validate the document once it is decoded.
+	Schema Schema
+	// UnknownKeysAsWarnings reports keys that the schema does not allow as warnings instead of errors.
+	UnknownKeysAsWarnings bool
+}
+
+func ReadWithOptions(fsys fs.FS, path string, options ReadOptions) (*Toml, error) {
+	content, err := readFile(fsys, path)
+	if err != nil {
+		return nil, err
+	}
+	return ReadStringWithOptions(content, options)
+}
+
+// ReadStringWithOptions reads a document as configured by options. Like ReadString, the returned error is the first
+// syntax error of the document; in strict mode it joins every syntax error or table redefinition found, each of which
+// is also reported as a located diagnostic.
+func ReadStringWithOptions(content string, options ReadOptions) (*Toml, error) {
+	t, err := ReadString(content)
+	if err != nil {
+		if options.Strict {
+			var errs []error
+			t.diagnostics, errs = syntaxErrors(content)
+			err = errors.Join(errs...)
+		}
+		return t, err
+	}
+	if options.Strict {
+		if diagnostics, errs := redefinitions(content); len(errs) > 0 {
+			t.diagnostics = diagnostics
+			return t, errors.Join(errs...)
+		}
+	}
+
+	if options.Schema == nil {
+		return t, nil
+	}
+	validator := NewValidatorWithOptions(options.Schema, ValidatorOptions{
+		UnknownKeysAsWarnings: options.UnknownKeysAsWarnings,
+	})
+	return t, validator.Validate(t)
+}
+
+// syntaxErrors decodes content repeatedly, recovering from each syntax error by blanking the line it is reported at,
+// until the rest of the document decodes. An error is only kept if it is reported after the previous one, since an
+// error reported at or before it is usually caused by the recovery itself.
+//
+// A line is blanked by replacing it with an empty line, or with a placeholder table header if it is a header, so that
+// the keys that follow it are not added to the previous table. Since every recovery blanks a line that was not blanked
+// before, at most one error is reported per line of the document.
+func syntaxErrors(content string) ([]Diagnostic, []error) {
+	lines := strings.SplitAfter(content, "\n")
+	blanked := make([]bool, len(lines)+1)
+	var diagnostics []Diagnostic
+	var errs []error
+	lastLine := 0
+	for {
+		var data map[string]any
+		_, err := toml.Decode(strings.Join(lines, ""), &data)
+		if err == nil {
+			break
+		}
+		diagnostic := parseErrorDiagnostic(err)
+		if diagnostic.Location == nil {
+			diagnostics = append(diagnostics, diagnostic)
+			errs = append(errs, err)
+			break
+		}
+		line := diagnostic.Location.StartLine
+		if line > lastLine {
+			diagnostics = append(diagnostics, diagnostic)
+			errs = append(errs, err)
+			lastLine = line
+		}
+
+		// An error reported at the end of a line, or at the end of the document, is caused by a line that has already
+		// been blanked or by the line before it.
+		target := min(line, len(lines))
+		for target > 0 && blanked[target] {
+			target--
+		}
+		if target == 0 {
+			break
+		}
+		blanked[target] = true
+		lines[target-1] = blankLine(lines[target-1], target)
+	}
+	return diagnostics, errs
+}
+
+// blankLine returns the replacement of the given line, which is at the 1-based index lineNumber.
+func blankLine(line string, lineNumber int) string {
+	newline := ""
+	if strings.HasSuffix(line, "\n") {
+		newline = "\n"
+	}
+	if strings.HasPrefix(strings.TrimSpace(line), "[") {
+		// The name cannot be written in a document without an escape sequence, so it does not clash with its tables.
+		return fmt.Sprintf(`["\u0000%d"]`, lineNumber) + newline
+	}
+	return newline
+}
+
+// tableFlags records how the tables of a document are defined, keyed by their names without the indices of arrays of
+// tables, since a table of an array is only ever extended through its last element.
+type tableFlags struct {
+	children map[string]*tableFlags
+	// explicit is set for a table defined by a header, or by dotted keys in an earlier section.
+	explicit bool
+	// frozen is set for an inline table or an array value, and so for everything below it.
+	frozen bool
+}
+
+func (f *tableFlags) child(path []string) *tableFlags {
+	node := f
+	for _, name := range path {
+		if node.children == nil {
+			node.children = make(map[string]*tableFlags)
+		}
+		next, ok := node.children[name]
+		if !ok {
+			next = &tableFlags{}
+			node.children[name] = next
+		}
+		node = next
+	}
+	return node
+}
+
+func (f *tableFlags) isExplicit(path []string) bool {
+	node := f
+	for _, name := range path {
+		if node = node.children[name]; node == nil {
+			return false
+		}
+	}
+	return node.explicit
+}
+
+// frozenPrefix returns the inline table or array value that path is in or is, or nil when there is none.
+func (f *tableFlags) frozenPrefix(path []string) []string {
+	node := f
+	for i, name := range path {
+		if node = node.children[name]; node == nil {
+			return nil
+		}
+		if node.frozen {
+			return path[:i+1]
+		}
+	}
+	return nil
+}
+
+// reset forgets the tables below path, which is the array of tables that a [[path]] header adds an element to.
+func (f *tableFlags) reset(path []string) {
+	parent := f.child(path[:len(path)-1])
+	delete(parent.children, path[len(path)-1])
+}
+
+const frozenMessage = "Key '%s' is an inline table or array and cannot be extended."
+
+// isFrozenValue reports whether value is an inline table or an array, which cannot be extended once defined.
+func isFrozenValue(value *SyntaxNode) bool {
+	return value != nil && (value.kind == InlineTableNode || value.kind == ArrayNode)
+}
+
+// redefinitions reports the tables of a document that BurntSushi/toml accepts although they are defined more than
+// once: a table defined by a header or by the dotted keys of an earlier section cannot be defined or extended again,
+// and an inline table or array value cannot be extended at all.
+func redefinitions(content string) ([]Diagnostic, []error) {
+	lines := newLineIndex(content)
+	var located []Diagnostic
+	var errs []error
+	report := func(key *SyntaxNode, format string, args ...any) {
+		location := lines.location(content, key.start, key.end)
+		message := fmt.Sprintf(format, args...)
+		located = append(located, Diagnostic{Message: message, Severity: diagnostics.Error, Location: &location})
+		errs = append(errs, fmt.Errorf("toml: line %d: %s", location.StartLine, message))
+	}
+	names := func(key *SyntaxNode) []string {
+		var names []string
+		for _, segment := range key.segments() {
+			names = append(names, keyName(content[segment.start:segment.end]))
+		}
+		return names
+	}
+
+	// checkValue checks the inline tables of the value of the key path, each of which has its own tables.
+	var checkValue func(path []string, value *SyntaxNode)
+	checkValue = func(path []string, value *SyntaxNode) {
+		switch value.kind {
+		case ArrayNode:
+			for _, element := range value.elements() {
+				checkValue(path, element)
+			}
+		case InlineTableNode:
+			flags := &tableFlags{}
+			for _, keyValue := range value.keyValues() {
+				key := names(keyValue.key)
+				if frozen := flags.frozenPrefix(key); frozen != nil {
+					report(keyValue.key, frozenMessage, formatKeyPath(append(clonePath(path), frozen...)))
+				}
+				if isFrozenValue(keyValue.value) {
+					flags.child(key).frozen = true
+					checkValue(append(clonePath(path), key...), keyValue.value)
+				}
+			}
+		}
+	}
+
+	flags := &tableFlags{}
+	// The tables defined by the dotted keys of a section become explicit once the section ends, as the dotted keys of
+	// a section may extend each other.
+	var pending [][]string
+	for _, section := range parseSyntax(content).children {
+		if section.kind != TableNode && section.kind != ArrayTableNode {
+			continue
+		}
+		for _, path := range pending {
+			flags.child(path).explicit = true
+		}
+		pending = nil
+
+		var header []string
+		if section.key != nil {
+			header = names(section.key)
+			switch frozen := flags.frozenPrefix(header); {
+			case frozen != nil:
+				report(section.key, frozenMessage, formatKeyPath(frozen))
+			case section.kind == ArrayTableNode:
+				flags.reset(header)
+				flags.child(header).explicit = true
+			case flags.isExplicit(header):
+				report(section.key, "Key '%s' has already been defined.", formatKeyPath(header))
+			default:
+				flags.child(header).explicit = true
+			}
+		}
+
+		for _, keyValue := range section.keyValues() {
+			key := names(keyValue.key)
+			path := append(clonePath(header), key...)
+			redefined := false
+			for i := len(header) + 1; i < len(path); i++ {
+				if flags.isExplicit(path[:i]) {
+					report(keyValue.key, "Key '%s' has already been defined as a table and cannot be extended with dotted keys.", formatKeyPath(path[:i]))
+					redefined = true
+					break
+				}
+				pending = append(pending, path[:i])
+			}
+			// A header in an inline table or an array is reported at the header.
+			if frozen := flags.frozenPrefix(path[:len(path)-1]); !redefined && len(frozen) > len(header) {
+				report(keyValue.key, frozenMessage, formatKeyPath(frozen))
+			}
+			if isFrozenValue(keyValue.value) {
+				flags.child(path).frozen = true
+				checkValue(path, keyValue.value)
+			}
+		}
+	}
+	return located, errs
+}
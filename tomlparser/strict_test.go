@@ -0,0 +1,141 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tomlparser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ballerina-lang-go/tools/diagnostics"
+
+	"golang.org/x/tools/txtar"
+)
+
+func TestReadStringWithOptionsNotStrict(t *testing.T) {
+	content := "a = 1\na = 2\nb = \nc = 3\nc = 4\n"
+
+	toml, err := ReadStringWithOptions(content, ReadOptions{})
+	if err == nil {
+		t.Fatal("expected error for invalid document")
+	}
+	if len(toml.Diagnostics()) != 1 {
+		t.Fatalf("expected only the first error without strict mode, got %v", toml.Diagnostics())
+	}
+
+	toml, err = ReadStringWithOptions(content, ReadOptions{Strict: true})
+	if err == nil {
+		t.Fatal("expected error for invalid document")
+	}
+	if len(toml.Diagnostics()) != 3 {
+		t.Fatalf("expected 3 errors in strict mode, got %v", toml.Diagnostics())
+	}
+	if got := len(strings.Split(err.Error(), "\n")); got != 3 {
+		t.Errorf("expected the error to join 3 errors, got %d: %v", got, err)
+	}
+}
+
+func TestReadWithOptions(t *testing.T) {
+	schema, err := NewSchemaFromPath(fsys, "testdata/sample-schema.json")
+	if err != nil {
+		t.Fatalf("failed to load schema: %v", err)
+	}
+
+	toml, err := ReadWithOptions(fsys, "testdata/ballerina-package.toml", ReadOptions{Strict: true, Schema: schema})
+	if err != nil {
+		t.Fatalf("failed to read valid document: %v", err)
+	}
+	if len(toml.Diagnostics()) != 0 {
+		t.Errorf("expected no diagnostics, got %v", toml.Diagnostics())
+	}
+}
+
+func TestStrictDiagnostics(t *testing.T) {
+	bless := os.Getenv("BLESS") == "1" || os.Getenv("BLESS") == "true"
+
+	files, err := filepath.Glob(filepath.Join("testdata", "strict", "*.txtar"))
+	if err != nil {
+		t.Fatalf("failed to list golden files: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no golden files found in testdata/strict")
+	}
+
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".txtar")
+		t.Run(name, func(t *testing.T) {
+			archive, err := txtar.ParseFile(file)
+			if err != nil {
+				t.Fatalf("failed to parse %s: %v", file, err)
+			}
+			sections := make(map[string]string)
+			for _, f := range archive.Files {
+				sections[f.Name] = string(f.Data)
+			}
+
+			options := ReadOptions{Strict: true}
+			for _, option := range strings.Fields(sections["options"]) {
+				switch option {
+				case "unknown-keys-as-warnings":
+					options.UnknownKeysAsWarnings = true
+				default:
+					t.Fatalf("unknown option %q", option)
+				}
+			}
+			if source, ok := sections["schema.json"]; ok {
+				options.Schema, err = NewSchemaFromString(source)
+				if err != nil {
+					t.Fatalf("invalid schema: %v", err)
+				}
+			}
+			tomlDoc, err := ReadStringWithOptions(sections["input.toml"], options)
+
+			var sb strings.Builder
+			hasErrors := false
+			for _, d := range tomlDoc.Diagnostics() {
+				location := "-"
+				if d.Location != nil {
+					location = fmt.Sprintf("%d:%d-%d:%d", d.Location.StartLine, d.Location.StartColumn, d.Location.EndLine, d.Location.EndColumn)
+				}
+				fmt.Fprintf(&sb, "%s %s %s\n", d.Severity, location, d.Message)
+				hasErrors = hasErrors || d.Severity == diagnostics.Error
+			}
+			actual := sb.String()
+			if hasErrors != (err != nil) {
+				t.Errorf("expected an error only if there are error diagnostics, got error %v", err)
+			}
+
+			if bless {
+				for i := range archive.Files {
+					if archive.Files[i].Name == "diagnostics" {
+						archive.Files[i].Data = []byte(actual)
+					}
+				}
+				if err := os.WriteFile(file, txtar.Format(archive), 0o644); err != nil {
+					t.Fatalf("failed to update %s: %v", file, err)
+				}
+				return
+			}
+
+			if expected := sections["diagnostics"]; actual != expected {
+				t.Errorf("diagnostics mismatch:\nwant:\n%s\ngot:\n%s", expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,506 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tomlparser
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// SyntaxKind is the kind of a SyntaxNode.
+type SyntaxKind uint8
+
+const (
+	DocumentNode SyntaxKind = iota
+	// TableNode is a [table] section: its header, key-values and comments. The key-values before the first header
+	// form a TableNode with an empty path and no header.
+	TableNode
+	// ArrayTableNode is a [[table]] section.
+	ArrayTableNode
+	KeyValueNode
+	// KeyNode is a possibly dotted key, made of KeySegmentNode children.
+	KeyNode
+	KeySegmentNode
+	// ValueNode is a string, number, boolean or date-time value.
+	ValueNode
+	ArrayNode
+	InlineTableNode
+	// TextNode is any other text: whitespace, comments and punctuation.
+	TextNode
+)
+
+func (sk SyntaxKind) String() string {
+	switch sk {
+	case DocumentNode:
+		return "DOCUMENT"
+	case TableNode:
+		return "TABLE"
+	case ArrayTableNode:
+		return "ARRAY_TABLE"
+	case KeyValueNode:
+		return "KEY_VALUE"
+	case KeyNode:
+		return "KEY"
+	case KeySegmentNode:
+		return "KEY_SEGMENT"
+	case ValueNode:
+		return "VALUE"
+	case ArrayNode:
+		return "ARRAY"
+	case InlineTableNode:
+		return "INLINE_TABLE"
+	case TextNode:
+		return "TEXT"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// SyntaxNode is a node of a SyntaxTree. The children of a node cover its text without gaps, so concatenating the
+// text of the leaves reproduces the document exactly.
+type SyntaxNode struct {
+	kind     SyntaxKind
+	start    int
+	end      int
+	path     []string
+	children []*SyntaxNode
+	// key is the header of a table or the key of a key-value.
+	key *SyntaxNode
+	// value is the value of a key-value.
+	value  *SyntaxNode
+	parent *SyntaxNode
+}
+
+func (sn *SyntaxNode) Kind() SyntaxKind {
+	return sn.kind
+}
+
+// Start returns the byte offset at which the node starts.
+func (sn *SyntaxNode) Start() int {
+	return sn.start
+}
+
+// End returns the byte offset at which the node ends.
+func (sn *SyntaxNode) End() int {
+	return sn.end
+}
+
+// Path returns the path of a table, key-value, key segment or array element, in the form used by Toml.KeyLocation.
+func (sn *SyntaxNode) Path() []string {
+	return clonePath(sn.path)
+}
+
+func (sn *SyntaxNode) Children() []*SyntaxNode {
+	return sn.children
+}
+
+// elements returns the values of an array node.
+func (sn *SyntaxNode) elements() []*SyntaxNode {
+	var elements []*SyntaxNode
+	for _, child := range sn.children {
+		if child.kind != TextNode {
+			elements = append(elements, child)
+		}
+	}
+	return elements
+}
+
+// segments returns the segments of a key node.
+func (sn *SyntaxNode) segments() []*SyntaxNode {
+	var segments []*SyntaxNode
+	for _, child := range sn.children {
+		if child.kind == KeySegmentNode {
+			segments = append(segments, child)
+		}
+	}
+	return segments
+}
+
+// keyValues returns the key-values of a table or inline table node.
+func (sn *SyntaxNode) keyValues() []*SyntaxNode {
+	var keyValues []*SyntaxNode
+	for _, child := range sn.children {
+		if child.kind == KeyValueNode {
+			keyValues = append(keyValues, child)
+		}
+	}
+	return keyValues
+}
+
+// syntaxParser builds the syntax tree of a document that BurntSushi/toml parses successfully. It recovers from
+// malformed input by skipping to the end of the line, but the resulting tree is only meaningful for valid TOML.
+type syntaxParser struct {
+	src         string
+	pos         int
+	arrayTables map[string]int
+}
+
+func parseSyntax(src string) *SyntaxNode {
+	p := &syntaxParser{src: src, arrayTables: make(map[string]int)}
+	document := &SyntaxNode{kind: DocumentNode, start: 0, end: len(src)}
+	section := &SyntaxNode{kind: TableNode, start: 0}
+
+	for {
+		p.skipTrivia()
+		if p.eof() {
+			break
+		}
+		if p.peek() == '[' {
+			boundary := p.sectionBoundary()
+			section.end = boundary
+			document.children = append(document.children, section)
+			section = p.parseHeader(boundary)
+			continue
+		}
+		keyValue := p.parseKeyValue(section.path)
+		if keyValue == nil {
+			p.skipLine()
+			continue
+		}
+		section.children = append(section.children, keyValue)
+	}
+	section.end = len(src)
+	document.children = append(document.children, section)
+	fillText(document)
+	return document
+}
+
+// fillText adds TextNode children for the text of a node that is not covered by its children.
+func fillText(node *SyntaxNode) {
+	switch node.kind {
+	case KeySegmentNode, ValueNode, TextNode:
+		return
+	}
+	var children []*SyntaxNode
+	offset := node.start
+	for _, child := range node.children {
+		if child.start > offset {
+			children = append(children, &SyntaxNode{kind: TextNode, start: offset, end: child.start})
+		}
+		fillText(child)
+		children = append(children, child)
+		offset = child.end
+	}
+	if node.end > offset {
+		children = append(children, &SyntaxNode{kind: TextNode, start: offset, end: node.end})
+	}
+	for _, child := range children {
+		child.parent = node
+	}
+	node.children = children
+}
+
+func (p *syntaxParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *syntaxParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *syntaxParser) at(prefix string) bool {
+	return strings.HasPrefix(p.src[p.pos:], prefix)
+}
+
+func (p *syntaxParser) skipSpace() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.pos++
+	}
+}
+
+// skipTrivia skips whitespace, newlines and comments.
+func (p *syntaxParser) skipTrivia() {
+	for !p.eof() {
+		switch p.peek() {
+		case ' ', '\t', '\r', '\n':
+			p.pos++
+		case '#':
+			p.skipLine()
+		default:
+			return
+		}
+	}
+}
+
+func (p *syntaxParser) skipLine() {
+	for !p.eof() && p.peek() != '\n' {
+		p.pos++
+	}
+}
+
+// sectionBoundary returns the offset at which the section whose header starts at the current position begins. The
+// comment lines directly above a header belong to its section.
+func (p *syntaxParser) sectionBoundary() int {
+	boundary := strings.LastIndexByte(p.src[:p.pos], '\n') + 1
+	for boundary > 0 {
+		previous := strings.LastIndexByte(p.src[:boundary-1], '\n') + 1
+		if !strings.HasPrefix(strings.TrimSpace(p.src[previous:boundary-1]), "#") {
+			break
+		}
+		boundary = previous
+	}
+	return boundary
+}
+
+func (p *syntaxParser) parseHeader(start int) *SyntaxNode {
+	section := &SyntaxNode{kind: TableNode, start: start}
+	isArray := p.at("[[")
+	if isArray {
+		section.kind = ArrayTableNode
+		p.pos += 2
+	} else {
+		p.pos++
+	}
+
+	key := p.parseKey()
+	p.skipLine()
+	if key == nil {
+		return section
+	}
+	section.key = key
+	section.children = append(section.children, key)
+
+	// Resolve the header through the last element of every array of tables it passes through.
+	var path []string
+	for i, segment := range key.children {
+		path = append(path, segment.path[0])
+		if i < len(key.children)-1 {
+			if index, ok := p.arrayTables[joinKeyPath(path)]; ok {
+				path = append(path, strconv.Itoa(index))
+			}
+		}
+		segment.path = clonePath(path)
+	}
+	if isArray {
+		arrayKey := joinKeyPath(path)
+		index := 0
+		if last, ok := p.arrayTables[arrayKey]; ok {
+			index = last + 1
+		}
+		p.arrayTables[arrayKey] = index
+		path = append(path, strconv.Itoa(index))
+	}
+	section.path = path
+	return section
+}
+
+func (p *syntaxParser) parseKeyValue(base []string) *SyntaxNode {
+	start := p.pos
+	key := p.parseKey()
+	if key == nil {
+		return nil
+	}
+	path := clonePath(base)
+	for _, segment := range key.children {
+		path = append(path, segment.path[0])
+		segment.path = clonePath(path)
+	}
+	keyValue := &SyntaxNode{kind: KeyValueNode, start: start, path: path, key: key, children: []*SyntaxNode{key}}
+
+	p.skipSpace()
+	if p.peek() != '=' {
+		keyValue.end = p.pos
+		return keyValue
+	}
+	p.pos++
+	p.skipSpace()
+	value := p.parseValue(path)
+	keyValue.value = value
+	keyValue.children = append(keyValue.children, value)
+	keyValue.end = value.end
+	return keyValue
+}
+
+// parseKey parses a dotted key. The path of each segment is set to its own name until the caller resolves it.
+func (p *syntaxParser) parseKey() *SyntaxNode {
+	p.skipSpace()
+	key := &SyntaxNode{kind: KeyNode, start: p.pos}
+	for {
+		p.skipSpace()
+		start := p.pos
+		var name string
+		switch c := p.peek(); {
+		case c == '"' || c == '\'':
+			name = keyName(p.scanString())
+		case isBareKeyChar(c):
+			for !p.eof() && isBareKeyChar(p.peek()) {
+				p.pos++
+			}
+			name = p.src[start:p.pos]
+		default:
+			if len(key.children) == 0 {
+				return nil
+			}
+			return key
+		}
+		key.children = append(key.children, &SyntaxNode{kind: KeySegmentNode, start: start, end: p.pos, path: []string{name}})
+		key.end = p.pos
+
+		save := p.pos
+		p.skipSpace()
+		if p.peek() != '.' {
+			p.pos = save
+			return key
+		}
+		p.pos++
+	}
+}
+
+// keyName returns the name of a bare, basic or literal key segment written as raw.
+func keyName(raw string) string {
+	switch {
+	case strings.HasPrefix(raw, "'"):
+		return strings.Trim(raw, "'")
+	case strings.HasPrefix(raw, "\""):
+		unquoted, err := strconv.Unquote(raw)
+		if err != nil {
+			return strings.Trim(raw, "\"")
+		}
+		return unquoted
+	default:
+		return raw
+	}
+}
+
+func isBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+// scanString consumes a basic, literal or multi-line string and returns its source text.
+func (p *syntaxParser) scanString() string {
+	begin := p.pos
+	quote := p.src[p.pos : p.pos+1]
+	if p.at(quote + quote + quote) {
+		delimiter := quote + quote + quote
+		p.pos += 3
+		for !p.eof() && !p.at(delimiter) {
+			if quote == "\"" && p.peek() == '\\' {
+				p.pos++
+			}
+			p.pos++
+		}
+		p.pos = min(p.pos+3, len(p.src))
+		// Up to two quotes may directly precede the closing delimiter.
+		for i := 0; i < 2 && p.at(quote); i++ {
+			p.pos++
+		}
+		return p.src[begin:p.pos]
+	}
+
+	p.pos++
+	for !p.eof() && p.src[p.pos:p.pos+1] != quote && p.peek() != '\n' {
+		if quote == "\"" && p.peek() == '\\' {
+			p.pos++
+		}
+		p.pos++
+	}
+	p.pos = min(p.pos+1, len(p.src))
+	return p.src[begin:p.pos]
+}
+
+func (p *syntaxParser) parseValue(path []string) *SyntaxNode {
+	start := p.pos
+	switch p.peek() {
+	case '"', '\'':
+		p.scanString()
+		return &SyntaxNode{kind: ValueNode, start: start, end: p.pos, path: path}
+	case '[':
+		array := &SyntaxNode{kind: ArrayNode, start: start, path: path}
+		p.pos++
+		for index := 0; ; index++ {
+			p.skipTrivia()
+			if p.eof() || p.peek() == ']' {
+				break
+			}
+			element := p.parseValue(append(clonePath(path), strconv.Itoa(index)))
+			array.children = append(array.children, element)
+			p.skipTrivia()
+			if p.peek() != ',' {
+				break
+			}
+			p.pos++
+		}
+		p.pos = min(p.pos+1, len(p.src))
+		array.end = p.pos
+		return array
+	case '{':
+		table := &SyntaxNode{kind: InlineTableNode, start: start, path: path}
+		p.pos++
+		for {
+			p.skipSpace()
+			if p.eof() || p.peek() == '}' {
+				break
+			}
+			keyValue := p.parseKeyValue(path)
+			if keyValue == nil {
+				break
+			}
+			table.children = append(table.children, keyValue)
+			p.skipSpace()
+			if p.peek() != ',' {
+				break
+			}
+			p.pos++
+		}
+		p.pos = min(p.pos+1, len(p.src))
+		table.end = p.pos
+		return table
+	default:
+		// Numbers, booleans and dates end at a delimiter. Spaces are allowed inside dates, so only trailing
+		// spaces are excluded.
+		end := p.pos
+		for !p.eof() && !strings.ContainsRune(",]}#\r\n", rune(p.peek())) {
+			p.pos++
+			if c := p.src[p.pos-1]; c != ' ' && c != '\t' {
+				end = p.pos
+			}
+		}
+		p.pos = end
+		return &SyntaxNode{kind: ValueNode, start: start, end: end, path: path}
+	}
+}
+
+// lineIndex converts byte offsets to one-based lines and columns, counting columns in runes.
+type lineIndex []int
+
+func newLineIndex(src string) lineIndex {
+	starts := lineIndex{0}
+	for i := 0; i < len(src); i++ {
+		if src[i] == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return starts
+}
+
+func (li lineIndex) position(src string, offset int) (int, int) {
+	line := 0
+	for line+1 < len(li) && li[line+1] <= offset {
+		line++
+	}
+	return line + 1, utf8.RuneCountInString(src[li[line]:offset]) + 1
+}
+
+func (li lineIndex) location(src string, start, end int) Location {
+	startLine, startColumn := li.position(src, start)
+	endLine, endColumn := li.position(src, end)
+	return Location{StartLine: startLine, StartColumn: startColumn, EndLine: endLine, EndColumn: endColumn}
+}
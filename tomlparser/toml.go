@@ -22,6 +22,7 @@ import (
 	"errors"
 	"io"
 	"io/fs"
+	"strconv"
 	"strings"
 
 	"ballerina-lang-go/tools/diagnostics"
@@ -30,10 +31,13 @@ import (
 )
 
 type Toml struct {
-	rootNode    map[string]any
-	metadata    toml.MetaData
-	diagnostics []Diagnostic
-	content     string
+	rootNode     map[string]any
+	metadata     toml.MetaData
+	diagnostics  []Diagnostic
+	content      string
+	keyLocations keyLocations
+	// path is the path of this table from the root of the document.
+	path []string
 }
 
 type Diagnostic struct {
@@ -110,6 +114,8 @@ func ReadString(content string) (*Toml, error) {
 
 	if err != nil {
 		t.diagnostics = append(t.diagnostics, parseErrorDiagnostic(err))
+	} else {
+		t.keyLocations = indexKeyLocations(content)
 	}
 
 	return t, err
@@ -204,10 +210,12 @@ func (t *Toml) GetTable(dottedKey string) (*Toml, bool) {
 	}
 
 	return &Toml{
-		rootNode:    table,
-		metadata:    t.metadata,
-		diagnostics: nil,
-		content:     "",
+		rootNode:     table,
+		metadata:     t.metadata,
+		diagnostics:  nil,
+		content:      "",
+		keyLocations: t.keyLocations,
+		path:         t.childPath(splitDottedKey(dottedKey)...),
 	}, true
 }
 
@@ -223,10 +231,12 @@ func (t *Toml) GetTables(dottedKey string) ([]*Toml, bool) {
 			result := make([]*Toml, len(tableArr))
 			for i, table := range tableArr {
 				result[i] = &Toml{
-					rootNode:    table,
-					metadata:    t.metadata,
-					diagnostics: nil,
-					content:     "",
+					rootNode:     table,
+					metadata:     t.metadata,
+					diagnostics:  nil,
+					content:      "",
+					keyLocations: t.keyLocations,
+					path:         t.childPath(append(splitDottedKey(dottedKey), strconv.Itoa(i))...),
 				}
 			}
 			return result, true
@@ -235,13 +245,15 @@ func (t *Toml) GetTables(dottedKey string) ([]*Toml, bool) {
 	}
 
 	result := make([]*Toml, 0)
-	for _, item := range arr {
+	for i, item := range arr {
 		if table, ok := item.(map[string]any); ok {
 			result = append(result, &Toml{
-				rootNode:    table,
-				metadata:    t.metadata,
-				diagnostics: nil,
-				content:     "",
+				rootNode:     table,
+				metadata:     t.metadata,
+				diagnostics:  nil,
+				content:      "",
+				keyLocations: t.keyLocations,
+				path:         t.childPath(append(splitDottedKey(dottedKey), strconv.Itoa(i))...),
 			})
 		}
 	}
@@ -261,23 +273,35 @@ func (t *Toml) ToMap() map[string]any {
 	return t.rootNode
 }
 
-func (t *Toml) To(target any) {
-	_, err := toml.Decode(t.content, target)
-	if err != nil {
-		t.diagnostics = append(t.diagnostics, parseErrorDiagnostic(err))
+// KeyLocation returns the location of the key with the given path relative to this table. Each element of the path
+// is a single key, or the decimal index of an element of an array or an array of tables.
+func (t *Toml) KeyLocation(path ...string) (*Location, bool) {
+	location, ok := t.keyLocations[joinKeyPath(t.childPath(path...))]
+	if !ok {
+		return nil, false
 	}
+	return &location, true
 }
 
+func (t *Toml) childPath(keys ...string) []string {
+	path := make([]string, 0, len(t.path)+len(keys))
+	path = append(path, t.path...)
+	return append(path, keys...)
+}
+
+// splitDottedKey splits a dotted key such as package."org" into its keys, without the quotes of the quoted ones.
 func splitDottedKey(dottedKey string) []string {
-	return strings.Split(dottedKey, ".")
+	keys := strings.Split(dottedKey, ".")
+	for i, key := range keys {
+		keys[i] = strings.Trim(key, "\"")
+	}
+	return keys
 }
 
 func (t *Toml) getValueByPath(keys []string) (any, bool) {
 	current := any(t.rootNode)
 
 	for _, key := range keys {
-		key = strings.Trim(key, "\"")
-
 		currentMap, ok := current.(map[string]any)
 		if !ok {
 			return nil, false
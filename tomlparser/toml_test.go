@@ -446,3 +446,78 @@ key = "value"
 		t.Errorf("Expected StartLine 3, got %d", diag.Location.StartLine)
 	}
 }
+
+func TestKeyLocation(t *testing.T) {
+	content := `# Ballerina.toml
+[package]
+org = "foo"
+name = "winery"   # trailing comment
+license = ["MIT", "Apache-2.0"]
+
+[[platform.java21.dependency]]
+groupId = "com.example"
+
+[[platform.java21.dependency]]
+path = "./libs/x.jar"
+
+[tool.openapi]
+spec = { file = "a.yaml", options = { mode = "client" } }
+"quoted.key" = 1
+multi = """
+fake = "key"
+"""
+after = 2024-01-01 10:00:00
+"\"quoted\"" = 3
+`
+	tomlDoc, err := ReadString(content)
+	if err != nil {
+		t.Fatalf("ReadString() error: %v", err)
+	}
+
+	tests := []struct {
+		path []string
+		want Location
+	}{
+		{[]string{"package"}, Location{2, 2, 2, 9}},
+		{[]string{"package", "org"}, Location{3, 1, 3, 4}},
+		{[]string{"package", "name"}, Location{4, 1, 4, 5}},
+		{[]string{"package", "license", "1"}, Location{5, 19, 5, 31}},
+		{[]string{"platform", "java21", "dependency"}, Location{7, 19, 7, 29}},
+		{[]string{"platform", "java21", "dependency", "1"}, Location{10, 19, 10, 29}},
+		{[]string{"platform", "java21", "dependency", "1", "path"}, Location{11, 1, 11, 5}},
+		{[]string{"tool", "openapi", "spec", "options", "mode"}, Location{14, 39, 14, 43}},
+		{[]string{"tool", "openapi", "quoted.key"}, Location{15, 1, 15, 13}},
+		{[]string{"tool", "openapi", "after"}, Location{19, 1, 19, 6}},
+		{[]string{"tool", "openapi", `"quoted"`}, Location{20, 1, 20, 13}},
+	}
+	for _, tt := range tests {
+		got, ok := tomlDoc.KeyLocation(tt.path...)
+		if !ok {
+			t.Errorf("KeyLocation(%v) not found", tt.path)
+			continue
+		}
+		if *got != tt.want {
+			t.Errorf("KeyLocation(%v) = %+v, want %+v", tt.path, *got, tt.want)
+		}
+	}
+
+	if _, ok := tomlDoc.KeyLocation("tool", "openapi", "fake"); ok {
+		t.Error("keys inside multi-line strings must not be indexed")
+	}
+
+	dependencies, ok := tomlDoc.GetTables("platform.java21.dependency")
+	if !ok || len(dependencies) != 2 {
+		t.Fatalf("GetTables() = %v, %v", dependencies, ok)
+	}
+	if got, ok := dependencies[1].KeyLocation("path"); !ok || got.StartLine != 11 {
+		t.Errorf("KeyLocation of a key in an array of tables = %+v, %v, want line 11", got, ok)
+	}
+	pkg, _ := tomlDoc.GetTable("package")
+	if got, ok := pkg.KeyLocation("org"); !ok || got.StartLine != 3 {
+		t.Errorf("KeyLocation of a key in a table = %+v, %v, want line 3", got, ok)
+	}
+	openapi, _ := tomlDoc.GetTable(`tool."openapi"`)
+	if got, ok := openapi.KeyLocation(`"quoted"`); !ok || got.StartLine != 20 {
+		t.Errorf("KeyLocation of a key with quotes in its name = %+v, %v, want line 20", got, ok)
+	}
+}
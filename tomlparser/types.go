@@ -0,0 +1,64 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tomlparser
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// Version is a semantic version, decoded from a string such as "1.2.3" or "2.0.0-beta.1".
+type Version struct {
+	semver.Version
+}
+
+func (v *Version) UnmarshalTOML(value any) error {
+	s, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("expected a version string, found %s", tomlValueType(value))
+	}
+	version, err := semver.StrictNewVersion(s)
+	if err != nil {
+		return fmt.Errorf("'%s' is not a semantic version", s)
+	}
+	v.Version = *version
+	return nil
+}
+
+// Duration is a time.Duration, decoded from a string such as "1m30s" or from an integer number of seconds, which is
+// how durations such as the timeouts of Settings.toml are written.
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalTOML(value any) error {
+	switch v := value.(type) {
+	case string:
+		duration, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("'%s' is not a duration", v)
+		}
+		d.Duration = duration
+	case int64:
+		d.Duration = time.Duration(v) * time.Second
+	default:
+		return fmt.Errorf("expected a duration string or a number of seconds, found %s", tomlValueType(value))
+	}
+	return nil
+}
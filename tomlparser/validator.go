@@ -17,22 +17,52 @@
 package tomlparser
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"ballerina-lang-go/tools/diagnostics"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
 )
 
+// messageKeyword is the schema keyword holding custom error messages, keyed by the failing keyword:
+//
+//	"org": {"type": "string", "pattern": "^[a-z]+$", "message": {"pattern": "invalid 'org': ..."}}
+const messageKeyword = "message"
+
 type Validator interface {
+	// Validate validates the document against the schema. Every failing key is reported as a diagnostic of the
+	// document located at the key; the returned error summarizes the failure. If every failure is reported as a
+	// warning, the document is valid and no error is returned.
 	Validate(toml *Toml) error
 }
 
+// ValidatorOptions configures how a Validator reports failures.
+type ValidatorOptions struct {
+	// UnknownKeysAsWarnings reports keys that the schema does not allow as warnings instead of errors.
+	UnknownKeysAsWarnings bool
+}
+
 type validatorImpl struct {
-	schema Schema
+	schema  Schema
+	options ValidatorOptions
 }
 
 func NewValidator(schema Schema) Validator {
+	return NewValidatorWithOptions(schema, ValidatorOptions{})
+}
+
+func NewValidatorWithOptions(schema Schema, options ValidatorOptions) Validator {
 	return &validatorImpl{
-		schema: schema,
+		schema:  schema,
+		options: options,
 	}
 }
 
@@ -44,13 +74,292 @@ func (v *validatorImpl) Validate(toml *Toml) error {
 	data := toml.ToMap()
 
 	if err := v.schema.Validate(data); err != nil {
-		diagnostic := Diagnostic{
-			Message:  err.Error(),
-			Severity: diagnostics.Error,
+		found := v.validationDiagnostics(toml, err)
+		toml.diagnostics = append(toml.diagnostics, found...)
+		for _, diagnostic := range found {
+			if diagnostic.Severity == diagnostics.Error {
+				return err
+			}
 		}
-		toml.diagnostics = append(toml.diagnostics, diagnostic)
-		return err
 	}
 
 	return nil
 }
+
+// validationDiagnostics reports one diagnostic per failing instance path of a validation error.
+func (v *validatorImpl) validationDiagnostics(toml *Toml, err error) []Diagnostic {
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return []Diagnostic{{Message: err.Error(), Severity: diagnostics.Error}}
+	}
+
+	var document any
+	if schema, ok := v.schema.(*schemaImpl); ok {
+		document = schema.document
+	}
+	c := &diagnosticCollector{toml: toml, document: document, paths: make(map[string]int), unknownKeySeverity: diagnostics.Error}
+	if v.options.UnknownKeysAsWarnings {
+		c.unknownKeySeverity = diagnostics.Warning
+	}
+	c.walk(validationErr)
+
+	sortDiagnostics(c.diagnostics)
+	return c.diagnostics
+}
+
+type diagnosticCollector struct {
+	toml        *Toml
+	document    any
+	diagnostics []Diagnostic
+	// paths maps an instance path to the index of its diagnostic, so that the alternatives of anyOf and oneOf
+	// failing at the same path are reported once.
+	paths map[string]int
+	// types holds the expected types reported for each path, which are merged into one message.
+	types map[string][]string
+	// unknownKeySeverity is the severity of keys not allowed by the schema.
+	unknownKeySeverity diagnostics.DiagnosticSeverity
+}
+
+func (c *diagnosticCollector) walk(e *jsonschema.ValidationError) {
+	if len(e.Causes) > 0 {
+		for _, cause := range e.Causes {
+			c.walk(cause)
+		}
+		return
+	}
+
+	path := e.InstanceLocation
+	switch k := e.ErrorKind.(type) {
+	case *kind.Required:
+		for _, key := range k.Missing {
+			c.add(append(clonePath(path), key), path, c.customMessage(e, "required",
+				fmt.Sprintf("missing required key '%s'", key)))
+		}
+	case *kind.AdditionalProperties:
+		for _, key := range k.Properties {
+			keyPath := append(clonePath(path), key)
+			c.addWithSeverity(keyPath, keyPath, c.customMessage(e, "additionalProperties",
+				fmt.Sprintf("unknown key '%s'", key)), c.unknownKeySeverity)
+		}
+	case *kind.Type:
+		key := joinKeyPath(path)
+		if c.types == nil {
+			c.types = make(map[string][]string)
+		}
+		c.types[key] = append(c.types[key], k.Want...)
+		found := tomlTypeName(k.Got)
+		if value, ok := c.value(path); ok {
+			found = tomlValueType(value)
+		}
+		msg := fmt.Sprintf("invalid type for %s: expected %s, found %s",
+			describePath(path), joinTypes(c.types[key]), found)
+		if index, ok := c.paths[key]; ok {
+			c.diagnostics[index].Message = c.customMessage(e, "type", msg)
+			return
+		}
+		c.add(path, path, c.customMessage(e, "type", msg))
+	case *kind.Enum:
+		want := make([]string, 0, len(k.Want))
+		for _, value := range k.Want {
+			want = append(want, fmt.Sprintf("'%v'", value))
+		}
+		c.add(path, path, c.customMessage(e, "enum",
+			fmt.Sprintf("invalid value for %s: expected one of %s", describePath(path), strings.Join(want, ", "))))
+	case *kind.Pattern:
+		c.add(path, path, c.customMessage(e, "pattern",
+			fmt.Sprintf("invalid value '%s' for %s: does not match pattern '%s'", k.Got, describePath(path), k.Want)))
+	case *kind.InvalidJsonValue:
+		c.add(path, path, fmt.Sprintf("unsupported value for %s", describePath(path)))
+	default:
+		keyword := ""
+		if keywordPath := e.ErrorKind.KeywordPath(); len(keywordPath) > 0 {
+			keyword = keywordPath[0]
+		}
+		detail := e.ErrorKind.LocalizedString(message.NewPrinter(language.English))
+		c.add(path, path, c.customMessage(e, keyword, fmt.Sprintf("invalid value for %s: %s", describePath(path), detail)))
+	}
+}
+
+// add records a diagnostic for the instance path, located at the key of locationPath or, if it does not exist,
+// at its closest defined parent.
+func (c *diagnosticCollector) add(path, locationPath []string, msg string) {
+	c.addWithSeverity(path, locationPath, msg, diagnostics.Error)
+}
+
+func (c *diagnosticCollector) addWithSeverity(path, locationPath []string, msg string, severity diagnostics.DiagnosticSeverity) {
+	key := joinKeyPath(path)
+	if _, ok := c.paths[key]; ok {
+		return
+	}
+	c.paths[key] = len(c.diagnostics)
+	c.diagnostics = append(c.diagnostics, Diagnostic{
+		Message:  msg,
+		Severity: severity,
+		Location: c.location(locationPath),
+	})
+}
+
+func (c *diagnosticCollector) location(path []string) *Location {
+	for i := len(path); i > 0; i-- {
+		if location, ok := c.toml.KeyLocation(path[:i]...); ok {
+			return location
+		}
+	}
+	return nil
+}
+
+// customMessage returns the message given for the keyword by the failing schema, or fallback if there is none.
+func (c *diagnosticCollector) customMessage(e *jsonschema.ValidationError, keyword, fallback string) string {
+	_, fragment, ok := strings.Cut(e.SchemaURL, "#")
+	if !ok || c.document == nil {
+		return fallback
+	}
+	schema := c.document
+	for _, token := range strings.Split(strings.TrimPrefix(fragment, "/"), "/") {
+		if token == "" {
+			continue
+		}
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch node := schema.(type) {
+		case map[string]any:
+			schema = node[token]
+		case []any:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(node) {
+				return fallback
+			}
+			schema = node[index]
+		default:
+			return fallback
+		}
+	}
+	node, ok := schema.(map[string]any)
+	if !ok {
+		return fallback
+	}
+	messages, ok := node[messageKeyword].(map[string]any)
+	if !ok {
+		return fallback
+	}
+	if msg, ok := messages[keyword].(string); ok {
+		return msg
+	}
+	return fallback
+}
+
+// value returns the value at the instance path in the document.
+func (c *diagnosticCollector) value(path []string) (any, bool) {
+	current := any(c.toml.ToMap())
+	for _, segment := range path {
+		switch node := current.(type) {
+		case map[string]any:
+			value, ok := node[segment]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []any:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		case []map[string]any:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// sortDiagnostics sorts diagnostics by their location, placing the diagnostics without a location first.
+func sortDiagnostics(diagnostics []Diagnostic) {
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		a, b := diagnostics[i].Location, diagnostics[j].Location
+		if a == nil || b == nil {
+			return a == nil && b != nil
+		}
+		if a.StartLine != b.StartLine {
+			return a.StartLine < b.StartLine
+		}
+		return a.StartColumn < b.StartColumn
+	})
+}
+
+func clonePath(path []string) []string {
+	return append([]string(nil), path...)
+}
+
+// describePath describes an instance path as a dotted key, such as key 'package.org' or element 'license[1]'.
+func describePath(path []string) string {
+	if len(path) == 0 {
+		return "the document"
+	}
+	var sb strings.Builder
+	for i, segment := range path {
+		if _, err := strconv.Atoi(segment); err == nil && i > 0 {
+			sb.WriteString("[" + segment + "]")
+			continue
+		}
+		if i > 0 {
+			sb.WriteString(".")
+		}
+		sb.WriteString(segment)
+	}
+	if _, err := strconv.Atoi(path[len(path)-1]); err == nil && len(path) > 1 {
+		return "element '" + sb.String() + "'"
+	}
+	return "key '" + sb.String() + "'"
+}
+
+func joinTypes(types []string) string {
+	names := make([]string, 0, len(types))
+	seen := make(map[string]bool)
+	for _, t := range types {
+		name := tomlTypeName(t)
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, " or ")
+}
+
+// tomlValueType returns the TOML type of a decoded value.
+func tomlValueType(value any) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case int64:
+		return "integer"
+	case float64:
+		return "float"
+	case bool:
+		return "boolean"
+	case map[string]any:
+		return "table"
+	case []any, []map[string]any:
+		return "array"
+	case time.Time:
+		return "datetime"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// tomlTypeName returns the TOML name of a JSON schema type.
+func tomlTypeName(jsonType string) string {
+	switch jsonType {
+	case "object":
+		return "table"
+	case "null":
+		return "empty value"
+	default:
+		return jsonType
+	}
+}
@@ -17,11 +17,15 @@
 package tomlparser
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"ballerina-lang-go/tools/diagnostics"
+
+	"golang.org/x/tools/txtar"
 )
 
 var fsys = os.DirFS(".")
@@ -413,3 +417,63 @@ func TestSchemaInvalidJSON(t *testing.T) {
 		t.Error("Should fail for invalid JSON schema")
 	}
 }
+
+// TestValidationDiagnostics runs the golden tests in testdata/validation. Each archive contains a schema, a TOML
+// document and the diagnostics reported for it. Set BLESS=1 to update the expected diagnostics.
+func TestValidationDiagnostics(t *testing.T) {
+	bless := os.Getenv("BLESS") == "1" || os.Getenv("BLESS") == "true"
+
+	files, err := filepath.Glob(filepath.Join("testdata", "validation", "*.txtar"))
+	if err != nil {
+		t.Fatalf("failed to list golden files: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no golden files found in testdata/validation")
+	}
+
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".txtar")
+		t.Run(name, func(t *testing.T) {
+			archive, err := txtar.ParseFile(file)
+			if err != nil {
+				t.Fatalf("failed to parse %s: %v", file, err)
+			}
+			sections := make(map[string]string)
+			for _, f := range archive.Files {
+				sections[f.Name] = string(f.Data)
+			}
+
+			schema, err := NewSchemaFromString(sections["schema.json"])
+			if err != nil {
+				t.Fatalf("invalid schema: %v", err)
+			}
+			tomlDoc, _ := ReadStringWithSchema(sections["input.toml"], schema)
+
+			var sb strings.Builder
+			for _, d := range tomlDoc.Diagnostics() {
+				location := "-"
+				if d.Location != nil {
+					location = fmt.Sprintf("%d:%d-%d:%d", d.Location.StartLine, d.Location.StartColumn, d.Location.EndLine, d.Location.EndColumn)
+				}
+				fmt.Fprintf(&sb, "%s %s %s\n", d.Severity, location, d.Message)
+			}
+			actual := sb.String()
+
+			if bless {
+				for i := range archive.Files {
+					if archive.Files[i].Name == "diagnostics" {
+						archive.Files[i].Data = []byte(actual)
+					}
+				}
+				if err := os.WriteFile(file, txtar.Format(archive), 0o644); err != nil {
+					t.Fatalf("failed to update %s: %v", file, err)
+				}
+				return
+			}
+
+			if expected := sections["diagnostics"]; actual != expected {
+				t.Errorf("diagnostics mismatch:\nwant:\n%s\ngot:\n%s", expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package codeaction
+
+import (
+	"sync"
+
+	"ballerina-lang-go/tools/diagnostics"
+	"ballerina-lang-go/tools/text"
+)
+
+// CodeActionRegistry dispatches diagnostics to the providers registered for their codes.
+type CodeActionRegistry interface {
+	Register(provider CodeActionProvider)
+	// CodeActions returns the code actions of every provider registered for the code of the diagnostic, in
+	// registration order.
+	CodeActions(diagnostic diagnostics.Diagnostic, document text.TextDocument) []CodeAction
+}
+
+type codeActionRegistryImpl struct {
+	mu        sync.RWMutex
+	providers map[string][]CodeActionProvider
+}
+
+func NewCodeActionRegistry() CodeActionRegistry {
+	return &codeActionRegistryImpl{
+		providers: make(map[string][]CodeActionProvider),
+	}
+}
+
+func (r *codeActionRegistryImpl) Register(provider CodeActionProvider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, code := range provider.SupportedDiagnosticCodes() {
+		r.providers[code] = append(r.providers[code], provider)
+	}
+}
+
+func (r *codeActionRegistryImpl) CodeActions(diagnostic diagnostics.Diagnostic, document text.TextDocument) []CodeAction {
+	code := diagnostic.DiagnosticInfo().Code()
+	if code == "" {
+		return nil
+	}
+	r.mu.RLock()
+	providers := r.providers[code]
+	r.mu.RUnlock()
+
+	ctx := CodeActionContext{Diagnostic: diagnostic, Document: document}
+	var actions []CodeAction
+	for _, provider := range providers {
+		actions = append(actions, provider.CodeActions(ctx)...)
+	}
+	return actions
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package codeaction provides the framework that turns diagnostics into fixes. Providers registered for a
+// diagnostic code read the positional properties of the diagnostic and produce text.TextDocumentChange edits.
+package codeaction
+
+import (
+	"ballerina-lang-go/tools/diagnostics"
+	"ballerina-lang-go/tools/text"
+)
+
+// CodeAction is a fix that can be applied to the document the diagnostic was reported on.
+type CodeAction interface {
+	Title() string
+	Diagnostic() diagnostics.Diagnostic
+	Change() text.TextDocumentChange
+}
+
+type codeActionImpl struct {
+	title      string
+	diagnostic diagnostics.Diagnostic
+	change     text.TextDocumentChange
+}
+
+func NewCodeAction(title string, diagnostic diagnostics.Diagnostic, change text.TextDocumentChange) CodeAction {
+	return &codeActionImpl{
+		title:      title,
+		diagnostic: diagnostic,
+		change:     change,
+	}
+}
+
+func (ca *codeActionImpl) Title() string {
+	return ca.title
+}
+
+func (ca *codeActionImpl) Diagnostic() diagnostics.Diagnostic {
+	return ca.diagnostic
+}
+
+func (ca *codeActionImpl) Change() text.TextDocumentChange {
+	return ca.change
+}
+
+// CodeActionContext holds the diagnostic a code action is requested for and the document it was reported on.
+type CodeActionContext struct {
+	Diagnostic diagnostics.Diagnostic
+	Document   text.TextDocument
+}
+
+// Offset returns the text position where the diagnostic starts.
+func (ctx CodeActionContext) Offset() (int, bool) {
+	location := ctx.Diagnostic.Location()
+	if location == nil {
+		return 0, false
+	}
+	if textRange := location.TextRange(); textRange != nil {
+		return textRange.StartOffset(), true
+	}
+	if location.LineRange() == nil || ctx.Document == nil {
+		return 0, false
+	}
+	offset, err := ctx.Document.Lines().TextPositionFromLinePosition(location.LineRange().StartLine())
+	if err != nil {
+		return 0, false
+	}
+	return offset, true
+}
+
+// Property returns the value of the property at the given index if it has the expected kind and type.
+// Properties are positional; each diagnostic code documents the properties it carries.
+func Property[T any](diagnostic diagnostics.Diagnostic, index int, kind diagnostics.DiagnosticPropertyKind) (T, bool) {
+	var zero T
+	properties := diagnostic.Properties()
+	if index < 0 || index >= len(properties) || properties[index] == nil || properties[index].Kind() != kind {
+		return zero, false
+	}
+	value, ok := properties[index].Value().(T)
+	return value, ok
+}
+
+// CodeActionProvider produces code actions for the diagnostic codes it supports.
+type CodeActionProvider interface {
+	SupportedDiagnosticCodes() []string
+	CodeActions(ctx CodeActionContext) []CodeAction
+}
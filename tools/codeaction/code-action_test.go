@@ -0,0 +1,113 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package codeaction
+
+import (
+	"testing"
+
+	"ballerina-lang-go/tools/diagnostics"
+	"ballerina-lang-go/tools/text"
+)
+
+type stubProvider struct {
+	codes []string
+	title string
+}
+
+func (p *stubProvider) SupportedDiagnosticCodes() []string {
+	return p.codes
+}
+
+func (p *stubProvider) CodeActions(ctx CodeActionContext) []CodeAction {
+	return []CodeAction{NewCodeAction(p.title, ctx.Diagnostic, text.TextDocumentChangeFromTextEdits(nil))}
+}
+
+func newTestDiagnostic(code string, location diagnostics.Location, properties ...diagnostics.DiagnosticProperty[any]) diagnostics.Diagnostic {
+	var codePtr *string
+	if code != "" {
+		codePtr = &code
+	}
+	return diagnostics.CreateDiagnosticWithProperties(diagnostics.NewDiagnosticInfo(codePtr, "test", diagnostics.Error), location, properties)
+}
+
+func TestCodeActionRegistry(t *testing.T) {
+	registry := NewCodeActionRegistry()
+	registry.Register(&stubProvider{codes: []string{"BCE2000", "BCE2001"}, title: "first"})
+	registry.Register(&stubProvider{codes: []string{"BCE2000"}, title: "second"})
+
+	actions := registry.CodeActions(newTestDiagnostic("BCE2000", nil), nil)
+	if len(actions) != 2 || actions[0].Title() != "first" || actions[1].Title() != "second" {
+		t.Errorf("expected the actions of both providers in registration order, got %v", actions)
+	}
+	if actions := registry.CodeActions(newTestDiagnostic("BCE2001", nil), nil); len(actions) != 1 {
+		t.Errorf("expected one action for BCE2001, got %d", len(actions))
+	}
+	if actions := registry.CodeActions(newTestDiagnostic("BCE9999", nil), nil); len(actions) != 0 {
+		t.Errorf("expected no actions for an unsupported code, got %d", len(actions))
+	}
+	if actions := registry.CodeActions(newTestDiagnostic("", nil), nil); len(actions) != 0 {
+		t.Errorf("expected no actions for a diagnostic without a code, got %d", len(actions))
+	}
+}
+
+func TestProperty(t *testing.T) {
+	diagnostic := newTestDiagnostic("BCE2066", nil,
+		diagnostics.NewDiagnosticProperty[any](diagnostics.Symbolic, "int"),
+		diagnostics.NewDiagnosticProperty[any](diagnostics.Numeric, 42))
+
+	if value, ok := Property[string](diagnostic, 0, diagnostics.Symbolic); !ok || value != "int" {
+		t.Errorf("Property(0) = %q, %v, want \"int\"", value, ok)
+	}
+	if value, ok := Property[int](diagnostic, 1, diagnostics.Numeric); !ok || value != 42 {
+		t.Errorf("Property(1) = %d, %v, want 42", value, ok)
+	}
+	if _, ok := Property[string](diagnostic, 0, diagnostics.String); ok {
+		t.Error("Property() should check the property kind")
+	}
+	if _, ok := Property[string](diagnostic, 1, diagnostics.Numeric); ok {
+		t.Error("Property() should check the value type")
+	}
+	if _, ok := Property[string](diagnostic, 2, diagnostics.Symbolic); ok {
+		t.Error("Property() should reject an out of range index")
+	}
+}
+
+func TestCodeActionContextOffset(t *testing.T) {
+	document := text.TextDocumentFromText("line one\nline two\n")
+	lineRange := text.LineRangeFromLinePositions("main.bal",
+		text.LinePositionFromLineAndOffset(1, 5), text.LinePositionFromLineAndOffset(1, 8))
+
+	withTextRange := CodeActionContext{
+		Diagnostic: newTestDiagnostic("BCE2066", diagnostics.NewLocation(lineRange, text.TextRangeFromStartOffsetAndLength(14, 3))),
+		Document:   document,
+	}
+	if offset, ok := withTextRange.Offset(); !ok || offset != 14 {
+		t.Errorf("Offset() = %d, %v, want 14", offset, ok)
+	}
+
+	withLineRange := CodeActionContext{
+		Diagnostic: newTestDiagnostic("BCE2066", diagnostics.NewLocation(lineRange, nil)),
+		Document:   document,
+	}
+	if offset, ok := withLineRange.Offset(); !ok || offset != 14 {
+		t.Errorf("Offset() from line range = %d, %v, want 14", offset, ok)
+	}
+
+	if _, ok := (CodeActionContext{Diagnostic: newTestDiagnostic("BCE2066", nil)}).Offset(); ok {
+		t.Error("Offset() should fail without a location")
+	}
+}
@@ -33,15 +33,35 @@ type defaultDiagnosticImpl struct {
 	diagnosticInfo DiagnosticInfo
 	location       Location
 	properties     []DiagnosticProperty[any]
+	relatedInfo    []DiagnosticRelatedInformation
 	message        string
 }
 
 func NewDefaultDiagnostic(diagnosticInfo DiagnosticInfo, location Location, properties []DiagnosticProperty[any], args ...any) DefaultDiagnostic {
+	return NewDefaultDiagnosticWithRelatedInformation(diagnosticInfo, location, nil, properties, args...)
+}
+
+// NewDefaultDiagnosticWithRelatedInformation creates a DefaultDiagnostic that also carries the locations and
+// messages related to it.
+func NewDefaultDiagnosticWithRelatedInformation(diagnosticInfo DiagnosticInfo, location Location, relatedInfo []DiagnosticRelatedInformation, properties []DiagnosticProperty[any], args ...any) DefaultDiagnostic {
 	message := formatMessage(diagnosticInfo.MessageFormat(), args...)
 	return &defaultDiagnosticImpl{
 		diagnosticInfo: diagnosticInfo,
 		location:       location,
 		properties:     properties,
+		relatedInfo:    relatedInfo,
+		message:        message,
+	}
+}
+
+// newDefaultDiagnosticWithMessage creates a DefaultDiagnostic with an already formatted message. It is used when
+// reading diagnostics back from an exported form where the message arguments are no longer available.
+func newDefaultDiagnosticWithMessage(diagnosticInfo DiagnosticInfo, location Location, relatedInfo []DiagnosticRelatedInformation, properties []DiagnosticProperty[any], message string) DefaultDiagnostic {
+	return &defaultDiagnosticImpl{
+		diagnosticInfo: diagnosticInfo,
+		location:       location,
+		properties:     properties,
+		relatedInfo:    relatedInfo,
 		message:        message,
 	}
 }
@@ -62,8 +82,21 @@ func (dd *defaultDiagnosticImpl) Properties() []DiagnosticProperty[any] {
 	return dd.properties
 }
 
+func (dd *defaultDiagnosticImpl) RelatedInformation() []DiagnosticRelatedInformation {
+	return dd.relatedInfo
+}
+
 func (dd *defaultDiagnosticImpl) String() string {
-	lineRange := dd.location.LineRange()
+	return diagnosticString(dd)
+}
+
+// diagnosticString formats a diagnostic with its severity, one-based location and message. The location is
+// omitted for diagnostics that do not refer to a source, such as a missing configuration value.
+func diagnosticString(d Diagnostic) string {
+	if d.Location() == nil || d.Location().LineRange() == nil {
+		return fmt.Sprintf("%s %s", d.DiagnosticInfo().Severity().String(), d.Message())
+	}
+	lineRange := d.Location().LineRange()
 	filePath := lineRange.FileName()
 
 	startLine := lineRange.StartLine()
@@ -74,10 +107,10 @@ func (dd *defaultDiagnosticImpl) String() string {
 	oneBasedLineRange := text.LineRangeFromLinePositions(filePath, oneBasedStartLine, oneBasedEndLine)
 
 	return fmt.Sprintf("%s [%s:%s] %s",
-		dd.diagnosticInfo.Severity().String(),
+		d.DiagnosticInfo().Severity().String(),
 		filePath,
 		oneBasedLineRange.String(),
-		dd.Message())
+		d.Message())
 }
 
 func formatMessage(format string, args ...any) string {
@@ -0,0 +1,253 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diagnostics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"ballerina-lang-go/tools/text"
+)
+
+// CollectorOptions configures a DiagnosticCollector.
+type CollectorOptions struct {
+	// MaxErrors is the number of errors reported by Diagnostics. Zero means no limit.
+	MaxErrors int
+	// WarningsAsErrors reports every warning as an error.
+	WarningsAsErrors bool
+}
+
+// DiagnosticSummary counts the diagnostics of a DiagnosticCollector per severity.
+type DiagnosticSummary struct {
+	Errors   int
+	Warnings int
+	Infos    int
+	Hints    int
+	Internal int
+	// Omitted is the number of errors left out by the MaxErrors cutoff.
+	Omitted int
+}
+
+// HasErrors reports whether any error was collected.
+func (s DiagnosticSummary) HasErrors() bool {
+	return s.Errors > 0
+}
+
+// ExitCode returns the exit code of a command that reported the summarized diagnostics.
+func (s DiagnosticSummary) ExitCode() int {
+	if s.HasErrors() {
+		return 1
+	}
+	return 0
+}
+
+func (s DiagnosticSummary) String() string {
+	var parts []string
+	for _, count := range []struct {
+		n    int
+		name string
+	}{
+		{s.Errors, "error"},
+		{s.Warnings, "warning"},
+		{s.Infos, "info"},
+		{s.Hints, "hint"},
+		{s.Internal, "internal error"},
+	} {
+		switch {
+		case count.n == 1:
+			parts = append(parts, fmt.Sprintf("1 %s", count.name))
+		case count.n > 1:
+			parts = append(parts, fmt.Sprintf("%d %ss", count.n, count.name))
+		}
+	}
+	if len(parts) == 0 {
+		return "no diagnostics"
+	}
+	summary := strings.Join(parts, ", ")
+	if s.Omitted > 0 {
+		summary += fmt.Sprintf(" (%d errors not shown)", s.Omitted)
+	}
+	return summary
+}
+
+// DiagnosticCollector gathers the diagnostics reported while compiling. It is safe for concurrent use.
+type DiagnosticCollector interface {
+	// Add collects the diagnostic and reports whether it was new.
+	Add(diagnostic Diagnostic) bool
+	AddAll(diagnostics []Diagnostic)
+	// Diagnostics returns the collected diagnostics sorted by file and position, without the errors beyond the
+	// MaxErrors cutoff.
+	Diagnostics() []Diagnostic
+	Summary() DiagnosticSummary
+	// LimitReached reports whether the MaxErrors cutoff was reached, so that producers can stop early.
+	LimitReached() bool
+}
+
+// diagnosticKey identifies duplicate diagnostics. DiagnosticInfoLookupKey holds the code by pointer, so the code is
+// dereferenced to match diagnostics created from different DiagnosticInfo values.
+type diagnosticKey struct {
+	code          string
+	messageFormat string
+	severity      DiagnosticSeverity
+	fileName      string
+	lineRange     text.LineRangeLookupKey
+}
+
+type diagnosticCollectorImpl struct {
+	mu          sync.Mutex
+	options     CollectorOptions
+	seen        map[diagnosticKey]bool
+	diagnostics []Diagnostic
+	counts      map[DiagnosticSeverity]int
+}
+
+func NewDiagnosticCollector(options CollectorOptions) DiagnosticCollector {
+	return &diagnosticCollectorImpl{
+		options: options,
+		seen:    make(map[diagnosticKey]bool),
+		counts:  make(map[DiagnosticSeverity]int),
+	}
+}
+
+func (c *diagnosticCollectorImpl) Add(diagnostic Diagnostic) bool {
+	if c.options.WarningsAsErrors && diagnostic.DiagnosticInfo().Severity() == Warning {
+		diagnostic = WithSeverity(diagnostic, Error)
+	}
+	key := newDiagnosticKey(diagnostic)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.seen[key] {
+		return false
+	}
+	c.seen[key] = true
+	c.diagnostics = append(c.diagnostics, diagnostic)
+	c.counts[diagnostic.DiagnosticInfo().Severity()]++
+	return true
+}
+
+func (c *diagnosticCollectorImpl) AddAll(diagnostics []Diagnostic) {
+	for _, diagnostic := range diagnostics {
+		c.Add(diagnostic)
+	}
+}
+
+func (c *diagnosticCollectorImpl) Diagnostics() []Diagnostic {
+	c.mu.Lock()
+	sorted := make([]Diagnostic, len(c.diagnostics))
+	copy(sorted, c.diagnostics)
+	c.mu.Unlock()
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return compareDiagnostics(sorted[i], sorted[j]) < 0
+	})
+	if c.options.MaxErrors <= 0 {
+		return sorted
+	}
+
+	result := sorted[:0]
+	errors := 0
+	for _, diagnostic := range sorted {
+		if diagnostic.DiagnosticInfo().Severity() == Error {
+			errors++
+			if errors > c.options.MaxErrors {
+				continue
+			}
+		}
+		result = append(result, diagnostic)
+	}
+	return result
+}
+
+func (c *diagnosticCollectorImpl) Summary() DiagnosticSummary {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	summary := DiagnosticSummary{
+		Errors:   c.counts[Error],
+		Warnings: c.counts[Warning],
+		Infos:    c.counts[Info],
+		Hints:    c.counts[Hint],
+		Internal: c.counts[Internal],
+	}
+	if c.options.MaxErrors > 0 && summary.Errors > c.options.MaxErrors {
+		summary.Omitted = summary.Errors - c.options.MaxErrors
+	}
+	return summary
+}
+
+func (c *diagnosticCollectorImpl) LimitReached() bool {
+	if c.options.MaxErrors <= 0 {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[Error] >= c.options.MaxErrors
+}
+
+func newDiagnosticKey(diagnostic Diagnostic) diagnosticKey {
+	info := diagnostic.DiagnosticInfo()
+	key := diagnosticKey{
+		code:          info.Code(),
+		messageFormat: info.MessageFormat(),
+		severity:      info.Severity(),
+	}
+	if location := diagnostic.Location(); location != nil && location.LineRange() != nil {
+		key.fileName = location.LineRange().FileName()
+		key.lineRange = location.LineRange().LineRangeLookupKey()
+	}
+	return key
+}
+
+// compareDiagnostics orders diagnostics by file, start and end position, with diagnostics without a location first.
+// Diagnostics at the same position are ordered by decreasing severity and then by code.
+func compareDiagnostics(a, b Diagnostic) int {
+	aRange, bRange := lineRangeOf(a), lineRangeOf(b)
+	switch {
+	case aRange == nil && bRange != nil:
+		return -1
+	case aRange != nil && bRange == nil:
+		return 1
+	case aRange != nil && bRange != nil:
+		if c := strings.Compare(aRange.FileName(), bRange.FileName()); c != 0 {
+			return c
+		}
+		for _, pair := range [][2]text.LinePosition{
+			{aRange.StartLine(), bRange.StartLine()},
+			{aRange.EndLine(), bRange.EndLine()},
+		} {
+			if c := pair[0].Line() - pair[1].Line(); c != 0 {
+				return c
+			}
+			if c := pair[0].Offset() - pair[1].Offset(); c != 0 {
+				return c
+			}
+		}
+	}
+	if c := int(b.DiagnosticInfo().Severity()) - int(a.DiagnosticInfo().Severity()); c != 0 {
+		return c
+	}
+	return strings.Compare(a.DiagnosticInfo().Code(), b.DiagnosticInfo().Code())
+}
+
+func lineRangeOf(diagnostic Diagnostic) text.LineRange {
+	if location := diagnostic.Location(); location != nil {
+		return location.LineRange()
+	}
+	return nil
+}
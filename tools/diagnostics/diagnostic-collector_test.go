@@ -0,0 +1,142 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diagnostics
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"ballerina-lang-go/tools/text"
+)
+
+func newCollectorTestDiagnostic(code string, severity DiagnosticSeverity, file string, line, offset int, args ...any) Diagnostic {
+	lineRange := text.LineRangeFromLinePositions(file,
+		text.LinePositionFromLineAndOffset(line, offset), text.LinePositionFromLineAndOffset(line, offset+1))
+	return CreateDiagnostic(NewDiagnosticInfo(&code, "message %v", severity), NewLocation(lineRange, nil), args...)
+}
+
+func TestDiagnosticCollectorDeduplicatesConcurrentProducers(t *testing.T) {
+	collector := NewDiagnosticCollector(CollectorOptions{})
+
+	var wg sync.WaitGroup
+	for producer := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 50 {
+				// Every producer reports the same cascade; only the first report of each must be kept.
+				collector.Add(newCollectorTestDiagnostic("BCE2066", Error, "main.bal", i, 4, producer))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := len(collector.Diagnostics()); got != 50 {
+		t.Errorf("expected 50 unique diagnostics, got %d", got)
+	}
+	if summary := collector.Summary(); summary.Errors != 50 {
+		t.Errorf("summary counted %d errors, want 50", summary.Errors)
+	}
+}
+
+func TestDiagnosticCollectorSortsByFileAndPosition(t *testing.T) {
+	collector := NewDiagnosticCollector(CollectorOptions{})
+	collector.AddAll([]Diagnostic{
+		newCollectorTestDiagnostic("BCW1001", Warning, "util.bal", 0, 0),
+		newCollectorTestDiagnostic("BCE2066", Error, "main.bal", 3, 8),
+		newCollectorTestDiagnostic("BCH2000", Hint, "main.bal", 3, 2),
+		newCollectorTestDiagnostic("BCW1001", Warning, "main.bal", 3, 2),
+		newCollectorTestDiagnostic("BCE2010", Error, "main.bal", 1, 10),
+		CreateDiagnostic(NewDiagnosticInfo(nil, "no location", Error), nil),
+	})
+
+	var got []string
+	for _, diagnostic := range collector.Diagnostics() {
+		location := "-"
+		if lineRange := lineRangeOf(diagnostic); lineRange != nil {
+			location = fmt.Sprintf("%s:%s", lineRange.FileName(), lineRange.StartLine())
+		}
+		got = append(got, location+" "+diagnostic.DiagnosticInfo().Code())
+	}
+	want := []string{
+		"- ",
+		"main.bal:1:10 BCE2010",
+		"main.bal:3:2 BCW1001",
+		"main.bal:3:2 BCH2000",
+		"main.bal:3:8 BCE2066",
+		"util.bal:0:0 BCW1001",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("unexpected order:\ngot:  %v\nwant: %v", got, want)
+	}
+}
+
+func TestDiagnosticCollectorMaxErrors(t *testing.T) {
+	collector := NewDiagnosticCollector(CollectorOptions{MaxErrors: 2})
+	for i := 4; i >= 0; i-- {
+		if collector.LimitReached() != (i < 3) {
+			t.Errorf("LimitReached() = %v after %d errors", collector.LimitReached(), 4-i)
+		}
+		collector.Add(newCollectorTestDiagnostic("BCE2066", Error, "main.bal", i, 0))
+	}
+	collector.Add(newCollectorTestDiagnostic("BCW1001", Warning, "main.bal", 9, 0))
+
+	diagnostics := collector.Diagnostics()
+	if len(diagnostics) != 3 {
+		t.Fatalf("expected 2 errors and 1 warning, got %d diagnostics", len(diagnostics))
+	}
+	if line := lineRangeOf(diagnostics[1]).StartLine().Line(); line != 1 {
+		t.Errorf("the first errors by position should be kept, got an error on line %d", line)
+	}
+
+	summary := collector.Summary()
+	if summary.Errors != 5 || summary.Omitted != 3 || summary.Warnings != 1 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+	if want := "5 errors, 1 warning (3 errors not shown)"; summary.String() != want {
+		t.Errorf("String() = %q, want %q", summary.String(), want)
+	}
+}
+
+func TestDiagnosticCollectorWarningsAsErrors(t *testing.T) {
+	collector := NewDiagnosticCollector(CollectorOptions{WarningsAsErrors: true})
+	collector.Add(newCollectorTestDiagnostic("BCW1001", Warning, "main.bal", 0, 0))
+	collector.Add(newCollectorTestDiagnostic("BCH2000", Hint, "main.bal", 1, 0))
+
+	summary := collector.Summary()
+	if summary.Errors != 1 || summary.Warnings != 0 || summary.Hints != 1 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+	if summary.ExitCode() != 1 {
+		t.Errorf("ExitCode() = %d, want 1", summary.ExitCode())
+	}
+	if severity := collector.Diagnostics()[0].DiagnosticInfo().Severity(); severity != Error {
+		t.Errorf("warning should be reported as an error, got %s", severity)
+	}
+}
+
+func TestDiagnosticSummaryWithoutErrors(t *testing.T) {
+	collector := NewDiagnosticCollector(CollectorOptions{})
+	if summary := collector.Summary(); summary.ExitCode() != 0 || summary.String() != "no diagnostics" {
+		t.Errorf("unexpected empty summary: %q exit code %d", summary.String(), summary.ExitCode())
+	}
+	collector.Add(newCollectorTestDiagnostic("BCW1001", Warning, "main.bal", 0, 0))
+	if summary := collector.Summary(); summary.ExitCode() != 0 || summary.String() != "1 warning" {
+		t.Errorf("unexpected summary: %q exit code %d", summary.String(), summary.ExitCode())
+	}
+}
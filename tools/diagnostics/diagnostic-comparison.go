@@ -0,0 +1,62 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diagnostics
+
+// DiagnosticComparison is the result of comparing the diagnostics reported by two runs.
+type DiagnosticComparison struct {
+	// Introduced holds the diagnostics reported only by the current run.
+	Introduced []Diagnostic
+	// Resolved holds the diagnostics reported only by the baseline run.
+	Resolved []Diagnostic
+	// Unchanged holds the diagnostics of the current run that were also reported by the baseline run.
+	Unchanged []Diagnostic
+}
+
+// HasChanges reports whether the two runs reported different diagnostics.
+func (dc DiagnosticComparison) HasChanges() bool {
+	return len(dc.Introduced) > 0 || len(dc.Resolved) > 0
+}
+
+// CompareDiagnostics matches the diagnostics of two runs by code, severity, message and location. Duplicate
+// diagnostics are matched one to one.
+func CompareDiagnostics(baseline, current []Diagnostic) DiagnosticComparison {
+	remaining := make(map[string][]Diagnostic)
+	for _, diagnostic := range baseline {
+		fingerprint := diagnosticFingerprint(diagnostic)
+		remaining[fingerprint] = append(remaining[fingerprint], diagnostic)
+	}
+
+	var comparison DiagnosticComparison
+	for _, diagnostic := range current {
+		fingerprint := diagnosticFingerprint(diagnostic)
+		if matches := remaining[fingerprint]; len(matches) > 0 {
+			remaining[fingerprint] = matches[1:]
+			comparison.Unchanged = append(comparison.Unchanged, diagnostic)
+			continue
+		}
+		comparison.Introduced = append(comparison.Introduced, diagnostic)
+	}
+
+	for _, diagnostic := range baseline {
+		fingerprint := diagnosticFingerprint(diagnostic)
+		if matches := remaining[fingerprint]; len(matches) > 0 {
+			remaining[fingerprint] = matches[1:]
+			comparison.Resolved = append(comparison.Resolved, diagnostic)
+		}
+	}
+	return comparison
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diagnostics
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"ballerina-lang-go/tools/text"
+)
+
+func newExportTestDiagnostics() []Diagnostic {
+	document := text.TextDocumentFromText("import ballerina/io;\n\npublic function main() {\n    int x = \"one\";\n}\n")
+	location := func(file string, start, length int) Location {
+		loc, err := newTestLocation(document, file, fmt.Sprint(start), fmt.Sprint(length))
+		if err != nil {
+			panic(err)
+		}
+		return loc
+	}
+
+	incompatible := "BCE2066"
+	unused := "BCW1002"
+	return []Diagnostic{
+		CreateDiagnosticWithRelatedInformation(
+			NewDiagnosticInfo(&incompatible, "incompatible types: expected '%s', found '%s'", Error),
+			location("main.bal", 59, 5),
+			[]DiagnosticRelatedInformation{
+				NewDiagnosticRelatedInformation(location("main.bal", 55, 1), "variable declared here"),
+				NewDiagnosticRelatedInformation(nil, "strings cannot be assigned to ints"),
+			},
+			[]DiagnosticProperty[any]{
+				NewDiagnosticProperty[any](Symbolic, "int"),
+				NewDiagnosticProperty[any](Symbolic, "string"),
+				NewDiagnosticProperty[any](Numeric, int64(3)),
+				NewDiagnosticProperty[any](Numeric, 0.5),
+				NewDiagnosticProperty[any](Collection, []any{int64(1), "two"}),
+			},
+			"int", "string"),
+		CreateDiagnostic(NewDiagnosticInfo(&unused, "unused module prefix '%s'", Warning), location("main.bal", 7, 12), "io"),
+		CreateDiagnostic(NewDiagnosticInfo(nil, "consider documenting public functions", Hint), location("main.bal", 22, 22)),
+	}
+}
+
+func TestExportImportJSONRoundTrip(t *testing.T) {
+	diagnostics := newExportTestDiagnostics()
+
+	var buf bytes.Buffer
+	if err := ExportJSON(&buf, diagnostics); err != nil {
+		t.Fatalf("ExportJSON() error: %v", err)
+	}
+	imported, err := ImportJSON(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("ImportJSON() error: %v", err)
+	}
+	assertSameDiagnostics(t, diagnostics, imported)
+
+	var again bytes.Buffer
+	if err := ExportJSON(&again, imported); err != nil {
+		t.Fatalf("ExportJSON() of imported diagnostics error: %v", err)
+	}
+	if buf.String() != again.String() {
+		t.Errorf("JSON export is not stable across a round trip:\nfirst:\n%s\nsecond:\n%s", buf.String(), again.String())
+	}
+}
+
+func TestExportImportSARIFRoundTrip(t *testing.T) {
+	diagnostics := newExportTestDiagnostics()
+
+	var buf bytes.Buffer
+	if err := ExportSARIF(&buf, diagnostics, SARIFTool{Name: "bal", Version: "2201.0.0"}); err != nil {
+		t.Fatalf("ExportSARIF() error: %v", err)
+	}
+	imported, err := ImportSARIF(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("ImportSARIF() error: %v", err)
+	}
+	assertSameDiagnostics(t, diagnostics, imported)
+}
+
+func TestExportSARIFStructure(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportSARIF(&buf, newExportTestDiagnostics(), SARIFTool{Name: "bal"}); err != nil {
+		t.Fatalf("ExportSARIF() error: %v", err)
+	}
+
+	var log map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("exported SARIF is not valid JSON: %v", err)
+	}
+	if log["version"] != SARIFVersion {
+		t.Errorf("version = %v, want %s", log["version"], SARIFVersion)
+	}
+
+	run := log["runs"].([]any)[0].(map[string]any)
+	rules := run["tool"].(map[string]any)["driver"].(map[string]any)["rules"].([]any)
+	if len(rules) != 2 {
+		t.Errorf("expected 2 rules, got %d", len(rules))
+	}
+
+	results := run["results"].([]any)
+	first := results[0].(map[string]any)
+	if first["level"] != "error" || first["ruleId"] != "BCE2066" {
+		t.Errorf("unexpected first result: level=%v ruleId=%v", first["level"], first["ruleId"])
+	}
+	region := first["locations"].([]any)[0].(map[string]any)["physicalLocation"].(map[string]any)["region"].(map[string]any)
+	if region["startLine"] != float64(4) || region["startColumn"] != float64(13) {
+		t.Errorf("region should use one-based positions, got line %v column %v", region["startLine"], region["startColumn"])
+	}
+	if third := results[2].(map[string]any); third["level"] != "note" {
+		t.Errorf("hint should be exported with level 'note', got %v", third["level"])
+	}
+}
+
+func TestImportRejectsUnknownVersion(t *testing.T) {
+	if _, err := ImportJSON(strings.NewReader(`{"version": "0.1", "diagnostics": []}`)); err == nil {
+		t.Error("ImportJSON() should reject an unknown format version")
+	}
+	if _, err := ImportSARIF(strings.NewReader(`{"version": "2.0.0", "runs": []}`)); err == nil {
+		t.Error("ImportSARIF() should reject an unknown SARIF version")
+	}
+}
+
+func TestCompareDiagnostics(t *testing.T) {
+	diagnostics := newExportTestDiagnostics()
+	baseline := diagnostics[:2]
+	current := diagnostics[1:]
+
+	comparison := CompareDiagnostics(baseline, current)
+	if !comparison.HasChanges() {
+		t.Fatal("expected changes between runs")
+	}
+	if len(comparison.Resolved) != 1 || comparison.Resolved[0] != diagnostics[0] {
+		t.Errorf("expected the first diagnostic to be resolved, got %v", comparison.Resolved)
+	}
+	if len(comparison.Introduced) != 1 || comparison.Introduced[0] != diagnostics[2] {
+		t.Errorf("expected the third diagnostic to be introduced, got %v", comparison.Introduced)
+	}
+	if len(comparison.Unchanged) != 1 {
+		t.Errorf("expected one unchanged diagnostic, got %d", len(comparison.Unchanged))
+	}
+
+	var buf bytes.Buffer
+	if err := ExportSARIF(&buf, diagnostics, SARIFTool{Name: "bal"}); err != nil {
+		t.Fatalf("ExportSARIF() error: %v", err)
+	}
+	imported, err := ImportSARIF(&buf)
+	if err != nil {
+		t.Fatalf("ImportSARIF() error: %v", err)
+	}
+	if comparison := CompareDiagnostics(diagnostics, imported); comparison.HasChanges() {
+		t.Errorf("imported diagnostics should match the originals, got %+v", comparison)
+	}
+}
+
+func assertSameDiagnostics(t *testing.T, want, got []Diagnostic) {
+	t.Helper()
+	if len(want) != len(got) {
+		t.Fatalf("expected %d diagnostics, got %d", len(want), len(got))
+	}
+	for i := range want {
+		w, g := want[i], got[i]
+		if w.DiagnosticInfo().Code() != g.DiagnosticInfo().Code() {
+			t.Errorf("[%d] code = %q, want %q", i, g.DiagnosticInfo().Code(), w.DiagnosticInfo().Code())
+		}
+		if w.DiagnosticInfo().Severity() != g.DiagnosticInfo().Severity() {
+			t.Errorf("[%d] severity = %s, want %s", i, g.DiagnosticInfo().Severity(), w.DiagnosticInfo().Severity())
+		}
+		if w.DiagnosticInfo().MessageFormat() != g.DiagnosticInfo().MessageFormat() {
+			t.Errorf("[%d] message format = %q, want %q", i, g.DiagnosticInfo().MessageFormat(), w.DiagnosticInfo().MessageFormat())
+		}
+		if w.Message() != g.Message() {
+			t.Errorf("[%d] message = %q, want %q", i, g.Message(), w.Message())
+		}
+		if w.String() != g.String() {
+			t.Errorf("[%d] String() = %q, want %q", i, g.String(), w.String())
+		}
+		if w.Location().TextRange().TextRangeLookupKey() != g.Location().TextRange().TextRangeLookupKey() {
+			t.Errorf("[%d] text range = %s, want %s", i, g.Location().TextRange(), w.Location().TextRange())
+		}
+		if len(w.RelatedInformation()) != len(g.RelatedInformation()) {
+			t.Errorf("[%d] expected %d related information, got %d", i, len(w.RelatedInformation()), len(g.RelatedInformation()))
+		}
+		for j := range min(len(w.RelatedInformation()), len(g.RelatedInformation())) {
+			wr, gr := w.RelatedInformation()[j], g.RelatedInformation()[j]
+			if wr.Message() != gr.Message() {
+				t.Errorf("[%d] related message = %q, want %q", i, gr.Message(), wr.Message())
+			}
+			if (wr.Location() == nil) != (gr.Location() == nil) {
+				t.Errorf("[%d] related location = %v, want %v", i, gr.Location(), wr.Location())
+			}
+		}
+		if len(w.Properties()) != len(g.Properties()) {
+			t.Errorf("[%d] expected %d properties, got %d", i, len(w.Properties()), len(g.Properties()))
+		}
+		for j := range min(len(w.Properties()), len(g.Properties())) {
+			wp, gp := w.Properties()[j], g.Properties()[j]
+			if wp.Kind() != gp.Kind() || !reflect.DeepEqual(wp.Value(), gp.Value()) {
+				t.Errorf("[%d] property = %s:%#v, want %s:%#v", i, gp.Kind(), gp.Value(), wp.Kind(), wp.Value())
+			}
+		}
+	}
+}
@@ -40,3 +40,17 @@ func CreateDiagnostic(diagnosticInfo DiagnosticInfo, location Location, args ...
 func CreateDiagnosticWithProperties(diagnosticInfo DiagnosticInfo, location Location, properties []DiagnosticProperty[any], args ...any) Diagnostic {
 	return NewDefaultDiagnostic(diagnosticInfo, location, properties, args...)
 }
+
+// CreateDiagnosticWithRelatedInformation creates a Diagnostic instance from the given details.
+//
+// Parameters:
+//   - diagnosticInfo: static diagnostic information
+//   - location: the location of the diagnostic
+//   - relatedInfo: locations and messages related to the diagnostic
+//   - properties: properties associated with the diagnostic
+//   - args: arguments to diagnostic message format
+//
+// Returns a Diagnostic instance.
+func CreateDiagnosticWithRelatedInformation(diagnosticInfo DiagnosticInfo, location Location, relatedInfo []DiagnosticRelatedInformation, properties []DiagnosticProperty[any], args ...any) Diagnostic {
+	return NewDefaultDiagnosticWithRelatedInformation(diagnosticInfo, location, relatedInfo, properties, args...)
+}
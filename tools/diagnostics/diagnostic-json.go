@@ -0,0 +1,226 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diagnostics
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"ballerina-lang-go/tools/text"
+)
+
+// DiagnosticsJSONVersion is the version of the JSON format written by ExportJSON.
+const DiagnosticsJSONVersion = "1.0"
+
+// DiagnosticsJSON is the stable JSON representation of a set of diagnostics. Line and column numbers are
+// zero-based, matching text.LinePosition.
+type DiagnosticsJSON struct {
+	Version     string           `json:"version"`
+	Diagnostics []DiagnosticJSON `json:"diagnostics"`
+}
+
+type DiagnosticJSON struct {
+	Code               string                   `json:"code,omitempty"`
+	Severity           string                   `json:"severity"`
+	Message            string                   `json:"message"`
+	MessageFormat      string                   `json:"messageFormat,omitempty"`
+	Location           *LocationJSON            `json:"location,omitempty"`
+	RelatedInformation []RelatedInformationJSON `json:"relatedInformation,omitempty"`
+	Properties         []PropertyJSON           `json:"properties,omitempty"`
+}
+
+type LocationJSON struct {
+	File        string `json:"file,omitempty"`
+	StartLine   int    `json:"startLine"`
+	StartColumn int    `json:"startColumn"`
+	EndLine     int    `json:"endLine"`
+	EndColumn   int    `json:"endColumn"`
+	StartOffset *int   `json:"startOffset,omitempty"`
+	Length      *int   `json:"length,omitempty"`
+}
+
+type RelatedInformationJSON struct {
+	Message  string        `json:"message"`
+	Location *LocationJSON `json:"location,omitempty"`
+}
+
+type PropertyJSON struct {
+	Kind  string `json:"kind"`
+	Value any    `json:"value"`
+}
+
+// ExportJSON writes the given diagnostics to w using the DiagnosticsJSON format.
+func ExportJSON(w io.Writer, diagnostics []Diagnostic) error {
+	doc := DiagnosticsJSON{
+		Version:     DiagnosticsJSONVersion,
+		Diagnostics: make([]DiagnosticJSON, 0, len(diagnostics)),
+	}
+	for _, diagnostic := range diagnostics {
+		doc.Diagnostics = append(doc.Diagnostics, toDiagnosticJSON(diagnostic))
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
+
+// ImportJSON reads diagnostics written by ExportJSON.
+func ImportJSON(r io.Reader) ([]Diagnostic, error) {
+	var doc DiagnosticsJSON
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode diagnostics: %w", err)
+	}
+	if doc.Version != DiagnosticsJSONVersion {
+		return nil, fmt.Errorf("unsupported diagnostics format version '%s'", doc.Version)
+	}
+
+	diagnostics := make([]Diagnostic, 0, len(doc.Diagnostics))
+	for i, d := range doc.Diagnostics {
+		diagnostic, err := fromDiagnosticJSON(d)
+		if err != nil {
+			return nil, fmt.Errorf("invalid diagnostic at index %d: %w", i, err)
+		}
+		diagnostics = append(diagnostics, diagnostic)
+	}
+	return diagnostics, nil
+}
+
+func toDiagnosticJSON(diagnostic Diagnostic) DiagnosticJSON {
+	info := diagnostic.DiagnosticInfo()
+	d := DiagnosticJSON{
+		Code:          info.Code(),
+		Severity:      info.Severity().String(),
+		Message:       diagnostic.Message(),
+		MessageFormat: info.MessageFormat(),
+		Location:      toLocationJSON(diagnostic.Location()),
+	}
+	for _, related := range diagnostic.RelatedInformation() {
+		d.RelatedInformation = append(d.RelatedInformation, RelatedInformationJSON{
+			Message:  related.Message(),
+			Location: toLocationJSON(related.Location()),
+		})
+	}
+	for _, property := range diagnostic.Properties() {
+		d.Properties = append(d.Properties, PropertyJSON{
+			Kind:  property.Kind().String(),
+			Value: property.Value(),
+		})
+	}
+	return d
+}
+
+func fromDiagnosticJSON(d DiagnosticJSON) (Diagnostic, error) {
+	severity, ok := DiagnosticSeverityFromString(d.Severity)
+	if !ok {
+		return nil, fmt.Errorf("unknown severity '%s'", d.Severity)
+	}
+
+	var code *string
+	if d.Code != "" {
+		code = &d.Code
+	}
+	messageFormat := d.MessageFormat
+	if messageFormat == "" {
+		messageFormat = d.Message
+	}
+
+	var related []DiagnosticRelatedInformation
+	for _, r := range d.RelatedInformation {
+		related = append(related, NewDiagnosticRelatedInformation(fromLocationJSON(r.Location), r.Message))
+	}
+
+	properties, err := fromPropertiesJSON(d.Properties)
+	if err != nil {
+		return nil, err
+	}
+
+	info := NewDiagnosticInfo(code, messageFormat, severity)
+	return newDefaultDiagnosticWithMessage(info, fromLocationJSON(d.Location), related, properties, d.Message), nil
+}
+
+func fromPropertiesJSON(props []PropertyJSON) ([]DiagnosticProperty[any], error) {
+	var properties []DiagnosticProperty[any]
+	for _, p := range props {
+		kind, ok := DiagnosticPropertyKindFromString(p.Kind)
+		if !ok {
+			return nil, fmt.Errorf("unknown property kind '%s'", p.Kind)
+		}
+		properties = append(properties, NewDiagnosticProperty(kind, fromJSONValue(p.Value)))
+	}
+	return properties, nil
+}
+
+// fromJSONValue converts the json.Number values of a property decoded with UseNumber back to the int64 or float64
+// they were exported from.
+func fromJSONValue(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case []any:
+		for i := range v {
+			v[i] = fromJSONValue(v[i])
+		}
+	case map[string]any:
+		for key := range v {
+			v[key] = fromJSONValue(v[key])
+		}
+	}
+	return value
+}
+
+func toLocationJSON(location Location) *LocationJSON {
+	if location == nil || location.LineRange() == nil {
+		return nil
+	}
+	lineRange := location.LineRange()
+	l := &LocationJSON{
+		File:        lineRange.FileName(),
+		StartLine:   lineRange.StartLine().Line(),
+		StartColumn: lineRange.StartLine().Offset(),
+		EndLine:     lineRange.EndLine().Line(),
+		EndColumn:   lineRange.EndLine().Offset(),
+	}
+	if textRange := location.TextRange(); textRange != nil {
+		startOffset := textRange.StartOffset()
+		length := textRange.Length()
+		l.StartOffset = &startOffset
+		l.Length = &length
+	}
+	return l
+}
+
+func fromLocationJSON(l *LocationJSON) Location {
+	if l == nil {
+		return nil
+	}
+	lineRange := text.LineRangeFromLinePositions(l.File,
+		text.LinePositionFromLineAndOffset(l.StartLine, l.StartColumn),
+		text.LinePositionFromLineAndOffset(l.EndLine, l.EndColumn))
+	var textRange text.TextRange
+	if l.StartOffset != nil && l.Length != nil {
+		textRange = text.TextRangeFromStartOffsetAndLength(*l.StartOffset, *l.Length)
+	}
+	return NewLocation(lineRange, textRange)
+}
@@ -44,3 +44,13 @@ func (dpk DiagnosticPropertyKind) String() string {
 		return "UNKNOWN"
 	}
 }
+
+// DiagnosticPropertyKindFromString returns the DiagnosticPropertyKind with the given name.
+func DiagnosticPropertyKindFromString(s string) (DiagnosticPropertyKind, bool) {
+	for _, kind := range []DiagnosticPropertyKind{Symbolic, String, Numeric, Collection, Other} {
+		if kind.String() == s {
+			return kind, true
+		}
+	}
+	return Other, false
+}
@@ -21,3 +21,24 @@ type DiagnosticProperty[T any] interface {
 	Kind() DiagnosticPropertyKind
 	Value() T
 }
+
+type diagnosticPropertyImpl[T any] struct {
+	kind  DiagnosticPropertyKind
+	value T
+}
+
+// NewDiagnosticProperty creates a DiagnosticProperty of the given kind holding the given value.
+func NewDiagnosticProperty[T any](kind DiagnosticPropertyKind, value T) DiagnosticProperty[T] {
+	return &diagnosticPropertyImpl[T]{
+		kind:  kind,
+		value: value,
+	}
+}
+
+func (dp diagnosticPropertyImpl[T]) Kind() DiagnosticPropertyKind {
+	return dp.kind
+}
+
+func (dp diagnosticPropertyImpl[T]) Value() T {
+	return dp.value
+}
@@ -0,0 +1,344 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diagnostics
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ballerina-lang-go/tools/text"
+)
+
+const defaultTabWidth = 4
+
+const (
+	ansiReset   = "\x1b[0m"
+	ansiBold    = "\x1b[1m"
+	ansiRed     = "\x1b[1;31m"
+	ansiYellow  = "\x1b[1;33m"
+	ansiGreen   = "\x1b[1;32m"
+	ansiBlue    = "\x1b[1;34m"
+	ansiCyan    = "\x1b[1;36m"
+	ansiMagenta = "\x1b[1;35m"
+)
+
+// RenderOptions configures the output produced by a DiagnosticRenderer.
+type RenderOptions struct {
+	// Color enables ANSI escape sequences in the output.
+	Color bool
+	// TabWidth is the number of columns a tab character expands to. Defaults to 4.
+	TabWidth int
+	// ContextLines is the number of unannotated source lines shown before and after each annotated line.
+	ContextLines int
+}
+
+// DiagnosticRenderer renders a Diagnostic together with the source lines it refers to, underlining the
+// offending range and any related information that belongs to the same document.
+type DiagnosticRenderer interface {
+	Render(diagnostic Diagnostic, document text.TextDocument) string
+}
+
+type diagnosticRendererImpl struct {
+	color        bool
+	tabWidth     int
+	contextLines int
+}
+
+// annotation is a single underlined range in the rendered snippet.
+type annotation struct {
+	startOffset int
+	endOffset   int
+	startLine   int
+	endLine     int
+	primary     bool
+	label       string
+}
+
+func NewDiagnosticRenderer(options RenderOptions) DiagnosticRenderer {
+	tabWidth := options.TabWidth
+	if tabWidth <= 0 {
+		tabWidth = defaultTabWidth
+	}
+	contextLines := max(options.ContextLines, 0)
+	return &diagnosticRendererImpl{
+		color:        options.Color,
+		tabWidth:     tabWidth,
+		contextLines: contextLines,
+	}
+}
+
+func (r *diagnosticRendererImpl) Render(diagnostic Diagnostic, document text.TextDocument) string {
+	var sb strings.Builder
+	r.writeHeader(&sb, diagnostic)
+
+	location := diagnostic.Location()
+	if location == nil || document == nil {
+		return sb.String()
+	}
+
+	lineMap := document.Lines()
+	content := document.String()
+	fileName := location.LineRange().FileName()
+
+	primary, ok := r.newAnnotation(location, lineMap, len(content), true, "")
+	if !ok {
+		return sb.String()
+	}
+
+	annotations := []annotation{primary}
+	var notes []DiagnosticRelatedInformation
+	for _, related := range diagnostic.RelatedInformation() {
+		relatedLocation := related.Location()
+		if relatedLocation == nil || relatedLocation.LineRange().FileName() != fileName {
+			notes = append(notes, related)
+			continue
+		}
+		secondary, ok := r.newAnnotation(relatedLocation, lineMap, len(content), false, related.Message())
+		if !ok {
+			notes = append(notes, related)
+			continue
+		}
+		annotations = append(annotations, secondary)
+	}
+
+	lines := r.linesToDisplay(annotations, len(lineMap.TextLines()))
+	gutterWidth := len(strconv.Itoa(lines[len(lines)-1] + 1))
+	padding := strings.Repeat(" ", gutterWidth)
+
+	position := fmt.Sprintf("%d:%d", primary.startLine+1, r.column(lineMap, primary.startOffset)+1)
+	if fileName != "" {
+		position = fmt.Sprintf("%s:%s", fileName, position)
+	}
+	fmt.Fprintf(&sb, "%s%s %s\n", padding, r.paint(ansiBlue, "-->"), position)
+	fmt.Fprintf(&sb, "%s %s\n", padding, r.paint(ansiBlue, "|"))
+
+	previous := -1
+	for _, lineNo := range lines {
+		if previous != -1 && lineNo > previous+1 {
+			fmt.Fprintf(&sb, "%s\n", r.paint(ansiBlue, "..."))
+		}
+		previous = lineNo
+
+		textLine, err := lineMap.TextLine(lineNo)
+		if err != nil {
+			continue
+		}
+		r.writeSourceLine(&sb, gutterWidth, lineNo, textLine.Text())
+		for _, a := range annotations {
+			if lineNo < a.startLine || lineNo > a.endLine {
+				continue
+			}
+			r.writeMarkerLine(&sb, padding, diagnostic.DiagnosticInfo().Severity(), textLine, a)
+		}
+	}
+
+	for _, note := range notes {
+		fmt.Fprintf(&sb, "%s %s %s\n", padding, r.paint(ansiBlue, "="), r.formatNote(note))
+	}
+
+	return sb.String()
+}
+
+func (r *diagnosticRendererImpl) writeHeader(sb *strings.Builder, diagnostic Diagnostic) {
+	severity := diagnostic.DiagnosticInfo().Severity()
+	label := strings.ToLower(severity.String())
+	if code := diagnostic.DiagnosticInfo().Code(); code != "" {
+		label = fmt.Sprintf("%s[%s]", label, code)
+	}
+	fmt.Fprintf(sb, "%s%s %s\n", r.paint(severityColor(severity), label), r.paint(ansiBold, ":"), r.paint(ansiBold, diagnostic.Message()))
+}
+
+func (r *diagnosticRendererImpl) writeSourceLine(sb *strings.Builder, gutterWidth, lineNo int, lineText string) {
+	gutter := r.paint(ansiBlue, fmt.Sprintf("%*d |", gutterWidth, lineNo+1))
+	expanded := r.expandTabs(lineText)
+	if expanded == "" {
+		fmt.Fprintf(sb, "%s\n", gutter)
+		return
+	}
+	fmt.Fprintf(sb, "%s %s\n", gutter, expanded)
+}
+
+func (r *diagnosticRendererImpl) writeMarkerLine(sb *strings.Builder, padding string, severity DiagnosticSeverity, textLine text.TextLine, a annotation) {
+	lineText := textLine.Text()
+	start := max(a.startOffset, textLine.StartOffset()) - textLine.StartOffset()
+	end := min(a.endOffset, textLine.EndOffset()) - textLine.StartOffset()
+	start = min(start, len(lineText))
+	end = max(min(end, len(lineText)), start)
+	isFirstLine := textLine.LineNo() == a.startLine
+	if !isFirstLine {
+		// Continuation lines of a multi-line range are underlined from the first non-blank character.
+		start = start + len(lineText[start:end]) - len(strings.TrimLeft(lineText[start:end], " \t"))
+	}
+
+	column := r.displayWidth(lineText[:start])
+	width := max(r.displayWidth(lineText[start:end]), 1)
+
+	var marker, color string
+	if a.primary && isFirstLine {
+		marker = "^" + strings.Repeat("~", width-1)
+		color = severityColor(severity)
+	} else if a.primary {
+		marker = strings.Repeat("~", width)
+		color = severityColor(severity)
+	} else {
+		marker = strings.Repeat("-", width)
+		color = ansiBlue
+	}
+
+	fmt.Fprintf(sb, "%s %s %s%s", padding, r.paint(ansiBlue, "|"), strings.Repeat(" ", column), r.paint(color, marker))
+	if a.label != "" && textLine.LineNo() == a.endLine {
+		fmt.Fprintf(sb, " %s", r.paint(color, a.label))
+	}
+	sb.WriteString("\n")
+}
+
+func (r *diagnosticRendererImpl) formatNote(note DiagnosticRelatedInformation) string {
+	location := note.Location()
+	if location == nil {
+		return fmt.Sprintf("%s %s", r.paint(ansiBold, "note:"), note.Message())
+	}
+	lineRange := location.LineRange()
+	position := fmt.Sprintf("%d:%d", lineRange.StartLine().Line()+1, lineRange.StartLine().Offset()+1)
+	if lineRange.FileName() != "" {
+		position = fmt.Sprintf("%s:%s", lineRange.FileName(), position)
+	}
+	return fmt.Sprintf("%s %s [%s]", r.paint(ansiBold, "note:"), note.Message(), position)
+}
+
+// newAnnotation resolves the text range of the given location into line numbers of the document.
+func (r *diagnosticRendererImpl) newAnnotation(location Location, lineMap text.LineMap, contentLength int, primary bool, label string) (annotation, bool) {
+	startOffset, endOffset, ok := resolveOffsets(location, lineMap, contentLength)
+	if !ok {
+		return annotation{}, false
+	}
+	startPosition, err := lineMap.LinePositionFromPosition(startOffset)
+	if err != nil {
+		return annotation{}, false
+	}
+	endPosition, err := lineMap.LinePositionFromPosition(endOffset)
+	if err != nil {
+		return annotation{}, false
+	}
+	endLine := endPosition.Line()
+	// A range ending right after a newline does not cover the following line.
+	if endLine > startPosition.Line() && endPosition.Offset() == 0 {
+		endLine = endLine - 1
+	}
+	return annotation{
+		startOffset: startOffset,
+		endOffset:   endOffset,
+		startLine:   startPosition.Line(),
+		endLine:     endLine,
+		primary:     primary,
+		label:       label,
+	}, true
+}
+
+// resolveOffsets returns the start and end offsets of the location, preferring the text range and falling back
+// to the line range when the text range is not available.
+func resolveOffsets(location Location, lineMap text.LineMap, contentLength int) (int, int, bool) {
+	if textRange := location.TextRange(); textRange != nil {
+		startOffset := min(max(textRange.StartOffset(), 0), contentLength)
+		endOffset := min(max(textRange.EndOffset(), startOffset), contentLength)
+		return startOffset, endOffset, true
+	}
+	lineRange := location.LineRange()
+	if lineRange == nil {
+		return 0, 0, false
+	}
+	startOffset, err := lineMap.TextPositionFromLinePosition(lineRange.StartLine())
+	if err != nil {
+		return 0, 0, false
+	}
+	endOffset, err := lineMap.TextPositionFromLinePosition(lineRange.EndLine())
+	if err != nil {
+		return 0, 0, false
+	}
+	return startOffset, max(endOffset, startOffset), true
+}
+
+// linesToDisplay returns the sorted, zero-based line numbers covered by the annotations and their context.
+func (r *diagnosticRendererImpl) linesToDisplay(annotations []annotation, lineCount int) []int {
+	seen := make(map[int]bool)
+	for _, a := range annotations {
+		from := max(a.startLine-r.contextLines, 0)
+		to := min(a.endLine+r.contextLines, lineCount-1)
+		for line := from; line <= to; line++ {
+			seen[line] = true
+		}
+	}
+	lines := make([]int, 0, len(seen))
+	for line := range seen {
+		lines = append(lines, line)
+	}
+	sort.Ints(lines)
+	return lines
+}
+
+// column returns the zero-based display column of the given offset within its line.
+func (r *diagnosticRendererImpl) column(lineMap text.LineMap, offset int) int {
+	position, err := lineMap.LinePositionFromPosition(offset)
+	if err != nil {
+		return 0
+	}
+	textLine, err := lineMap.TextLine(position.Line())
+	if err != nil {
+		return 0
+	}
+	lineText := textLine.Text()
+	return r.displayWidth(lineText[:min(position.Offset(), len(lineText))])
+}
+
+func (r *diagnosticRendererImpl) expandTabs(s string) string {
+	return strings.ReplaceAll(s, "\t", strings.Repeat(" ", r.tabWidth))
+}
+
+func (r *diagnosticRendererImpl) displayWidth(s string) int {
+	width := 0
+	for _, ch := range s {
+		if ch == '\t' {
+			width = width + r.tabWidth
+		} else {
+			width = width + 1
+		}
+	}
+	return width
+}
+
+func (r *diagnosticRendererImpl) paint(color, s string) string {
+	if !r.color || s == "" {
+		return s
+	}
+	return color + s + ansiReset
+}
+
+func severityColor(severity DiagnosticSeverity) string {
+	switch severity {
+	case Error:
+		return ansiRed
+	case Warning:
+		return ansiYellow
+	case Info:
+		return ansiCyan
+	case Hint:
+		return ansiGreen
+	default:
+		return ansiMagenta
+	}
+}
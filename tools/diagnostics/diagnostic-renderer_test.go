@@ -0,0 +1,218 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diagnostics
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"ballerina-lang-go/tools/text"
+
+	"golang.org/x/tools/txtar"
+)
+
+const renderTestFileName = "main.bal"
+
+// TestDiagnosticRenderer runs the golden tests in testdata/renderer. Each archive contains the source, the
+// diagnostics to render, the renderer options and the expected output. Set BLESS=1 to update the expected output.
+func TestDiagnosticRenderer(t *testing.T) {
+	bless := os.Getenv("BLESS") == "1" || os.Getenv("BLESS") == "true"
+
+	files, err := filepath.Glob(filepath.Join("testdata", "renderer", "*.txtar"))
+	if err != nil {
+		t.Fatalf("failed to list golden files: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no golden files found in testdata/renderer")
+	}
+
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".txtar")
+		t.Run(name, func(t *testing.T) {
+			archive, err := txtar.ParseFile(file)
+			if err != nil {
+				t.Fatalf("failed to parse %s: %v", file, err)
+			}
+			sections := make(map[string]string)
+			for _, f := range archive.Files {
+				sections[f.Name] = string(f.Data)
+			}
+
+			source := strings.TrimSuffix(sections["source"], "\n")
+			document := text.TextDocumentFromText(source)
+			options, err := parseRenderOptions(sections["options"])
+			if err != nil {
+				t.Fatalf("invalid options: %v", err)
+			}
+			diagnostics, err := parseRenderDiagnostics(sections["diagnostics"], document)
+			if err != nil {
+				t.Fatalf("invalid diagnostics: %v", err)
+			}
+
+			renderer := NewDiagnosticRenderer(options)
+			var sb strings.Builder
+			for _, diagnostic := range diagnostics {
+				sb.WriteString(renderer.Render(diagnostic, document))
+			}
+			actual := strings.ReplaceAll(sb.String(), "\x1b", `\e`)
+
+			if bless {
+				for i := range archive.Files {
+					if archive.Files[i].Name == "expected" {
+						archive.Files[i].Data = []byte(actual)
+					}
+				}
+				if err := os.WriteFile(file, txtar.Format(archive), 0o644); err != nil {
+					t.Fatalf("failed to update %s: %v", file, err)
+				}
+				return
+			}
+
+			if expected := sections["expected"]; actual != expected {
+				t.Errorf("output mismatch:\nwant:\n%s\ngot:\n%s", expected, actual)
+			}
+		})
+	}
+}
+
+func TestDiagnosticRendererWithoutLocation(t *testing.T) {
+	code := "BCE0001"
+	info := NewDiagnosticInfo(&code, "something went wrong", Error)
+	diagnostic := CreateDiagnostic(info, nil)
+
+	got := NewDiagnosticRenderer(RenderOptions{}).Render(diagnostic, nil)
+	if want := "error[BCE0001]: something went wrong\n"; got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func parseRenderOptions(section string) (RenderOptions, error) {
+	var options RenderOptions
+	for _, field := range strings.Fields(section) {
+		key, value, _ := strings.Cut(field, "=")
+		switch key {
+		case "color":
+			options.Color = value == "true"
+		case "tabwidth":
+			width, err := strconv.Atoi(value)
+			if err != nil {
+				return options, err
+			}
+			options.TabWidth = width
+		case "context":
+			lines, err := strconv.Atoi(value)
+			if err != nil {
+				return options, err
+			}
+			options.ContextLines = lines
+		default:
+			return options, fmt.Errorf("unknown option %q", key)
+		}
+	}
+	return options, nil
+}
+
+// parseRenderDiagnostics reads diagnostics in the form
+//
+//	diagnostic <severity> <code> <start-offset> <length> <message>
+//	related <file> <start-offset> <length> <message>
+//
+// where each related line attaches to the preceding diagnostic. A code of "-" means the diagnostic has no code.
+func parseRenderDiagnostics(section string, document text.TextDocument) ([]Diagnostic, error) {
+	type pending struct {
+		info     DiagnosticInfo
+		location Location
+		related  []DiagnosticRelatedInformation
+	}
+
+	var items []*pending
+	for _, line := range strings.Split(section, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.SplitN(line, " ", 6)
+		switch fields[0] {
+		case "diagnostic":
+			if len(fields) < 6 {
+				return nil, fmt.Errorf("malformed diagnostic line: %q", line)
+			}
+			severity, ok := DiagnosticSeverityFromString(fields[1])
+			if !ok {
+				return nil, fmt.Errorf("unknown severity %q", fields[1])
+			}
+			var code *string
+			if fields[2] != "-" {
+				code = &fields[2]
+			}
+			location, err := newTestLocation(document, renderTestFileName, fields[3], fields[4])
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, &pending{
+				info:     NewDiagnosticInfo(code, fields[5], severity),
+				location: location,
+			})
+		case "related":
+			if len(items) == 0 || len(fields) < 5 {
+				return nil, fmt.Errorf("malformed related line: %q", line)
+			}
+			location, err := newTestLocation(document, fields[1], fields[2], fields[3])
+			if err != nil {
+				return nil, err
+			}
+			message := strings.Join(fields[4:], " ")
+			last := items[len(items)-1]
+			last.related = append(last.related, NewDiagnosticRelatedInformation(location, message))
+		default:
+			return nil, fmt.Errorf("unknown directive %q", fields[0])
+		}
+	}
+
+	diagnostics := make([]Diagnostic, 0, len(items))
+	for _, item := range items {
+		diagnostics = append(diagnostics, CreateDiagnosticWithRelatedInformation(item.info, item.location, item.related, nil))
+	}
+	return diagnostics, nil
+}
+
+func newTestLocation(document text.TextDocument, fileName, start, length string) (Location, error) {
+	startOffset, err := strconv.Atoi(start)
+	if err != nil {
+		return nil, err
+	}
+	rangeLength, err := strconv.Atoi(length)
+	if err != nil {
+		return nil, err
+	}
+	lineMap := document.Lines()
+	startPosition, err := lineMap.LinePositionFromPosition(startOffset)
+	if err != nil {
+		return nil, err
+	}
+	endPosition, err := lineMap.LinePositionFromPosition(startOffset + rangeLength)
+	if err != nil {
+		return nil, err
+	}
+	return NewLocation(
+		text.LineRangeFromLinePositions(fileName, startPosition, endPosition),
+		text.TextRangeFromStartOffsetAndLength(startOffset, rangeLength),
+	), nil
+}
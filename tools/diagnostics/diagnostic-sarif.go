@@ -0,0 +1,350 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diagnostics
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"ballerina-lang-go/tools/text"
+)
+
+const (
+	SARIFVersion   = "2.1.0"
+	SARIFSchemaURI = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// Keys of the SARIF property bags used to preserve the information that SARIF cannot represent natively.
+const (
+	sarifSeverityProperty      = "ballerina/severity"
+	sarifMessageFormatProperty = "ballerina/messageFormat"
+	sarifPropertiesProperty    = "ballerina/properties"
+	sarifFingerprintKey        = "ballerina/v1"
+)
+
+// SARIFTool describes the tool that produced the exported diagnostics.
+type SARIFTool struct {
+	Name           string
+	Version        string
+	InformationURI string
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifToolComponent `json:"tool"`
+	Results []sarifResult      `json:"results"`
+}
+
+type sarifToolComponent struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules,omitempty"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     *sarifMessage      `json:"shortDescription,omitempty"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId,omitempty"`
+	RuleIndex           *int              `json:"ruleIndex,omitempty"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations,omitempty"`
+	RelatedLocations    []sarifLocation   `json:"relatedLocations,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+	Properties          map[string]any    `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+// sarifLocation has no physical location when it only carries the message of related information without a location.
+type sarifLocation struct {
+	ID               *int                   `json:"id,omitempty"`
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	Message          *sarifMessage          `json:"message,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// sarifRegion uses one-based lines and columns as required by the SARIF specification.
+type sarifRegion struct {
+	StartLine   int  `json:"startLine"`
+	StartColumn int  `json:"startColumn"`
+	EndLine     int  `json:"endLine"`
+	EndColumn   int  `json:"endColumn"`
+	CharOffset  *int `json:"charOffset,omitempty"`
+	CharLength  *int `json:"charLength,omitempty"`
+}
+
+// ExportSARIF writes the given diagnostics to w as a SARIF 2.1.0 log with a single run.
+func ExportSARIF(w io.Writer, diagnostics []Diagnostic, tool SARIFTool) error {
+	driver := sarifDriver{
+		Name:           tool.Name,
+		Version:        tool.Version,
+		InformationURI: tool.InformationURI,
+	}
+	ruleIndexes := make(map[string]int)
+	results := make([]sarifResult, 0, len(diagnostics))
+
+	for _, diagnostic := range diagnostics {
+		info := diagnostic.DiagnosticInfo()
+		result := sarifResult{
+			Level:   sarifLevel(info.Severity()),
+			Message: sarifMessage{Text: diagnostic.Message()},
+			PartialFingerprints: map[string]string{
+				sarifFingerprintKey: diagnosticFingerprint(diagnostic),
+			},
+			Properties: map[string]any{
+				sarifSeverityProperty:      info.Severity().String(),
+				sarifMessageFormatProperty: info.MessageFormat(),
+			},
+		}
+
+		if code := info.Code(); code != "" {
+			index, ok := ruleIndexes[code]
+			if !ok {
+				index = len(driver.Rules)
+				ruleIndexes[code] = index
+				driver.Rules = append(driver.Rules, sarifRule{
+					ID:                   code,
+					ShortDescription:     &sarifMessage{Text: info.MessageFormat()},
+					DefaultConfiguration: sarifConfiguration{Level: sarifLevel(info.Severity())},
+				})
+			}
+			result.RuleID = code
+			result.RuleIndex = &index
+		}
+
+		if location, ok := toSARIFLocation(diagnostic.Location()); ok {
+			result.Locations = []sarifLocation{location}
+		}
+
+		for i, related := range diagnostic.RelatedInformation() {
+			location, _ := toSARIFLocation(related.Location())
+			id := i + 1
+			location.ID = &id
+			location.Message = &sarifMessage{Text: related.Message()}
+			result.RelatedLocations = append(result.RelatedLocations, location)
+		}
+
+		if props := diagnostic.Properties(); len(props) > 0 {
+			values := make([]PropertyJSON, 0, len(props))
+			for _, property := range props {
+				values = append(values, PropertyJSON{Kind: property.Kind().String(), Value: property.Value()})
+			}
+			result.Properties[sarifPropertiesProperty] = values
+		}
+
+		results = append(results, result)
+	}
+
+	log := sarifLog{
+		Schema:  SARIFSchemaURI,
+		Version: SARIFVersion,
+		Runs: []sarifRun{{
+			Tool:    sarifToolComponent{Driver: driver},
+			Results: results,
+		}},
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
+
+// ImportSARIF reads the results of every run in a SARIF 2.1.0 log as diagnostics. Information written by
+// ExportSARIF into property bags is used to restore the exact severity, message format and properties.
+func ImportSARIF(r io.Reader) ([]Diagnostic, error) {
+	var log sarifLog
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	if err := decoder.Decode(&log); err != nil {
+		return nil, fmt.Errorf("failed to decode SARIF log: %w", err)
+	}
+	if log.Version != SARIFVersion {
+		return nil, fmt.Errorf("unsupported SARIF version '%s'", log.Version)
+	}
+
+	var diagnostics []Diagnostic
+	for _, run := range log.Runs {
+		for i, result := range run.Results {
+			diagnostic, err := fromSARIFResult(result)
+			if err != nil {
+				return nil, fmt.Errorf("invalid SARIF result at index %d: %w", i, err)
+			}
+			diagnostics = append(diagnostics, diagnostic)
+		}
+	}
+	return diagnostics, nil
+}
+
+func fromSARIFResult(result sarifResult) (Diagnostic, error) {
+	severity := severityFromSARIFLevel(result.Level)
+	if name, ok := result.Properties[sarifSeverityProperty].(string); ok {
+		if s, ok := DiagnosticSeverityFromString(name); ok {
+			severity = s
+		}
+	}
+
+	messageFormat := result.Message.Text
+	if format, ok := result.Properties[sarifMessageFormatProperty].(string); ok && format != "" {
+		messageFormat = format
+	}
+
+	var code *string
+	if result.RuleID != "" {
+		ruleID := result.RuleID
+		code = &ruleID
+	}
+
+	var location Location
+	if len(result.Locations) > 0 {
+		location = fromSARIFLocation(result.Locations[0])
+	}
+
+	var related []DiagnosticRelatedInformation
+	for _, l := range result.RelatedLocations {
+		message := ""
+		if l.Message != nil {
+			message = l.Message.Text
+		}
+		related = append(related, NewDiagnosticRelatedInformation(fromSARIFLocation(l), message))
+	}
+
+	var properties []DiagnosticProperty[any]
+	if raw, ok := result.Properties[sarifPropertiesProperty]; ok {
+		// Round trip through JSON to reuse the decoding of PropertyJSON.
+		encoded, err := json.Marshal(raw)
+		if err != nil {
+			return nil, err
+		}
+		var props []PropertyJSON
+		decoder := json.NewDecoder(bytes.NewReader(encoded))
+		decoder.UseNumber()
+		if err := decoder.Decode(&props); err != nil {
+			return nil, fmt.Errorf("invalid diagnostic properties: %w", err)
+		}
+		properties, err = fromPropertiesJSON(props)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	info := NewDiagnosticInfo(code, messageFormat, severity)
+	return newDefaultDiagnosticWithMessage(info, location, related, properties, result.Message.Text), nil
+}
+
+func toSARIFLocation(location Location) (sarifLocation, bool) {
+	l := toLocationJSON(location)
+	if l == nil {
+		return sarifLocation{}, false
+	}
+	return sarifLocation{
+		PhysicalLocation: &sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: l.File},
+			Region: sarifRegion{
+				StartLine:   l.StartLine + 1,
+				StartColumn: l.StartColumn + 1,
+				EndLine:     l.EndLine + 1,
+				EndColumn:   l.EndColumn + 1,
+				CharOffset:  l.StartOffset,
+				CharLength:  l.Length,
+			},
+		},
+	}, true
+}
+
+func fromSARIFLocation(l sarifLocation) Location {
+	if l.PhysicalLocation == nil {
+		return nil
+	}
+	region := l.PhysicalLocation.Region
+	lineRange := text.LineRangeFromLinePositions(l.PhysicalLocation.ArtifactLocation.URI,
+		text.LinePositionFromLineAndOffset(max(region.StartLine-1, 0), max(region.StartColumn-1, 0)),
+		text.LinePositionFromLineAndOffset(max(region.EndLine-1, 0), max(region.EndColumn-1, 0)))
+	var textRange text.TextRange
+	if region.CharOffset != nil && region.CharLength != nil {
+		textRange = text.TextRangeFromStartOffsetAndLength(*region.CharOffset, *region.CharLength)
+	}
+	return NewLocation(lineRange, textRange)
+}
+
+func sarifLevel(severity DiagnosticSeverity) string {
+	switch severity {
+	case Error:
+		return "error"
+	case Warning:
+		return "warning"
+	case Info, Hint:
+		return "note"
+	default:
+		return "none"
+	}
+}
+
+func severityFromSARIFLevel(level string) DiagnosticSeverity {
+	switch level {
+	case "error":
+		return Error
+	case "warning":
+		return Warning
+	case "note":
+		return Info
+	default:
+		return Internal
+	}
+}
+
+// diagnosticFingerprint returns a hash that identifies a diagnostic across runs.
+func diagnosticFingerprint(diagnostic Diagnostic) string {
+	hasher := sha256.New()
+	fmt.Fprintf(hasher, "%s\x00%s\x00%s", diagnostic.DiagnosticInfo().Code(), diagnostic.DiagnosticInfo().Severity(), diagnostic.Message())
+	if location := diagnostic.Location(); location != nil && location.LineRange() != nil {
+		lineRange := location.LineRange()
+		fmt.Fprintf(hasher, "\x00%s\x00%s", lineRange.FileName(), lineRange.String())
+	}
+	return hex.EncodeToString(hasher.Sum(nil))
+}
@@ -16,6 +16,8 @@
 
 package diagnostics
 
+import "strings"
+
 // DiagnosticSeverity represents a severity of a Diagnostic.
 type DiagnosticSeverity uint8
 
@@ -43,3 +45,13 @@ func (ds DiagnosticSeverity) String() string {
 		return "UNKNOWN"
 	}
 }
+
+// DiagnosticSeverityFromString returns the DiagnosticSeverity with the given name. The comparison is case-insensitive.
+func DiagnosticSeverityFromString(s string) (DiagnosticSeverity, bool) {
+	for _, severity := range []DiagnosticSeverity{Internal, Hint, Info, Warning, Error} {
+		if strings.EqualFold(severity.String(), s) {
+			return severity, true
+		}
+	}
+	return Internal, false
+}
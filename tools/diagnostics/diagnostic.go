@@ -25,6 +25,7 @@ type Diagnostic interface {
 	DiagnosticInfo() DiagnosticInfo
 	Message() string
 	Properties() []DiagnosticProperty[any]
+	RelatedInformation() []DiagnosticRelatedInformation
 	String() string
 }
 
@@ -24,3 +24,24 @@ type Location interface {
 	LineRange() text.LineRange
 	TextRange() text.TextRange
 }
+
+type locationImpl struct {
+	lineRange text.LineRange
+	textRange text.TextRange
+}
+
+// NewLocation creates a Location from the given line range and text range.
+func NewLocation(lineRange text.LineRange, textRange text.TextRange) Location {
+	return &locationImpl{
+		lineRange: lineRange,
+		textRange: textRange,
+	}
+}
+
+func (l locationImpl) LineRange() text.LineRange {
+	return l.lineRange
+}
+
+func (l locationImpl) TextRange() text.TextRange {
+	return l.textRange
+}
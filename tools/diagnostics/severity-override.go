@@ -0,0 +1,50 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diagnostics
+
+type severityOverriddenDiagnostic struct {
+	Diagnostic
+	diagnosticInfo DiagnosticInfo
+}
+
+// WithSeverity returns a view of the diagnostic reported with the given severity. The code, message format,
+// location and properties of the original diagnostic are preserved.
+func WithSeverity(diagnostic Diagnostic, severity DiagnosticSeverity) Diagnostic {
+	info := diagnostic.DiagnosticInfo()
+	if info.Severity() == severity {
+		return diagnostic
+	}
+	if overridden, ok := diagnostic.(*severityOverriddenDiagnostic); ok {
+		diagnostic = overridden.Diagnostic
+	}
+	var code *string
+	if c := info.Code(); c != "" {
+		code = &c
+	}
+	return &severityOverriddenDiagnostic{
+		Diagnostic:     diagnostic,
+		diagnosticInfo: NewDiagnosticInfo(code, info.MessageFormat(), severity),
+	}
+}
+
+func (d *severityOverriddenDiagnostic) DiagnosticInfo() DiagnosticInfo {
+	return d.diagnosticInfo
+}
+
+func (d *severityOverriddenDiagnostic) String() string {
+	return diagnosticString(d)
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diagnostics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWithSeverity(t *testing.T) {
+	code := "BCW1001"
+	info := NewDiagnosticInfo(&code, "unused variable '%s'", Warning)
+	diagnostic := CreateDiagnostic(info, newExportTestDiagnostics()[0].Location(), "x")
+
+	escalated := WithSeverity(diagnostic, Error)
+	if escalated.DiagnosticInfo().Severity() != Error {
+		t.Errorf("severity = %s, want ERROR", escalated.DiagnosticInfo().Severity())
+	}
+	if escalated.DiagnosticInfo().Code() != "BCW1001" || escalated.Message() != "unused variable 'x'" {
+		t.Errorf("unexpected diagnostic: %s", escalated)
+	}
+	if want := strings.Replace(diagnostic.String(), "WARNING", "ERROR", 1); escalated.String() != want {
+		t.Errorf("String() = %q, want %q", escalated.String(), want)
+	}
+	if WithSeverity(diagnostic, Warning) != diagnostic {
+		t.Error("WithSeverity() with the same severity should return the diagnostic unchanged")
+	}
+	if restored := WithSeverity(escalated, Hint); restored.DiagnosticInfo().Severity() != Hint {
+		t.Errorf("severity = %s, want HINT", restored.DiagnosticInfo().Severity())
+	}
+}